@@ -0,0 +1,86 @@
+package goinject
+
+import (
+	"context"
+	"reflect"
+)
+
+const (
+	// WarningUncancellableContext report a provider requesting an InvocationContext that is resolved with a
+	// context that is never cancelled, such as context.Background() during the eager creation of singletons
+	WarningUncancellableContext WarningKind = "uncancellable-context"
+	// WarningResolutionOutsideInvoke report a binding of a context dependent scope (see NewContextualScope and
+	// NewTenantScope) resolved outside of an invocation, e.g. by a Provider function called with an unrelated context
+	WarningResolutionOutsideInvoke WarningKind = "resolution-outside-invoke"
+)
+
+type auditContextPropagationOption struct{}
+
+func (o *auditContextPropagationOption) apply(mod *configuration) error {
+	mod.auditContexts = true
+	return nil
+}
+
+// AuditContextPropagation return a debug InjectorOption reporting context plumbing mistakes as WarningEvent (see
+// WarningUncancellableContext and WarningResolutionOutsideInvoke). Each binding is reported once per kind.
+func AuditContextPropagation() InjectorOption {
+	return newInjectorOption("AuditContextPropagation", &auditContextPropagationOption{})
+}
+
+type invocationContextKey struct{}
+
+// withInvocation mark ctx as the context of an invocation, if context propagation is audited
+func (injector *Injector) withInvocation(ctx context.Context) context.Context {
+	if !injector.auditContexts {
+		return ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, invocationContextKey{}, true)
+}
+
+// auditResolution report the resolution of b in scope outside of an invocation if scope depends on the context
+func (injector *Injector) auditResolution(ctx context.Context, b *binding, scope Scope) {
+	if injector.auditContexts && isContextDependentScope(scope) &&
+		(ctx == nil || ctx.Value(invocationContextKey{}) == nil) {
+		injector.warn(WarningEvent{
+			Kind:     WarningResolutionOutsideInvoke,
+			Key:      b.key(),
+			Location: b.location,
+			Message:  "binding of scope " + b.scope + " is resolved outside of an invocation",
+		})
+	}
+}
+
+// auditCreation report the creation of an instance of b with a context that is never cancelled if its provider
+// requests an InvocationContext
+func (injector *Injector) auditCreation(ctx context.Context, b *binding) {
+	if injector.auditContexts && (ctx == nil || ctx.Done() == nil) && requestsInvocationContext(b.provider.Type()) {
+		injector.warn(WarningEvent{
+			Kind:     WarningUncancellableContext,
+			Key:      b.key(),
+			Location: b.location,
+			Message:  "provider requests an InvocationContext but is resolved with a context that is never cancelled",
+		})
+	}
+}
+
+func isContextDependentScope(scope Scope) bool {
+	switch scope.(type) {
+	case *contextualScope, *tenantScope:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestsInvocationContext tell if the provider function type has an InvocationContext argument
+func requestsInvocationContext(providerType reflect.Type) bool {
+	for i := 0; i < providerType.NumIn(); i++ {
+		if providerType.In(i) == invocationContextReflectType {
+			return true
+		}
+	}
+	return false
+}
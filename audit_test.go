@@ -0,0 +1,43 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditContextPropagation(t *testing.T) {
+	injector, err := NewInjector(
+		AuditContextPropagation(),
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+		Provide(func(_ InvocationContext) *Parent { return &Parent{} }),
+		Provide(func() *Request { return &Request{ID: 42} }, In("request")),
+	)
+	assert.Nil(t, err)
+
+	warnings := injector.Warnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, WarningUncancellableContext, warnings[0].Kind)
+	assert.Equal(t, KeyOf[*Parent](), warnings[0].Key)
+
+	requestCtx, cancel := context.WithCancel(WithContextualScopeEnabled(context.Background(), requestScopeKeyVal))
+	defer cancel()
+	defer ShutdownContextualScope(requestCtx, requestScopeKeyVal)
+	var getRequest Provider[*Request]
+	err = injector.Invoke(requestCtx, func(r *Request, get Provider[*Request]) {
+		assert.Equal(t, 42, r.ID)
+		getRequest = get
+	})
+	assert.Nil(t, err)
+	assert.Len(t, injector.Warnings(), 1)
+
+	r, err := getRequest(requestCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, 42, r.ID)
+	warnings = injector.Warnings()
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, WarningResolutionOutsideInvoke, warnings[1].Kind)
+	assert.Equal(t, KeyOf[*Request](), warnings[1].Key)
+	assert.Equal(t, "binding of scope request is resolved outside of an invocation", warnings[1].Message)
+}
@@ -0,0 +1,39 @@
+package goinject
+
+import "sync"
+
+// autoRegistered hold the options registered with AutoRegister, in registration order
+var autoRegistered struct {
+	mu      sync.Mutex
+	options []Option
+}
+
+// AutoRegister register options to be installed by CollectAutoRegistered. It is intended to be called from the
+// init function of leaf packages, so that they self-register their providers and the application module only
+// collects them (importing the packages, e.g. with blank imports, is enough).
+func AutoRegister(options ...Option) {
+	autoRegistered.mu.Lock()
+	defer autoRegistered.mu.Unlock()
+	autoRegistered.options = append(autoRegistered.options, options...)
+}
+
+type collectAutoRegisteredOption struct{}
+
+func (o *collectAutoRegisteredOption) apply(mod *configuration) error {
+	autoRegistered.mu.Lock()
+	options := append([]Option(nil), autoRegistered.options...)
+	autoRegistered.mu.Unlock()
+	for _, opt := range options {
+		if err := opt.apply(mod); err != nil {
+			return newInjectorConfigurationError("error while installing auto-registered options", err)
+		}
+	}
+	return nil
+}
+
+// CollectAutoRegistered return an Option installing the options registered with AutoRegister, in registration
+// order. Registered Provide options keep their source location, so configuration problems point to the
+// registering package.
+func CollectAutoRegistered() Option {
+	return &collectAutoRegisteredOption{}
+}
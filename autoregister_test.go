@@ -0,0 +1,38 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectAutoRegistered(t *testing.T) {
+	autoRegistered.mu.Lock()
+	saved := autoRegistered.options
+	autoRegistered.options = nil
+	autoRegistered.mu.Unlock()
+	defer func() {
+		autoRegistered.mu.Lock()
+		autoRegistered.options = saved
+		autoRegistered.mu.Unlock()
+	}()
+
+	AutoRegister(Provide(func() *Child { return &Child{} }))
+	AutoRegister(Provide(func(c *Child) *Parent { return &Parent{} }))
+
+	injector, err := NewInjector(CollectAutoRegistered())
+	assert.Nil(t, err)
+	err = injector.Invoke(context.Background(), func(p *Parent, c *Child) {
+		assert.NotNil(t, p)
+		assert.NotNil(t, c)
+	})
+	assert.Nil(t, err)
+
+	t.Run("Should report problems of auto-registered options", func(t *testing.T) {
+		AutoRegister(Provide(nil))
+		_, err := NewInjector(CollectAutoRegistered())
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.Contains(t, err.(*ConfigurationReport).Problems[0].Location, "autoregister_test.go")
+	})
+}
@@ -0,0 +1,58 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+)
+
+type freshInBatchAnnotation struct{}
+
+func (a *freshInBatchAnnotation) apply(b *binding) error {
+	b.freshInBatch = true
+	return nil
+}
+
+// FreshInBatch return an annotation preventing the instances of a PerLookUp binding to be shared between
+// the functions of an InvokeAllFns batch. Instances of DestroyOnReturn bindings are never shared.
+func FreshInBatch() Annotation {
+	return &freshInBatchAnnotation{}
+}
+
+// sharedInBatch return true if the instances of the binding are shared between the functions of a batch: instances
+// destroyed when a function returns cannot be given to the next ones
+func (b *binding) sharedInBatch() bool {
+	return b.scope == PerLookUp && !b.freshInBatch && !b.destroyOnExit
+}
+
+type batchContextKey struct{}
+
+// batchRegistry return the registry of PerLookUp instances shared in the current batch, if any
+func batchRegistry(ctx context.Context) *instanceRegistry {
+	if ctx == nil {
+		return nil
+	}
+	registry, _ := ctx.Value(batchContextKey{}).(*instanceRegistry)
+	return registry
+}
+
+// InvokeAllFns invoke each function in order like Invoke, stopping at the first error.
+// PerLookUp instances are created once for the whole batch and shared between the functions (unless their
+// binding is annotated with FreshInBatch or DestroyOnReturn), which is useful for startup routines needing the same moderately
+// expensive dependencies.
+func (injector *Injector) InvokeAllFns(ctx context.Context, fns ...any) error {
+	for i, fn := range fns {
+		if _, err := validateInvokedFunction(fn); err != nil {
+			return fmt.Errorf("invalid function #%d: %w", i, err)
+		}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = context.WithValue(ctx, batchContextKey{}, newInstanceRegistry())
+	for i, fn := range fns {
+		if err := injector.Invoke(ctx, fn); err != nil {
+			return fmt.Errorf("invocation of function #%d failed: %w", i, err)
+		}
+	}
+	return nil
+}
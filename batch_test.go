@@ -0,0 +1,62 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type SchemaDescriptor struct{}
+
+type RequestBuffer struct{}
+
+func TestInvokeAllFns(t *testing.T) {
+	var descriptors, buffers int
+	injector, err := NewInjector(
+		Provide(func() *SchemaDescriptor {
+			descriptors++
+			return &SchemaDescriptor{}
+		}, In(PerLookUp)),
+		Provide(func() *RequestBuffer {
+			buffers++
+			return &RequestBuffer{}
+		}, In(PerLookUp), FreshInBatch()),
+	)
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	t.Run("Should share per lookup instances within the batch", func(t *testing.T) {
+		descriptors, buffers = 0, 0
+		var first, second *SchemaDescriptor
+		err := injector.InvokeAllFns(ctx,
+			func(d *SchemaDescriptor, _ *RequestBuffer) { first = d },
+			func(d *SchemaDescriptor, _ *RequestBuffer) { second = d },
+		)
+		assert.Nil(t, err)
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, descriptors)
+		assert.Equal(t, 2, buffers)
+
+		assert.Nil(t, injector.Invoke(ctx, func(_ *SchemaDescriptor) {}))
+		assert.Equal(t, 2, descriptors)
+	})
+
+	t.Run("Should stop at first error", func(t *testing.T) {
+		called := false
+		err := injector.InvokeAllFns(ctx,
+			func() error { return errors.New("migration failed") },
+			func() { called = true },
+		)
+		assert.EqualError(t, err, "invocation of function #0 failed: invokation returned error: migration failed")
+		assert.False(t, called)
+	})
+
+	t.Run("Should validate all functions before invoking", func(t *testing.T) {
+		called := false
+		err := injector.InvokeAllFns(ctx, func() { called = true }, true)
+		assert.ErrorContains(t, err, "invalid function #1")
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,55 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// InvokeBestEffort execute the parameter function like Invoke, except that arguments (and fields of Params
+// arguments) that cannot be resolved are set to their zero value instead of failing the invocation.
+// Resolution errors are returned as warnings, the returned error is only set if function is invalid or returned
+// an error (or an InvokeMiddleware did). It is intended for diagnostic endpoints that must render whatever part of
+// the system is wired.
+func (injector *Injector) InvokeBestEffort(ctx context.Context, function any) ([]error, error) {
+	if err := injector.checkNotStopped("InvokeBestEffort"); err != nil {
+		return nil, err
+	}
+	fvalue, err := validateInvokedFunction(function)
+	if err != nil {
+		return nil, err
+	}
+	ftype := fvalue.Type()
+	plans := planOf(ftype)
+	var warnings []error
+	err = injector.invoke(ctx, fvalue, func(ctx context.Context) ([]reflect.Value, error) {
+		invocationID, _ := InvocationIDOf(ctx)
+		tolerate := func(err error) bool {
+			warnings = append(warnings, decorateError(injector.errorDecorators, newInvocationError(invocationID, err)))
+			return true
+		}
+		in := make([]reflect.Value, ftype.NumIn())
+		for i := 0; i < ftype.NumIn(); i++ {
+			if err := checkResolutionContext(ctx); err != nil {
+				return nil, err
+			}
+			argType := ftype.In(i)
+			argCtx := ctx
+			if !isContextualArgument(argType) {
+				argCtx = withPendingInjectionPoint(ctx, InjectionPoint{target: ftype, position: i})
+			}
+			if EmbedsParams(argType) {
+				in[i], _ = injector.createEmbeddedParams(argCtx, argType, argumentPlans(plans, i), tolerate)
+				continue
+			}
+			var err error
+			in[i], err = injector.getInstanceOfAnnotatedType(argCtx, argType, "", false)
+			if err != nil {
+				tolerate(fmt.Errorf("failed to resolve function argument #%d: %w", i, err))
+				in[i] = reflect.Zero(argType)
+			}
+		}
+		return fvalue.Call(in), nil
+	})
+	return warnings, err
+}
@@ -0,0 +1,86 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type DiagnosticParams struct {
+	Params
+	Parent *Parent `inject:""`
+	Child  *Child  `inject:""`
+	Color  *Color  `inject:"red,optional"`
+}
+
+func TestInvokeBestEffort(t *testing.T) {
+	brokenErr := errors.New("broken provider")
+	injector, err := NewInjector(
+		Provide(func() *Parent { return &Parent{} }),
+		Provide(func() (*Child, error) { return nil, brokenErr }, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	t.Run("Should set zero values for failed params fields", func(t *testing.T) {
+		called := false
+		warnings, err := injector.InvokeBestEffort(ctx, func(params DiagnosticParams) {
+			called = true
+			assert.NotNil(t, params.Parent)
+			assert.Nil(t, params.Child)
+			assert.Nil(t, params.Color)
+		})
+		assert.Nil(t, err)
+		assert.True(t, called)
+		assert.Len(t, warnings, 1)
+		assert.ErrorIs(t, warnings[0], brokenErr)
+	})
+
+	t.Run("Should set zero values for failed arguments", func(t *testing.T) {
+		warnings, err := injector.InvokeBestEffort(ctx, func(p *Parent, c *Child, s Shape) {
+			assert.NotNil(t, p)
+			assert.Nil(t, c)
+			assert.Nil(t, s)
+		})
+		assert.Nil(t, err)
+		assert.Len(t, warnings, 2)
+		assert.ErrorIs(t, warnings[0], brokenErr)
+		assert.ErrorContains(t, warnings[1], "did not found binding, expected one")
+	})
+
+	t.Run("Should return function error", func(t *testing.T) {
+		fnErr := errors.New("render failed")
+		_, err := injector.InvokeBestEffort(ctx, func() error { return fnErr })
+		assert.ErrorIs(t, err, fnErr)
+	})
+
+	t.Run("Should apply middlewares and notify failures like Invoke", func(t *testing.T) {
+		var wrapped []string
+		var failures []InvocationFailedEvent
+		injector, err := NewInjector(
+			WithInvokeMiddleware(func(ctx context.Context, info InvokeInfo, next func(ctx context.Context) error) error {
+				wrapped = append(wrapped, info.Function)
+				return next(ctx)
+			}),
+			WithObserver(func(event Event) {
+				if failed, ok := event.(InvocationFailedEvent); ok {
+					failures = append(failures, failed)
+				}
+			}),
+		)
+		assert.Nil(t, err)
+		fnErr := errors.New("render failed")
+		_, err = injector.InvokeBestEffort(ctx, func() error { return fnErr })
+		assert.ErrorIs(t, err, fnErr)
+		assert.Len(t, wrapped, 1)
+		assert.Len(t, failures, 1)
+		assert.ErrorIs(t, failures[0].Err, fnErr)
+	})
+
+	t.Run("Should validate function", func(t *testing.T) {
+		_, err := injector.InvokeBestEffort(ctx, nil)
+		assert.IsType(t, err, &invalidInputError{})
+	})
+}
@@ -4,20 +4,74 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync/atomic"
 )
 
 // binding defines a type mapped to a more concrete type
 type binding struct {
-	typeof        reflect.Type
-	provider      reflect.Value
-	providedType  reflect.Type
-	annotatedWith string
-	scope         string
-	destroyMethod func(value reflect.Value)
+	typeof         reflect.Type
+	provider       reflect.Value
+	providedType   reflect.Type
+	annotatedWith  string
+	scope          string
+	explicitScope  bool // the scope is set by In, otherwise DefaultScope applies
+	destroyMethod  func(value reflect.Value)
+	order          int                            // registration order
+	cacheKey       func(ctx context.Context) any  // instances are memoized by key within the scope if set
+	maxCached      int                            // maximum number of instances memoized by key, 0 means unlimited
+	validity       func(value reflect.Value) bool // instances are re-created when invalid if set
+	nonCritical    bool                           // eager creation failure does not fail the injector creation
+	modules        []string                       // names of the modules that installed the binding, outermost first
+	location       string                         // source location of the Provide call
+	fallback       bool                           // only bound if there is no other binding with the same key
+	grouped        bool                           // member of a group, see IntoGroup
+	expandFields   bool                           // tagged fields of the provided struct are bound, see ExpandFields
+	refreshable    bool                           // re-created when one of its dependencies is refreshed
+	creationSlots  chan struct{}                  // limit concurrent creations if set, see MaxConcurrentCreations
+	coalescer      *coalescer                     // share concurrent creations if set, see Coalesced
+	freshInBatch   bool                           // PerLookUp instances are not shared in InvokeAllFns batches
+	destroyOnExit  bool                           // destroyed when the invocation returns, see DestroyOnReturn
+	weight         int                            // weight used by the Weighted selector, see Weight
+	proxy          reflect.Value                  // func(*CallGuard, T) T applied at injection if set, see WithProxy
+	callPolicies   []callPolicy                   // policies applied by the proxy CallGuard, outermost first
+	lazyProxy      *lazyProxy                     // create the value injected in place of the instance, see LazyProxy
+	removed        atomic.Bool                    // set by Injector.Remove
+	destroyCurrent atomic.Pointer[func()]         // destroy the singleton instance at most once, see Swap and StopUnit
+	deprecation    string                         // deprecation message, see Deprecated
+	sensitive      bool                           // resolutions are audited, see Sensitive
+	allowNil       bool                           // nil instances are not rejected by StrictNil, see AllowNil
+	firstCalls     firstCalls                     // first call stacks of proxy methods, see CheckInterfaceBindings
+	activeInScope  string                         // only resolved while this scope is active if set, see WhenInScope
+	toggle         string                         // feature toggle enabling the binding if set, see Toggleable
+	fake           reflect.Value                  // constructor replacing the provider in tests, see FakeInTests
+	unit           string                         // name of the unit the binding belongs to, see Unit
+	conversionFrom *BindingKey                    // key of the converted binding if set, see Convert
+	subscribes     []reflect.Type                 // event types declared with Subscribe
+	shutdownGroup  string                         // see ShutdownGroup
+	shutdownOrder  int                            // destroy callbacks with lower orders run first, see ShutdownGroup
+	sizer          func(reflect.Value) int64      // estimate the memory size of instances if set, see Sizer
+	onStart        lifecycleHook
+	onStop         lifecycleHook
+	readinessCheck lifecycleHook
+	phase          string
 }
 
 func (b *binding) create(ctx context.Context, injector *Injector) (reflect.Value, error) {
-	res, err := injector.callFunctionWithArgumentInstance(ctx, b.provider)
+	if key, ok := b.coalescingKey(ctx); b.coalescer != nil && ok {
+		return b.coalescer.do(ctx, key, func() (reflect.Value, error) {
+			return b.doCreate(ctx, injector)
+		})
+	}
+	return b.doCreate(ctx, injector)
+}
+
+func (b *binding) doCreate(ctx context.Context, injector *Injector) (reflect.Value, error) {
+	release, err := b.acquireCreationSlot(ctx)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("failed to create instance of type %q: %w", b.providedType.String(), err)
+	}
+	defer release()
+	res, err := injector.callFunctionWithArgumentInstance(ctx, b.provider, b.providedType)
 	if err != nil {
 		return reflect.Value{},
 			fmt.Errorf("failed to call provider function for type %q: %w", b.providedType.String(), err)
@@ -29,8 +83,23 @@ func (b *binding) create(ctx context.Context, injector *Injector) (reflect.Value
 		}
 	}
 	if err != nil {
-		return res[0], fmt.Errorf("provider for type %q returned error: %w", b.providedType.String(), err)
+		providerErr := newProviderError(b.providedType, err)
+		if len(providerErr.causes) > 1 {
+			injector.notify(ProviderErrorsEvent{Key: b.key(), Errs: providerErr.causes})
+		}
+		return res[0], providerErr
+	} else if injector.strictNil && !b.allowNil && isNilInstance(res[0]) {
+		return res[0], fmt.Errorf("provider for %s at %s returned nil without error", b.providedType, b.location)
+	} else if injector.checkInterfaces {
+		return res[0], b.checkInterfaceInstance(res[0])
 	} else {
 		return res[0], nil
 	}
 }
+
+// destroySingleton call the destroy method of the singleton instance, unless it was already destroyed
+func (b *binding) destroySingleton() {
+	if destroy := b.destroyCurrent.Swap(nil); destroy != nil {
+		(*destroy)()
+	}
+}
@@ -0,0 +1,86 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// BoundProvider[T] may be requested instead of T (as a function argument or a Params field) to resolve T later,
+// against the context of the injection instead of a context given at call time: callbacks executed on goroutines
+// that lack the original scopes (e.g. a request scope) resolve the same instances as the injection would have.
+// The cancellation of the injection context is ignored, so that the callbacks can run after the request returned.
+//
+// The contextual scopes of the injection context are kept alive as with PropagateScopes: their shutdown, and thus
+// the destroy callbacks of their instances, is deferred until Release is called, which must be done once the
+// provider is not used anymore. A BoundProvider injected outside of any contextual scope (e.g. in a singleton) does
+// not need to be released.
+type BoundProvider[T any] struct {
+	ctx        context.Context
+	injector   *Injector
+	annotation string
+	optional   bool
+}
+
+// Get resolve the instance against the injection context
+func (p BoundProvider[T]) Get() (T, error) {
+	var res T
+	if p.injector == nil {
+		return res, errors.New("BoundProvider was not created by the injector")
+	}
+	instance, err := p.injector.getInstanceOfAnnotatedType(p.ctx, reflect.TypeFor[T](), p.annotation, p.optional)
+	if err != nil || !instance.IsValid() {
+		return res, err
+	}
+	res, _ = instance.Interface().(T)
+	return res, nil
+}
+
+// Release release the scopes of the injection context, see ReleaseScopes
+func (p BoundProvider[T]) Release() {
+	if p.ctx != nil {
+		ReleaseScopes(p.ctx)
+	}
+}
+
+// boundProviderValue is implemented by pointers to BoundProvider types
+type boundProviderValue interface {
+	boundType() reflect.Type
+	bind(injector *Injector, ctx context.Context, annotation string, optional bool)
+}
+
+var boundProviderValueReflectType = reflect.TypeFor[boundProviderValue]()
+
+func (p *BoundProvider[T]) boundType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (p *BoundProvider[T]) bind(injector *Injector, ctx context.Context, annotation string, optional bool) {
+	p.injector = injector
+	p.ctx = ctx
+	p.annotation = annotation
+	p.optional = optional
+}
+
+// isBoundProviderType tell if t is a BoundProvider type
+func isBoundProviderType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(boundProviderValueReflectType)
+}
+
+// boundProviderElemType return the type provided by the BoundProvider type t
+func boundProviderElemType(t reflect.Type) reflect.Type {
+	return reflect.New(t).Interface().(boundProviderValue).boundType()
+}
+
+// createBoundProviderValue create a BoundProvider of type t capturing ctx
+func (injector *Injector) createBoundProviderValue(
+	ctx context.Context,
+	t reflect.Type,
+	annotation string,
+	optional bool,
+) reflect.Value {
+	ctx = PropagateScopes(context.WithoutCancel(withNewResolutionPath(ctx)))
+	res := reflect.New(t)
+	res.Interface().(boundProviderValue).bind(injector, ctx, annotation, optional)
+	return res.Elem()
+}
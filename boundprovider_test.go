@@ -0,0 +1,48 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundProvider(t *testing.T) {
+	var destroyed []*Request
+	injector, err := NewInjector(
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+		Provide(func() *Request { return &Request{ID: 1} }, In("request"),
+			WithDestroy(func(r *Request) { destroyed = append(destroyed, r) })),
+	)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(WithContextualScopeEnabled(context.Background(), requestScopeKeyVal))
+	var request *Request
+	var callback func()
+	err = injector.Invoke(ctx, func(r *Request, provider BoundProvider[*Request]) {
+		request = r
+		callback = func() {
+			defer provider.Release()
+			later, err := provider.Get()
+			assert.Nil(t, err)
+			assert.Same(t, request, later)
+		}
+	})
+	assert.Nil(t, err)
+	cancel()
+	ShutdownContextualScope(ctx, requestScopeKeyVal)
+	assert.Empty(t, destroyed)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		callback()
+	}()
+	<-done
+	assert.Equal(t, []*Request{request}, destroyed)
+
+	t.Run("Zero BoundProvider should fail", func(t *testing.T) {
+		_, err := BoundProvider[*Request]{}.Get()
+		assert.ErrorContains(t, err, "not created by the injector")
+	})
+}
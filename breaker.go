@@ -0,0 +1,152 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched (using errors.Is) by errors returned by calls rejected by an open circuit breaker
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // calls are let through
+	CircuitOpen     CircuitState = "open"      // calls are rejected with ErrCircuitOpen
+	CircuitHalfOpen CircuitState = "half-open" // a single trial call is let through
+)
+
+// CircuitBreakerPolicy configure WithCircuitBreaker
+type CircuitBreakerPolicy struct {
+	FailureThreshold int                  // consecutive failures opening the breaker, 5 if not set
+	OpenDuration     time.Duration        // time the breaker stays open before a trial call, 30s if not set
+	IsFailure        func(err error) bool // tell if the error of a call is a failure, any error by default
+}
+
+// CircuitStateChangedEvent is notified when the circuit breaker of a binding changes state
+type CircuitStateChangedEvent struct {
+	Key  BindingKey
+	From CircuitState
+	To   CircuitState
+}
+
+func (CircuitStateChangedEvent) isEvent() {}
+
+// circuitBreaker hold the state of the circuit breaker of a binding
+type circuitBreaker struct {
+	policy CircuitBreakerPolicy
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int       // consecutive failures while closed
+	openedAt time.Time // time the breaker was opened
+	trial    bool      // true while the trial call of the half-open state is running
+}
+
+func newCircuitBreaker(policy CircuitBreakerPolicy) *circuitBreaker {
+	if policy.FailureThreshold <= 0 {
+		policy.FailureThreshold = 5
+	}
+	if policy.OpenDuration <= 0 {
+		policy.OpenDuration = 30 * time.Second
+	}
+	if policy.IsFailure == nil {
+		policy.IsFailure = func(err error) bool { return err != nil }
+	}
+	return &circuitBreaker{policy: policy, state: CircuitClosed}
+}
+
+func (cb *circuitBreaker) call(
+	ctx context.Context,
+	guard *CallGuard,
+	method string,
+	call func(ctx context.Context) error,
+) error {
+	if !cb.acquire(guard) {
+		return fmt.Errorf("call of %s on binding %s rejected: %w", method, guard.key, ErrCircuitOpen)
+	}
+	err := call(ctx)
+	cb.release(guard, cb.policy.IsFailure(err))
+	return err
+}
+
+// acquire tell if a call is let through, moving the open breaker to half-open once its open duration elapsed
+func (cb *circuitBreaker) acquire(guard *CallGuard) bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case CircuitOpen:
+		if time.Since(cb.openedAt) < cb.policy.OpenDuration {
+			return false
+		}
+		cb.setState(guard, CircuitHalfOpen)
+		cb.trial = true
+		return true
+	case CircuitHalfOpen:
+		if cb.trial {
+			return false
+		}
+		cb.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (cb *circuitBreaker) release(guard *CallGuard, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch {
+	case cb.state == CircuitHalfOpen && failed:
+		cb.trial = false
+		cb.open(guard)
+	case cb.state == CircuitHalfOpen:
+		cb.trial = false
+		cb.failures = 0
+		cb.setState(guard, CircuitClosed)
+	case failed:
+		cb.failures++
+		if cb.failures >= cb.policy.FailureThreshold {
+			cb.open(guard)
+		}
+	default:
+		cb.failures = 0
+	}
+}
+
+func (cb *circuitBreaker) open(guard *CallGuard) {
+	cb.openedAt = time.Now()
+	cb.failures = 0
+	cb.setState(guard, CircuitOpen)
+}
+
+func (cb *circuitBreaker) setState(guard *CallGuard, state CircuitState) {
+	if cb.state == state {
+		return
+	}
+	event := CircuitStateChangedEvent{Key: guard.key, From: cb.state, To: state}
+	cb.state = state
+	guard.injector.notify(event)
+}
+
+type circuitBreakerAnnotation struct {
+	policy CircuitBreakerPolicy
+}
+
+func (a *circuitBreakerAnnotation) apply(b *binding) error {
+	b.callPolicies = append(b.callPolicies, newCircuitBreaker(a.policy).call)
+	return nil
+}
+
+// WithCircuitBreaker return an annotation applying a circuit breaker to the method calls of the binding proxy
+// (see WithProxy): after FailureThreshold consecutive failures the breaker opens and calls fail with
+// ErrCircuitOpen, once OpenDuration elapsed a trial call is let through and closes the breaker if it succeeds.
+// The breaker is shared by all the instances of the binding, state changes are notified to observers as
+// CircuitStateChangedEvent.
+func WithCircuitBreaker(policy CircuitBreakerPolicy) Annotation {
+	return &circuitBreakerAnnotation{policy: policy}
+}
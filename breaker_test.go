@@ -0,0 +1,100 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Inventory interface {
+	Stock(ctx context.Context, item string) (int, error)
+}
+
+type flakyInventory struct {
+	err   error
+	calls int
+}
+
+func (i *flakyInventory) Stock(_ context.Context, _ string) (int, error) {
+	i.calls++
+	if i.err != nil {
+		return 0, i.err
+	}
+	return 3, nil
+}
+
+// inventoryProxy is the facade running the Inventory methods through the CallGuard
+type inventoryProxy struct {
+	guard  *CallGuard
+	target Inventory
+}
+
+func (p *inventoryProxy) Stock(ctx context.Context, item string) (stock int, err error) {
+	err = p.guard.Call(ctx, "Stock", func(ctx context.Context) error {
+		stock, err = p.target.Stock(ctx, item)
+		return err
+	})
+	return stock, err
+}
+
+func newInventoryProxy(guard *CallGuard, target Inventory) Inventory {
+	return &inventoryProxy{guard: guard, target: target}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	unavailable := errors.New("inventory unavailable")
+	inventory := &flakyInventory{err: unavailable}
+	var events []Event
+	injector, err := NewInjector(
+		WithObserver(func(event Event) {
+			events = append(events, event)
+		}),
+		Provide(func() *flakyInventory { return inventory }, As(Type[Inventory]()),
+			WithProxy(newInventoryProxy),
+			WithCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 2, OpenDuration: 20 * time.Millisecond})),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(i Inventory) {
+		assert.IsType(t, &inventoryProxy{}, i)
+		for n := 0; n < 2; n++ {
+			_, err := i.Stock(ctx, "book")
+			assert.ErrorIs(t, err, unavailable)
+		}
+		_, err := i.Stock(ctx, "book")
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, 2, inventory.calls)
+
+		time.Sleep(30 * time.Millisecond)
+		inventory.err = nil
+		stock, err := i.Stock(ctx, "book")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, stock)
+	})
+	assert.Nil(t, err)
+	key := KeyOf[Inventory]()
+	assert.Equal(t, []Event{
+		CircuitStateChangedEvent{Key: key, From: CircuitClosed, To: CircuitOpen},
+		CircuitStateChangedEvent{Key: key, From: CircuitOpen, To: CircuitHalfOpen},
+		CircuitStateChangedEvent{Key: key, From: CircuitHalfOpen, To: CircuitClosed},
+	}, events)
+
+	t.Run("Call policies should require a proxy", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *flakyInventory { return inventory }, As(Type[Inventory]()),
+				WithCircuitBreaker(CircuitBreakerPolicy{})),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+	})
+
+	t.Run("WithProxy should take and return the binding type", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *flakyInventory { return inventory }, WithProxy(newInventoryProxy)),
+		)
+		assert.ErrorContains(t, err, "proxy of WithProxy must take and return the binding type")
+	})
+}
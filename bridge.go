@@ -0,0 +1,62 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type bridgeOption struct {
+	other       *Injector
+	target      AsType
+	annotations []Annotation
+	location    string // source location of the BridgeFrom call
+}
+
+func (o *bridgeOption) apply(mod *configuration) error {
+	if o.other == nil {
+		return newInjectorConfigurationError("cannot bridge from nil injector", nil)
+	}
+	t := o.target.getType()
+	name, err := annotationName(o.annotations)
+	if err != nil {
+		return newConfigurationProblemError(InvalidProvider, "", mod.modules, o.location,
+			fmt.Errorf("cannot bridge %s: %w", t, err))
+	}
+	option := &provideOption{
+		constructor: delegatingProvider(t, func(ctx context.Context) (reflect.Value, error) {
+			return o.other.getInstanceOfAnnotatedType(ctx, t, name, false)
+		}),
+		annotations: append(append([]Annotation{}, o.annotations...), In(PerLookUp)),
+		location:    o.location,
+	}
+	return option.apply(mod)
+}
+
+// delegatingProvider return a provider of t, func(InvocationContext) (t, error), returning the instance resolved
+// by resolve in another injector
+func delegatingProvider(t reflect.Type, resolve func(ctx context.Context) (reflect.Value, error)) any {
+	providerType := reflect.FuncOf(
+		[]reflect.Type{invocationContextReflectType},
+		[]reflect.Type{t, errorReflectType},
+		false,
+	)
+	return reflect.MakeFunc(providerType, func(args []reflect.Value) []reflect.Value {
+		ctx, _ := args[0].Interface().(context.Context)
+		instance, err := resolve(withNewResolutionPath(ctx))
+		if err != nil {
+			return []reflect.Value{reflect.Zero(t), reflect.ValueOf(&err).Elem()}
+		}
+		if !instance.IsValid() {
+			instance = reflect.Zero(t)
+		}
+		return []reflect.Value{instance.Convert(t), reflect.Zero(errorReflectType)}
+	}).Interface()
+}
+
+// BridgeFrom return an Option binding the type t (with the given annotations, e.g. Named) to the binding of the
+// same key in the other injector, which resolves it with its own scopes: the local binding never caches instances.
+// It is useful during migrations, when two containers must temporarily coexist in one process.
+func BridgeFrom(other *Injector, t AsType, annotations ...Annotation) Option {
+	return &bridgeOption{other: other, target: t, annotations: annotations, location: callerLocation()}
+}
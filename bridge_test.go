@@ -0,0 +1,56 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBridgeFrom(t *testing.T) {
+	legacy, err := NewInjector(
+		Provide(func() *Parent { return &Parent{} }),
+		Provide(func() *Child { return &Child{} }, Named("legacy"), In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+	defer legacy.Shutdown()
+
+	injector, err := NewInjector(
+		BridgeFrom(legacy, Type[*Parent]()),
+		BridgeFrom(legacy, Type[*Child](), Named("legacy")),
+		BridgeFrom(legacy, Type[*AppConfig](), Named("missing"), In(Singleton)),
+	)
+	assert.Nil(t, err)
+
+	type params struct {
+		Params
+		Child *Child `inject:"legacy"`
+	}
+	ctx := context.Background()
+	var parents []*Parent
+	var children []*Child
+	for i := 0; i < 2; i++ {
+		err = injector.Invoke(ctx, func(parent *Parent, p params) {
+			parents = append(parents, parent)
+			children = append(children, p.Child)
+		})
+		assert.Nil(t, err)
+	}
+	assert.Same(t, parents[0], parents[1])
+	assert.NotSame(t, children[0], children[1])
+
+	type configParams struct {
+		Params
+		Config *AppConfig `inject:"missing"`
+	}
+	err = injector.Invoke(ctx, func(_ configParams) {
+		assert.Fail(t, "should not be reached")
+	})
+	assert.ErrorContains(t, err, "did not found binding")
+
+	_, err = NewInjector(BridgeFrom(nil, Type[*Parent]()))
+	assert.ErrorContains(t, err, "cannot bridge from nil injector")
+
+	_, err = NewInjector(BridgeFrom(legacy, Type[*Parent](), As(Type[any]())))
+	assert.ErrorContains(t, err, "cannot bridge *goinject.Parent: As(interface {}) cannot be used here")
+}
@@ -0,0 +1,87 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+type cachedByAnnotation struct {
+	keyFn func(ctx context.Context) any
+}
+
+func (a *cachedByAnnotation) apply(b *binding) error {
+	if a.keyFn == nil {
+		return newInjectorConfigurationError("argument of CachedBy cannot be nil", nil)
+	}
+	b.cacheKey = a.keyFn
+	return nil
+}
+
+// CachedBy return an annotation that memoize instances of the binding per key derived from the resolution
+// context, within the binding scope: one instance is created per distinct key (e.g. per tenant ID inside the
+// singleton scope) and all of them are destroyed when the scope is shut down.
+// Returned keys must be comparable.
+// Note that a binding in PerLookUp scope never reuse instances, even when cached by key.
+func CachedBy(keyFn func(ctx context.Context) any) Annotation {
+	return &cachedByAnnotation{keyFn: keyFn}
+}
+
+type maxCachedInstancesAnnotation struct {
+	maxInstances int
+}
+
+func (a *maxCachedInstancesAnnotation) apply(b *binding) error {
+	if a.maxInstances <= 0 {
+		return newInjectorConfigurationError("argument of MaxCachedInstances must be positive", nil)
+	}
+	b.maxCached = a.maxInstances
+	return nil
+}
+
+// MaxCachedInstances return an annotation limiting the number of instances kept by a binding annotated with
+// CachedBy within its scope (e.g. the number of tenants). Resolving a new key beyond the limit return an
+// *InstanceQuotaError.
+func MaxCachedInstances(maxInstances int) Annotation {
+	return &maxCachedInstancesAnnotation{maxInstances: maxInstances}
+}
+
+var instanceRegistryReflectType = reflect.TypeFor[*instanceRegistry]()
+
+// resolveCachedInstance resolve the instance of a binding annotated with CachedBy: the scope hold a registry
+// of instances by key instead of a single instance
+func resolveCachedInstance(
+	ctx context.Context,
+	scope Scope,
+	binding *binding,
+	instanceCreator func() (Instance, error),
+) (Instance, error) {
+	key := binding.cacheKey(ctx)
+	if key != nil && !reflect.TypeOf(key).Comparable() {
+		return Instance{}, newInjectionError(binding.typeof, binding.annotatedWith,
+			fmt.Errorf("cache key of type %T is not comparable", key))
+	}
+	holder, err := scope.ResolveBinding(ctx, binding, func() (Instance, error) {
+		return Instance(reflect.ValueOf(newInstanceRegistry())), nil
+	})
+	if err != nil {
+		return Instance{}, err
+	}
+	holderValue := reflect.Value(holder)
+	if !holderValue.IsValid() || holderValue.Type() != instanceRegistryReflectType {
+		return Instance{}, newInjectionError(binding.typeof, binding.annotatedWith,
+			fmt.Errorf("scope %q did not return the instance cache", binding.scope))
+	}
+	instance, err := holderValue.Interface().(*instanceRegistry).resolveWithQuota(
+		cacheKey{key}, binding.maxCached, instanceCreator)
+	if errors.Is(err, errQuotaExceeded) {
+		return Instance{}, newInstanceQuotaError(binding, binding.maxCached)
+	}
+	return instance, err
+}
+
+// cacheKey wrap keys returned by CachedBy functions so that they never collide with bindings
+type cacheKey struct {
+	value any
+}
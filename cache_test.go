@@ -0,0 +1,111 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantKey struct{}
+
+type TenantClient struct {
+	tenant string
+}
+
+func TestCachedBy(t *testing.T) {
+	assert.NotPanics(t, func() {
+		created := 0
+		destroyed := 0
+		injector, err := NewInjector(
+			Provide(func(ctx InvocationContext) *TenantClient {
+				created++
+				tenant, _ := ctx.Value(tenantKey{}).(string)
+				return &TenantClient{tenant: tenant}
+			}, CachedBy(func(ctx context.Context) any {
+				return ctx.Value(tenantKey{})
+			}), WithDestroy(func(_ *TenantClient) {
+				destroyed++
+			})),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, created)
+
+		acmeCtx := context.WithValue(context.Background(), tenantKey{}, "acme")
+		globexCtx := context.WithValue(context.Background(), tenantKey{}, "globex")
+		var acme1, acme2, globex *TenantClient
+		err = injector.Invoke(acmeCtx, func(c *TenantClient) { acme1 = c })
+		assert.Nil(t, err)
+		err = injector.Invoke(globexCtx, func(c *TenantClient) { globex = c })
+		assert.Nil(t, err)
+		err = injector.Invoke(acmeCtx, func(c *TenantClient) { acme2 = c })
+		assert.Nil(t, err)
+
+		assert.Same(t, acme1, acme2)
+		assert.NotSame(t, acme1, globex)
+		assert.Equal(t, "acme", acme1.tenant)
+		assert.Equal(t, "globex", globex.tenant)
+		assert.Equal(t, 2, created)
+
+		injector.Shutdown()
+		assert.Equal(t, 2, destroyed)
+	})
+
+	t.Run("Should return error if key is not comparable", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func() *TenantClient {
+				return &TenantClient{}
+			}, CachedBy(func(_ context.Context) any {
+				return []string{"not", "comparable"}
+			})),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func(_ *TenantClient) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorContains(t, err, "cache key of type []string is not comparable")
+	})
+
+	t.Run("CachedBy should not accept nil", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *TenantClient {
+				return &TenantClient{}
+			}, CachedBy(nil)),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+	})
+
+	t.Run("MaxCachedInstances should limit the number of keys", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func(ctx InvocationContext) *TenantClient {
+				tenant, _ := ctx.Value(tenantKey{}).(string)
+				return &TenantClient{tenant: tenant}
+			}, CachedBy(func(ctx context.Context) any {
+				return ctx.Value(tenantKey{})
+			}), MaxCachedInstances(1)),
+		)
+		assert.Nil(t, err)
+
+		acmeCtx := context.WithValue(context.Background(), tenantKey{}, "acme")
+		globexCtx := context.WithValue(context.Background(), tenantKey{}, "globex")
+		assert.Nil(t, injector.Invoke(acmeCtx, func(_ *TenantClient) {}))
+		assert.Nil(t, injector.Invoke(acmeCtx, func(_ *TenantClient) {}))
+		err = injector.Invoke(globexCtx, func(_ *TenantClient) {
+			assert.Fail(t, "should not be reached")
+		})
+		var quotaErr *InstanceQuotaError
+		assert.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, Singleton, quotaErr.Scope)
+		assert.Equal(t, KeyOf[*TenantClient](), quotaErr.Key)
+		assert.Equal(t, 1, quotaErr.MaxInstances)
+	})
+
+	t.Run("MaxCachedInstances should be positive", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *TenantClient {
+				return &TenantClient{}
+			}, CachedBy(func(_ context.Context) any { return "" }), MaxCachedInstances(0)),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+	})
+}
@@ -0,0 +1,94 @@
+// Package cli assembles commands registered as goinject bindings into a runnable command line application.
+//
+// Commands are bindings implementing Command added to the CommandsGroup group:
+//
+//	goinject.Provide(NewMigrateCommand, goinject.As(goinject.Type[cli.Command]()), goinject.IntoGroup(cli.CommandsGroup))
+//
+// Each command run within a fresh command scope (see CommandScope), so bindings declared in that scope are created
+// once per command execution and destroyed when the command returns.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// CommandsGroup is the group commands must be added to using goinject.IntoGroup
+const CommandsGroup = "commands"
+
+// CommandScope is the name of the contextual scope active while a command runs
+const CommandScope = "cli.Command"
+
+type commandScopeKey int
+
+const commandScopeKeyVal commandScopeKey = 0
+
+// Command is a sub command of the application
+type Command interface {
+	// Name is the name used to select the command on the command line
+	Name() string
+	// Description is a one line description displayed in usage
+	Description() string
+	// Run execute the command with the remaining command line arguments.
+	// ctx has the command scope enabled, it can be used to Invoke the injector.
+	Run(ctx context.Context, args []string) error
+}
+
+type commandsParams struct {
+	goinject.Params
+	Commands []Command `inject:"commands,optional"`
+}
+
+// Module return a goinject module registering the command scope
+func Module() goinject.Option {
+	return goinject.Module("cli",
+		goinject.RegisterScope(CommandScope, goinject.NewContextualScope(commandScopeKeyVal)),
+	)
+}
+
+// Run select the command named by args[0] among the commands of the injector and run it within a fresh command
+// scope. Usage is written to out when no command (or "help") is given.
+func Run(ctx context.Context, injector *goinject.Injector, args []string, out io.Writer) error {
+	ctx = goinject.WithContextualScopeEnabled(ctx, commandScopeKeyVal)
+	defer goinject.ShutdownContextualScope(ctx, commandScopeKeyVal)
+
+	return injector.Invoke(ctx, func(params commandsParams) error {
+		commands := make(map[string]Command, len(params.Commands))
+		for _, c := range params.Commands {
+			if _, ok := commands[c.Name()]; ok {
+				return fmt.Errorf("command %q is registered twice", c.Name())
+			}
+			commands[c.Name()] = c
+		}
+		if len(args) == 0 || args[0] == "help" {
+			return writeUsage(out, params.Commands)
+		}
+		command, ok := commands[args[0]]
+		if !ok {
+			_ = writeUsage(out, params.Commands)
+			return fmt.Errorf("unknown command %q", args[0])
+		}
+		return command.Run(ctx, args[1:])
+	})
+}
+
+func writeUsage(out io.Writer, commands []Command) error {
+	sorted := make([]Command, len(commands))
+	copy(sorted, commands)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name() < sorted[j].Name()
+	})
+	if _, err := fmt.Fprintln(out, "Available commands:"); err != nil {
+		return err
+	}
+	for _, c := range sorted {
+		if _, err := fmt.Fprintf(out, "  %-16s %s\n", c.Name(), c.Description()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+type Connection struct {
+	closed bool
+}
+
+type migrateCommand struct {
+	injector *goinject.Injector
+	ran      []string
+	conn     *Connection
+}
+
+func (c *migrateCommand) Name() string        { return "migrate" }
+func (c *migrateCommand) Description() string { return "run database migrations" }
+func (c *migrateCommand) Run(ctx context.Context, args []string) error {
+	c.ran = args
+	return c.injector.Invoke(ctx, func(conn *Connection) {
+		c.conn = conn
+	})
+}
+
+type serveCommand struct{}
+
+func (c *serveCommand) Name() string                            { return "serve" }
+func (c *serveCommand) Description() string                     { return "start the server" }
+func (c *serveCommand) Run(_ context.Context, _ []string) error { return nil }
+
+func TestRun(t *testing.T) {
+	migrate := &migrateCommand{}
+	injector, err := goinject.NewInjector(
+		Module(),
+		goinject.Provide(func(i *goinject.Injector) *migrateCommand {
+			migrate.injector = i
+			return migrate
+		},
+			goinject.As(goinject.Type[Command]()), goinject.IntoGroup(CommandsGroup)),
+		goinject.Provide(func() *serveCommand { return &serveCommand{} },
+			goinject.As(goinject.Type[Command]()), goinject.IntoGroup(CommandsGroup)),
+		goinject.Provide(func() *Connection { return &Connection{} }, goinject.In(CommandScope),
+			goinject.WithDestroy(func(c *Connection) { c.closed = true })),
+	)
+	assert.Nil(t, err)
+
+	t.Run("Should run selected command in command scope", func(t *testing.T) {
+		var out bytes.Buffer
+		err := Run(context.Background(), injector, []string{"migrate", "--dry-run"}, &out)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"--dry-run"}, migrate.ran)
+		assert.NotNil(t, migrate.conn)
+		assert.True(t, migrate.conn.closed)
+	})
+
+	t.Run("Should write usage", func(t *testing.T) {
+		var out bytes.Buffer
+		err := Run(context.Background(), injector, nil, &out)
+		assert.Nil(t, err)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, 3)
+		assert.Contains(t, lines[1], "migrate")
+		assert.Contains(t, lines[2], "serve")
+	})
+
+	t.Run("Should return error on unknown command", func(t *testing.T) {
+		var out bytes.Buffer
+		err := Run(context.Background(), injector, []string{"unknown"}, &out)
+		assert.ErrorContains(t, err, "unknown command \"unknown\"")
+	})
+}
@@ -0,0 +1,123 @@
+// Package clock provides a Clock binding so that time-dependent providers (token TTLs, backoffs, ...) can be
+// tested with a Fake clock.
+//
+// Production code installs Module and depends on Clock, tests override it container-wide with WithClock:
+//
+//	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	injector, err := goinject.NewInjector(app.Module(), clock.WithClock(fake))
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// ModuleName is the name of the module installed by Module
+const ModuleName = "clock"
+
+// Clock tells the time
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+}
+
+// Module return a goinject module providing the system Clock
+func Module() goinject.Option {
+	return goinject.Module(ModuleName,
+		goinject.Provide(func() Clock { return System() }, goinject.In(goinject.Singleton)),
+	)
+}
+
+// WithClock return an Option replacing the Clock provided by Module (whether it is installed before or after
+// this option) with the given one
+func WithClock(c Clock) goinject.Option {
+	return goinject.ReplaceModule(ModuleName,
+		goinject.Provide(func() Clock { return c }, goinject.In(goinject.Singleton)),
+	)
+}
+
+type systemClock struct{}
+
+// System return the Clock using the system time
+func System() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Fake is a Clock whose time only changes when calling Set or Advance
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+var _ Clock = new(Fake)
+
+// NewFake return a Fake clock set to now
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now return the current fake time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since return the fake time elapsed since t
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After return a channel receiving the fake time once it is advanced by d or more
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+	f.waiters = append(f.waiters, fakeWaiter{deadline: f.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance move the fake time forward by d, firing the channels returned by After whose deadline is reached
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(f.now.Add(d))
+}
+
+// Set change the fake time, firing the channels returned by After whose deadline is reached
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(now)
+}
+
+// set change the fake time, f.mu must be held
+func (f *Fake) set(now time.Time) {
+	f.now = now
+	pending := f.waiters[:0]
+	for _, w := range f.waiters {
+		if now.Before(w.deadline) {
+			pending = append(pending, w)
+		} else {
+			w.ch <- now
+		}
+	}
+	f.waiters = pending
+}
@@ -0,0 +1,86 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+type Token struct {
+	expiresAt time.Time
+	clock     Clock
+}
+
+func (t *Token) Expired() bool {
+	return !t.clock.Now().Before(t.expiresAt)
+}
+
+func tokenModule() goinject.Option {
+	return goinject.Module("token",
+		Module(),
+		goinject.Provide(func(c Clock) *Token {
+			return &Token{expiresAt: c.Now().Add(time.Hour), clock: c}
+		}),
+	)
+}
+
+func TestClock(t *testing.T) {
+	t.Run("Should provide the system clock", func(t *testing.T) {
+		injector, err := goinject.NewInjector(tokenModule())
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func(c Clock, token *Token) {
+			assert.Equal(t, System(), c)
+			assert.False(t, token.Expired())
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should override the clock with a fake", func(t *testing.T) {
+		fake := NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		injector, err := goinject.NewInjector(WithClock(fake), tokenModule())
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func(token *Token) {
+			assert.False(t, token.Expired())
+			fake.Advance(time.Hour)
+			assert.True(t, token.Expired())
+		})
+		assert.Nil(t, err)
+	})
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+	after := fake.After(time.Minute)
+
+	fake.Advance(30 * time.Second)
+	assert.Equal(t, 30*time.Second, fake.Since(start))
+	select {
+	case <-after:
+		assert.Fail(t, "channel should not fire before deadline")
+	default:
+	}
+
+	fake.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-after)
+}
+
+func TestFakeConcurrentAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fake.Advance(time.Second)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, start.Add(100*time.Second), fake.Now())
+}
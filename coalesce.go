@@ -0,0 +1,99 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type coalescedAnnotation struct{}
+
+func (a *coalescedAnnotation) apply(b *binding) error {
+	b.coalescer = &coalescer{calls: make(map[any]*coalescedCall)}
+	return nil
+}
+
+// Coalesced return an annotation making concurrent creations of the binding share a single provider call
+// (singleflight): resolutions happening while an instance is being created get the same instance (or error).
+// For bindings cached with CachedBy, calls are shared per cache key.
+// It is intended for PerLookUp bindings fetching remote resources, as the shared instance must not be destroyed
+// by each of its users. Bindings of the other scopes (except Singleton, whose creations are already shared) are
+// rejected: the instances of two concurrent requests or invocations would be shared.
+// A resolution waiting for the call of another one is aborted when its own context is done.
+func Coalesced() Annotation {
+	return &coalescedAnnotation{}
+}
+
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[any]*coalescedCall
+}
+
+type coalescedCall struct {
+	done chan struct{} // closed once val and err are set
+	val  reflect.Value
+	err  error
+}
+
+// do call create unless a call with the same key is in flight, in which case it waits for its result or for ctx to
+// be done. If create panics, the waiting calls get an error and the panic is propagated to the caller of create.
+func (c *coalescer) do(ctx context.Context, key any, create func() (reflect.Value, error)) (reflect.Value, error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		var done <-chan struct{}
+		if ctx != nil {
+			done = ctx.Done()
+		}
+		select {
+		case <-call.done:
+			return call.val, call.err
+		case <-done:
+			return reflect.Value{}, checkResolutionContext(ctx)
+		}
+	}
+	call := &coalescedCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	defer func() {
+		r := recover()
+		if r != nil {
+			call.val, call.err = reflect.Value{}, fmt.Errorf("coalesced creation panicked: %v", r)
+		}
+		c.mu.Lock()
+		delete(c.calls, key)
+		c.mu.Unlock()
+		close(call.done)
+		if r != nil {
+			panic(r)
+		}
+	}()
+	call.val, call.err = create()
+	return call.val, call.err
+}
+
+// checkCoalesced return an error for each Coalesced binding whose scope is neither PerLookUp nor Singleton
+func (mod *configuration) checkCoalesced() []error {
+	var errs []error
+	for _, b := range mod.orderedBindings() {
+		if b.coalescer != nil && b.scope != PerLookUp && b.scope != Singleton {
+			errs = append(errs, newConfigurationProblemError(InvalidProvider, b.key().String(), b.modules, b.location,
+				newInjectorConfigurationError(fmt.Sprintf(
+					"binding %s in scope %q cannot be Coalesced, only PerLookUp and Singleton bindings can",
+					b.key(), b.scope), nil)))
+		}
+	}
+	return errs
+}
+
+// coalescingKey return the key of the coalesced calls of the binding, ok is false if the cache key cannot be
+// used as a map key
+func (b *binding) coalescingKey(ctx context.Context) (key any, ok bool) {
+	if b.cacheKey == nil {
+		return nil, true
+	}
+	key = b.cacheKey(ctx)
+	return key, key == nil || reflect.TypeOf(key).Comparable()
+}
@@ -0,0 +1,111 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type RemoteDescriptor struct {
+	version int32
+}
+
+func TestCoalesced(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	injector, err := NewInjector(
+		Provide(func() *RemoteDescriptor {
+			n := calls.Add(1)
+			<-release
+			return &RemoteDescriptor{version: n}
+		}, In(PerLookUp), Coalesced()),
+	)
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	results := make([]*RemoteDescriptor, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, injector.Invoke(context.Background(), func(d *RemoteDescriptor) { results[i] = d }))
+		}()
+	}
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond) // let other resolutions join the in-flight call
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, d := range results {
+		assert.Same(t, results[0], d)
+	}
+
+	err = injector.Invoke(context.Background(), func(d *RemoteDescriptor) {
+		assert.Equal(t, int32(2), d.version)
+	})
+	assert.Nil(t, err)
+}
+
+func TestCoalescedWaiters(t *testing.T) {
+	notCoalesced := func() (reflect.Value, error) { return reflect.Value{}, errors.New("not coalesced") }
+	waitForCall := func(c *coalescer) {
+		assert.Eventually(t, func() bool {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return len(c.calls) == 1
+		}, time.Second, time.Millisecond)
+	}
+
+	t.Run("Waiters should honor their own context", func(t *testing.T) {
+		c := &coalescer{calls: make(map[any]*coalescedCall)}
+		release := make(chan struct{})
+		go func() {
+			_, _ = c.do(context.Background(), nil, func() (reflect.Value, error) {
+				<-release
+				return reflect.ValueOf(1), nil
+			})
+		}()
+		waitForCall(c)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := c.do(ctx, nil, notCoalesced)
+		assert.ErrorIs(t, err, context.Canceled)
+		close(release)
+	})
+
+	t.Run("Waiters should get an error if the creation panics", func(t *testing.T) {
+		c := &coalescer{calls: make(map[any]*coalescedCall)}
+		release := make(chan struct{})
+		go func() {
+			defer func() { _ = recover() }()
+			_, _ = c.do(context.Background(), nil, func() (reflect.Value, error) {
+				<-release
+				panic("remote unavailable")
+			})
+		}()
+		waitForCall(c)
+		errs := make(chan error)
+		go func() {
+			_, err := c.do(context.Background(), nil, notCoalesced)
+			errs <- err
+		}()
+		time.Sleep(10 * time.Millisecond) // let the waiter join the in-flight call
+		close(release)
+		assert.EqualError(t, <-errs, "coalesced creation panicked: remote unavailable")
+	})
+
+	t.Run("Coalesced should be rejected in contextual scopes", func(t *testing.T) {
+		_, err := NewInjector(
+			RegisterScope("request", NewContextualScope(struct{}{})),
+			Provide(func() *RemoteDescriptor { return &RemoteDescriptor{} }, In("request"), Coalesced()),
+		)
+		assert.ErrorContains(t, err, `binding *goinject.RemoteDescriptor in scope "request" cannot be Coalesced`)
+	})
+}
@@ -0,0 +1,91 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// collector add bindings to the configuration once all options are applied
+type collector func(mod *configuration) error
+
+type collectImplementationsOption[T any] struct {
+	pkgsPrefix string
+	location   string
+}
+
+func (o *collectImplementationsOption[T]) apply(mod *configuration) error {
+	target := reflect.TypeFor[T]()
+	if target.Kind() != reflect.Interface {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("cannot collect implementations of %s: it must be an interface", target), nil)
+	}
+	mod.collectors = append(mod.collectors, o.collect)
+	return nil
+}
+
+// collect add a T binding for each binding of the configuration implementing T in a matching package
+func (o *collectImplementationsOption[T]) collect(mod *configuration) error {
+	target := reflect.TypeFor[T]()
+	var sources []*binding
+	for b := range mod.bindings {
+		if b.typeof != target && !b.fallback && b.providedType.Implements(target) &&
+			strings.HasPrefix(packageOf(b.providedType), o.pkgsPrefix) {
+			sources = append(sources, b)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].order < sources[j].order })
+	for _, source := range sources {
+		provide := &provideOption{
+			constructor: func(ctx InvocationContext, injector *Injector) (T, error) {
+				var res T
+				instance, err := injector.getInjectedInstance(ctx, source)
+				if err != nil || !instance.IsValid() {
+					return res, err
+				}
+				res, _ = instance.Interface().(T)
+				return res, nil
+			},
+			annotations: []Annotation{In(PerLookUp)},
+			location:    o.location,
+		}
+		var b *binding
+		if err := provide.configure(mod, &b); err != nil {
+			return err
+		}
+		b.grouped = true
+		b.modules = source.modules
+		b.order = mod.registered
+		mod.registered++
+		mod.bindings[b] = true
+	}
+	return nil
+}
+
+// packageOf return the import path of the package declaring t (or the type t points to)
+func packageOf(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.PkgPath()
+}
+
+// CollectImplementations return an Option adding the bindings whose provided type implements the interface T and
+// is declared in a package whose import path starts with pkgsPrefix to the multi-binding of T (resolved with a
+// []T), even if they are not bound to T with As. Each collected binding is added once, sharing the instance of the
+// original binding (according to its scope). Bindings already bound to T are not collected again.
+func CollectImplementations[T any](pkgsPrefix string) Option {
+	return &collectImplementationsOption[T]{pkgsPrefix: pkgsPrefix, location: callerLocation()}
+}
+
+// collectImplementations run the collectors of CollectImplementations options, once all options are applied
+func (mod *configuration) collectImplementations() []error {
+	var errs []error
+	for _, collect := range mod.collectors {
+		if err := collect(mod); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
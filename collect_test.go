@@ -0,0 +1,49 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type HealthChecker interface {
+	CheckHealth() error
+}
+
+type diskChecker struct{}
+
+func (c *diskChecker) CheckHealth() error { return nil }
+
+type queueChecker struct{}
+
+func (c *queueChecker) CheckHealth() error { return nil }
+
+func TestCollectImplementations(t *testing.T) {
+	disk := &diskChecker{}
+	options := []Option{
+		Provide(func() *diskChecker { return disk }),
+		Provide(func() *queueChecker { return &queueChecker{} }, As(Type[HealthChecker]())),
+	}
+	ctx := context.Background()
+
+	injector, err := NewInjector(append(options, CollectImplementations[HealthChecker]("github.com/illuin-tech/"))...)
+	assert.Nil(t, err)
+	err = injector.Invoke(ctx, func(checkers []HealthChecker, d *diskChecker) {
+		assert.Len(t, checkers, 2)
+		assert.Contains(t, checkers, HealthChecker(d))
+	})
+	assert.Nil(t, err)
+
+	injector, err = NewInjector(append(options, CollectImplementations[HealthChecker]("example.com/"))...)
+	assert.Nil(t, err)
+	err = injector.Invoke(ctx, func(checkers []HealthChecker) {
+		assert.Len(t, checkers, 1)
+	})
+	assert.Nil(t, err)
+
+	t.Run("Collected type should be an interface", func(t *testing.T) {
+		_, err := NewInjector(CollectImplementations[*diskChecker](""))
+		assert.ErrorContains(t, err, "it must be an interface")
+	})
+}
@@ -0,0 +1,39 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+)
+
+type maxConcurrentCreationsAnnotation struct {
+	limit int
+}
+
+func (a *maxConcurrentCreationsAnnotation) apply(b *binding) error {
+	if a.limit <= 0 {
+		return newInjectorConfigurationError("argument of MaxConcurrentCreations must be positive", nil)
+	}
+	b.creationSlots = make(chan struct{}, a.limit)
+	return nil
+}
+
+// MaxConcurrentCreations return an annotation limiting the number of instances of the binding being created
+// simultaneously (e.g. per-lookup or request scoped instances calling an expensive downstream service).
+// Resolutions beyond the limit block until a creation ends, or fail when the resolution context is done.
+func MaxConcurrentCreations(limit int) Annotation {
+	return &maxConcurrentCreationsAnnotation{limit: limit}
+}
+
+// acquireCreationSlot wait for a creation slot of the binding if its creations are limited, release must be called
+// once the creation ends
+func (b *binding) acquireCreationSlot(ctx context.Context) (release func(), err error) {
+	if b.creationSlots == nil {
+		return func() {}, nil
+	}
+	select {
+	case b.creationSlots <- struct{}{}:
+		return func() { <-b.creationSlots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a creation slot: %w", checkResolutionContext(ctx))
+	}
+}
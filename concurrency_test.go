@@ -0,0 +1,71 @@
+package goinject
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ExpensiveClient struct{}
+
+func TestMaxConcurrentCreations(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	release := make(chan struct{})
+	injector, err := NewInjector(
+		Provide(func() *ExpensiveClient {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			<-release
+			return &ExpensiveClient{}
+		}, In(PerLookUp), MaxConcurrentCreations(2)),
+	)
+	assert.Nil(t, err)
+
+	t.Run("Should block creations beyond the limit", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.Nil(t, injector.Invoke(context.Background(), func(_ *ExpensiveClient) {}))
+			}()
+		}
+		assert.Eventually(t, func() bool { return inFlight.Load() == 2 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(2), maxInFlight.Load())
+	})
+
+	t.Run("Should return error if context is done while waiting", func(t *testing.T) {
+		blocking, err := NewInjector(
+			Provide(func(ctx InvocationContext) *ExpensiveClient {
+				<-ctx.Done()
+				return &ExpensiveClient{}
+			}, In(PerLookUp), MaxConcurrentCreations(1)),
+		)
+		assert.Nil(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		go func() { _ = blocking.Invoke(ctx, func(_ *ExpensiveClient) {}) }()
+		time.Sleep(10 * time.Millisecond)
+
+		err = blocking.Invoke(ctx, func(_ *ExpensiveClient) {})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "waiting for a creation slot")
+	})
+
+	t.Run("Should reject non positive limit", func(t *testing.T) {
+		_, err := NewInjector(Provide(func() *ExpensiveClient { return &ExpensiveClient{} }, MaxConcurrentCreations(0)))
+		assert.ErrorContains(t, err, "argument of MaxConcurrentCreations must be positive")
+	})
+}
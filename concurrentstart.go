@@ -0,0 +1,192 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StartPolicy configure Injector.StartConcurrently
+type StartPolicy struct {
+	HookTimeout time.Duration // maximum duration of each start hook, unlimited if not set
+	Deadline    time.Duration // maximum duration of the whole startup, unlimited if not set
+}
+
+// HookStatus is the outcome of a start hook in a StartReport
+type HookStatus string
+
+const (
+	// HookSucceeded is the status of a start hook that returned without error
+	HookSucceeded HookStatus = "succeeded"
+	// HookFailed is the status of a start hook that returned an error or timed out
+	HookFailed HookStatus = "failed"
+	// HookSkipped is the status of a start hook that did not run because another hook failed or the startup
+	// deadline was exceeded
+	HookSkipped HookStatus = "skipped"
+)
+
+// HookResult is the outcome of the start hook of a binding
+type HookResult struct {
+	Key      BindingKey
+	Phase    string
+	Status   HookStatus
+	Duration time.Duration // duration of the hook, 0 if it was skipped
+	Err      error
+}
+
+// StartReport list the start hooks run by Injector.StartConcurrently, phase by phase and in creation order within
+// a phase
+type StartReport struct {
+	Hooks []HookResult
+}
+
+// StartConcurrently run start hooks like Injector.Start, but the hooks of a phase that do not depend on each other
+// (transitively) run concurrently, each hook waiting for the hooks of its dependencies. When a hook fails or times
+// out, the context of the running hooks is cancelled and the remaining hooks are skipped. The returned report tell
+// which hooks ran, even if an error is returned. Hooks must honor their context for the timeouts to stop them, a
+// hook returning after its timeout is still considered started.
+func (injector *Injector) StartConcurrently(ctx context.Context, policy StartPolicy) (_ *StartReport, err error) {
+	endStart, err := injector.beginStart("StartConcurrently")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { endStart(err) }()
+	if policy.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Deadline)
+		defer cancel()
+	}
+	report := &StartReport{}
+	for _, phase := range injector.lifecycle.phases {
+		injector.lifecycle.mu.Lock()
+		created := append([]*binding(nil), injector.lifecycle.created...)
+		injector.lifecycle.mu.Unlock()
+
+		var pending []*binding
+		for _, b := range created {
+			if b.phase == phase && !injector.isStarted(b) {
+				pending = append(pending, b)
+			}
+		}
+		if err := injector.startPhaseConcurrently(ctx, policy, pending, report); err != nil {
+			return report, decorateError(injector.errorDecorators, err)
+		}
+	}
+	injector.lifecycle.mu.Lock()
+	defer injector.lifecycle.mu.Unlock()
+	injector.lifecycle.startSucceed = true
+	return report, nil
+}
+
+// startPhaseConcurrently start the pending bindings of a phase, appending their results to report
+func (injector *Injector) startPhaseConcurrently(
+	ctx context.Context,
+	policy StartPolicy,
+	pending []*binding,
+	report *StartReport,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	isPending := make(map[*binding]bool, len(pending))
+	done := make(map[*binding]chan struct{}, len(pending))
+	for _, b := range pending {
+		isPending[b] = true
+		done[b] = make(chan struct{})
+	}
+	var mu sync.Mutex
+	results := make(map[*binding]HookResult, len(pending))
+	var errs []error
+	var wg sync.WaitGroup
+	for _, b := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[b])
+			result := HookResult{Key: b.key(), Phase: b.phase, Status: HookSkipped}
+			defer func() {
+				mu.Lock()
+				defer mu.Unlock()
+				results[b] = result
+			}()
+			for _, dependency := range injector.startDependencies(b, isPending) {
+				<-done[dependency]
+				mu.Lock()
+				status := results[dependency].Status
+				mu.Unlock()
+				if status != HookSucceeded {
+					return
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			begin := time.Now()
+			err := injector.startBindingWithTimeout(ctx, policy.HookTimeout, b)
+			result.Duration = time.Since(begin)
+			if err != nil {
+				result.Status, result.Err = HookFailed, err
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				cancel()
+				return
+			}
+			result.Status = HookSucceeded
+		}()
+	}
+	wg.Wait()
+	for _, b := range pending {
+		report.Hooks = append(report.Hooks, results[b])
+	}
+	if len(errs) == 0 && ctx.Err() != nil {
+		return fmt.Errorf("startup deadline exceeded: %w", ctx.Err())
+	}
+	return errors.Join(errs...)
+}
+
+// startBindingWithTimeout start b, failing if its hook does not return within timeout (if set)
+func (injector *Injector) startBindingWithTimeout(ctx context.Context, timeout time.Duration, b *binding) error {
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	res := make(chan error, 1)
+	go func() {
+		res <- injector.startBinding(ctx, b)
+	}()
+	select {
+	case err := <-res:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("start hook of binding %s did not return: %w", b.key(), ctx.Err())
+	}
+}
+
+// startDependencies return the pending bindings b depends on, directly or through bindings that are not pending
+func (injector *Injector) startDependencies(b *binding, pending map[*binding]bool) []*binding {
+	var res []*binding
+	visited := map[*binding]bool{b: true}
+	var visit func(b *binding)
+	visit = func(b *binding) {
+		for _, key := range dependenciesOf(b.provider.Type()) {
+			for _, dependency := range injector.findBindingsForAnnotatedType(key.Type, key.Annotation) {
+				if visited[dependency] {
+					continue
+				}
+				visited[dependency] = true
+				if pending[dependency] {
+					res = append(res, dependency)
+				} else {
+					visit(dependency)
+				}
+			}
+		}
+	}
+	visit(b)
+	return res
+}
@@ -0,0 +1,94 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type UserDatabase struct{}
+
+type Migrations struct{}
+
+type Metrics struct{}
+
+func TestStartConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+	databaseErr := errors.New("database unavailable")
+	newInjector := func(databaseHook func(ctx context.Context) error) *Injector {
+		injector, err := NewInjector(
+			Provide(func() *UserDatabase { return &UserDatabase{} }, OnStart(func(ctx context.Context, _ *UserDatabase) error {
+				return databaseHook(ctx)
+			})),
+			Provide(func(_ *UserDatabase) *Migrations { return &Migrations{} },
+				OnStart(func(_ context.Context, _ *Migrations) error {
+					record("migrations")
+					return nil
+				})),
+			Provide(func() *Metrics { return &Metrics{} }, OnStart(func(_ context.Context, _ *Metrics) error {
+				record("metrics")
+				return nil
+			})),
+		)
+		assert.Nil(t, err)
+		return injector
+	}
+	statuses := func(report *StartReport) map[BindingKey]HookStatus {
+		res := make(map[BindingKey]HookStatus)
+		for _, hook := range report.Hooks {
+			res[hook.Key] = hook.Status
+		}
+		return res
+	}
+
+	t.Run("Independent hooks should run concurrently in dependency order", func(t *testing.T) {
+		order = nil
+		injector := newInjector(func(_ context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			record("user-database")
+			return nil
+		})
+		report, err := injector.StartConcurrently(context.Background(), StartPolicy{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"metrics", "user-database", "migrations"}, order)
+		assert.Len(t, report.Hooks, 3)
+		for _, status := range statuses(report) {
+			assert.Equal(t, HookSucceeded, status)
+		}
+	})
+
+	t.Run("Hooks depending on a failed hook should be skipped", func(t *testing.T) {
+		injector := newInjector(func(_ context.Context) error { return databaseErr })
+		report, err := injector.StartConcurrently(context.Background(), StartPolicy{})
+		assert.ErrorIs(t, err, databaseErr)
+		assert.Equal(t, HookFailed, statuses(report)[KeyOf[*UserDatabase]()])
+		assert.Equal(t, HookSkipped, statuses(report)[KeyOf[*Migrations]()])
+	})
+
+	t.Run("Hooks should time out", func(t *testing.T) {
+		injector := newInjector(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		report, err := injector.StartConcurrently(context.Background(), StartPolicy{HookTimeout: 10 * time.Millisecond})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, HookFailed, statuses(report)[KeyOf[*UserDatabase]()])
+
+		injector = newInjector(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		_, err = injector.StartConcurrently(context.Background(), StartPolicy{Deadline: 10 * time.Millisecond})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
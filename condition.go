@@ -1,9 +1,13 @@
 package goinject
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strings"
+)
 
 type Conditional interface {
-	evaluate() bool
+	evaluate(mod *configuration) bool
 }
 
 type environmentVariableConditional struct {
@@ -12,7 +16,7 @@ type environmentVariableConditional struct {
 	matchIfMissing bool
 }
 
-func (c *environmentVariableConditional) evaluate() bool {
+func (c *environmentVariableConditional) evaluate(_ *configuration) bool {
 	val, ok := os.LookupEnv(c.name)
 	if !ok {
 		return c.matchIfMissing
@@ -27,3 +31,50 @@ func OnEnvironmentVariable(name, havingValue string, matchIfMissing bool) Condit
 		matchIfMissing: matchIfMissing,
 	}
 }
+
+type variantConditional struct {
+	variants []string
+}
+
+func (c *variantConditional) evaluate(mod *configuration) bool {
+	mod.variantsEvaluated = true
+	for _, v := range c.variants {
+		if mod.variants[v] {
+			return true
+		}
+	}
+	return false
+}
+
+// OnVariant return a Conditional matching if one of the given variants is enabled with WithVariants.
+// Unlike OnEnvironmentVariable, variants (product flavors such as "enterprise" or "cloud") are passed
+// programmatically by the build entrypoint.
+func OnVariant(variants ...string) Conditional {
+	return &variantConditional{variants: variants}
+}
+
+type variantsOption struct {
+	variants []string
+}
+
+func (o *variantsOption) apply(mod *configuration) error {
+	if mod.variantsEvaluated {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("variants %s must be enabled before the options conditional on variants",
+				strings.Join(o.variants, ", ")),
+			nil,
+		)
+	}
+	if mod.variants == nil {
+		mod.variants = make(map[string]bool)
+	}
+	for _, v := range o.variants {
+		mod.variants[v] = true
+	}
+	return nil
+}
+
+// WithVariants enable the given variants, it must be given before the options using OnVariant conditionals
+func WithVariants(variants ...string) InjectorOption {
+	return newInjectorOption("WithVariants", &variantsOption{variants: variants})
+}
@@ -0,0 +1,131 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ConfigOption configure configuration bindings such as ProvideFromEnv and ProvideFromFile
+type ConfigOption interface {
+	applyConfig(*configSource) error
+}
+
+const defaultPollInterval = time.Second
+
+// configSource hold the configuration of a configuration binding
+type configSource struct {
+	valueType    reflect.Type
+	validators   []func(value reflect.Value) error
+	watch        bool          // refresh the binding when the source changes
+	pollInterval time.Duration // interval between checks of the source
+	location     string        // source location of the Option creation
+}
+
+type validatorOption struct {
+	validator any
+	validate  func(value reflect.Value) error
+}
+
+func (o *validatorOption) applyConfig(source *configSource) error {
+	if o.validator == nil {
+		return newInjectorConfigurationError("argument of WithValidator cannot be nil", nil)
+	}
+	if expected := reflect.PointerTo(source.valueType); reflect.TypeOf(o.validator).In(0) != expected {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("argument of WithValidator must be a function accepting %s", expected), nil)
+	}
+	source.validators = append(source.validators, o.validate)
+	return nil
+}
+
+// WithValidator return a ConfigOption running fn on each created configuration, the configuration is rejected
+// if it returns an error
+func WithValidator[T any](fn func(config *T) error) ConfigOption {
+	o := &validatorOption{validate: func(value reflect.Value) error {
+		return fn(value.Interface().(*T))
+	}}
+	if fn != nil {
+		o.validator = fn
+	}
+	return o
+}
+
+type watchOption struct{}
+
+func (o *watchOption) applyConfig(source *configSource) error {
+	source.watch = true
+	return nil
+}
+
+// WatchAndRefresh return a ConfigOption refreshing the binding (see Injector.Refresh) when its source changes.
+// It is only supported by ProvideFromFile, environment variables cannot change from outside the process.
+func WatchAndRefresh() ConfigOption {
+	return &watchOption{}
+}
+
+type pollIntervalOption struct {
+	interval time.Duration
+}
+
+func (o *pollIntervalOption) applyConfig(source *configSource) error {
+	if o.interval <= 0 {
+		return newInjectorConfigurationError("argument of PollInterval must be positive", nil)
+	}
+	source.pollInterval = o.interval
+	return nil
+}
+
+// PollInterval return a ConfigOption setting the interval between checks of watched sources (one second by default)
+func PollInterval(interval time.Duration) ConfigOption {
+	return &pollIntervalOption{interval: interval}
+}
+
+func newConfigSource(
+	mod *configuration,
+	valueType reflect.Type,
+	options []ConfigOption,
+	location string,
+) (*configSource, error) {
+	source := &configSource{valueType: valueType, pollInterval: defaultPollInterval, location: location}
+	for _, opt := range options {
+		if err := opt.applyConfig(source); err != nil {
+			return nil, newConfigurationProblemError(InvalidProvider, reflect.PointerTo(valueType).String(), mod.modules,
+				location, err)
+		}
+	}
+	return source, nil
+}
+
+// validated run validators on the value if err is nil
+func (s *configSource) validated(value reflect.Value, err error) (reflect.Value, error) {
+	if err != nil {
+		return value, err
+	}
+	for _, validate := range s.validators {
+		if err = validate(value); err != nil {
+			return value, fmt.Errorf("invalid configuration %s: %w", value.Type(), err)
+		}
+	}
+	return value, nil
+}
+
+// provideOption return the Option providing the Refreshable *T singleton returned by read (then validated)
+func (s *configSource) provideOption(read func() (reflect.Value, error)) *provideOption {
+	ptrType := reflect.PointerTo(s.valueType)
+	provider := reflect.MakeFunc(
+		reflect.FuncOf(nil, []reflect.Type{ptrType, errorReflectType}, false),
+		func(_ []reflect.Value) []reflect.Value {
+			value, err := s.validated(read())
+			if err != nil {
+				return []reflect.Value{reflect.Zero(ptrType), reflect.ValueOf(&err).Elem()}
+			}
+			return []reflect.Value{value, reflect.Zero(errorReflectType)}
+		},
+	)
+	return &provideOption{
+		constructor: provider.Interface(),
+		annotations: []Annotation{In(Singleton), Refreshable()},
+		location:    s.location,
+	}
+}
@@ -0,0 +1,115 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync"
+)
+
+type checkInterfaceBindingsOption struct{}
+
+func (o *checkInterfaceBindingsOption) apply(mod *configuration) error {
+	mod.checkInterfaces = true
+	return nil
+}
+
+// CheckInterfaceBindings return a debug InjectorOption checking the instances of interface bindings when they are
+// created: a provider returning a nil pointer (or another nil value) as an interface fails the resolution instead of
+// injecting an interface with a nil receiver. Instances are not wrapped in a validating proxy, proxies being written
+// by hand (see WithProxy): method calls are only tracked for the bindings declaring a proxy,
+// which record the stack trace of the first call of each method run through CallGuard.Call (see
+// Injector.FirstCallStacks) and re-raise panics of those calls as *ProxiedCallPanic telling the responsible binding.
+func CheckInterfaceBindings() InjectorOption {
+	return newInjectorOption("CheckInterfaceBindings", &checkInterfaceBindingsOption{})
+}
+
+// ProxiedCallPanic is the value re-panicked by CallGuard.Call when a proxied call panics and CheckInterfaceBindings
+// is enabled
+type ProxiedCallPanic struct {
+	Key            BindingKey
+	Location       string // source location of the binding
+	Method         string
+	Value          any    // value given to panic
+	FirstCallStack string // stack trace of the first call of the method
+}
+
+func (p *ProxiedCallPanic) Error() string {
+	return fmt.Sprintf("call of %s on binding %s provided at %s panicked: %v\nfirst call of %s:\n%s",
+		p.Method, p.Key, p.Location, p.Value, p.Method, p.FirstCallStack)
+}
+
+// firstCalls record the stack trace of the first call of each method of a proxied binding
+type firstCalls struct {
+	mu     sync.Mutex
+	stacks map[string]string
+}
+
+func (c *firstCalls) record(method string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stack, ok := c.stacks[method]; ok {
+		return stack
+	}
+	if c.stacks == nil {
+		c.stacks = make(map[string]string)
+	}
+	c.stacks[method] = string(debug.Stack())
+	return c.stacks[method]
+}
+
+// FirstCallStacks return the stack traces of the first call of each method of the proxy of the binding, by method
+// name. It is only filled for bindings declaring a proxy with WithProxy, for the calls run through CallGuard.Call,
+// when CheckInterfaceBindings is enabled: it is empty for the other bindings.
+func (injector *Injector) FirstCallStacks(key BindingKey) map[string]string {
+	res := make(map[string]string)
+	for _, b := range injector.findBindingsForAnnotatedType(key.Type, key.Annotation) {
+		b.firstCalls.mu.Lock()
+		for method, stack := range b.firstCalls.stacks {
+			res[method] = stack
+		}
+		b.firstCalls.mu.Unlock()
+	}
+	return res
+}
+
+// checkInterfaceInstance fail if instance, provided for the interface binding b, is a nil pointer, map, slice,
+// channel or function
+func (b *binding) checkInterfaceInstance(instance reflect.Value) error {
+	if b.typeof.Kind() != reflect.Interface || !instance.IsValid() {
+		return nil
+	}
+	if instance.Kind() == reflect.Interface {
+		if instance.IsNil() {
+			return nil
+		}
+		instance = instance.Elem()
+	}
+	switch instance.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		if instance.IsNil() {
+			return fmt.Errorf("provider for interface type %q at %s returned a nil %s",
+				b.typeof.String(), b.location, instance.Type())
+		}
+	default:
+	}
+	return nil
+}
+
+// checkedCall record the first call of method and re-panic panics of call as *ProxiedCallPanic
+func (g *CallGuard) checkedCall(ctx context.Context, method string, call func(ctx context.Context) error) error {
+	firstCallStack := g.binding.firstCalls.record(method)
+	defer func() {
+		if r := recover(); r != nil {
+			panic(&ProxiedCallPanic{
+				Key:            g.key,
+				Location:       g.binding.location,
+				Method:         method,
+				Value:          r,
+				FirstCallStack: firstCallStack,
+			})
+		}
+	}()
+	return call(ctx)
+}
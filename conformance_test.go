@@ -0,0 +1,93 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type panickingInventory struct{}
+
+func (panickingInventory) Stock(_ context.Context, _ string) (int, error) {
+	panic("inventory not initialized")
+}
+
+func TestCheckInterfaceBindings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nil receiver", func(t *testing.T) {
+		injector, err := NewInjector(
+			CheckInterfaceBindings(),
+			Provide(func() *flakyInventory { return nil }, As(Type[Inventory]()), In(PerLookUp)),
+			Provide(func() Inventory {
+				var inventory *flakyInventory
+				return inventory
+			}, Named("typed"), In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+
+		err = injector.Invoke(ctx, func(_ Inventory) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorContains(t, err, "provider for interface type \"goinject.Inventory\" at ")
+		assert.ErrorContains(t, err, "conformance_test.go")
+		assert.ErrorContains(t, err, "returned a nil *goinject.flakyInventory")
+
+		type params struct {
+			Params
+			Inventory Inventory `inject:"typed"`
+		}
+		err = injector.Invoke(ctx, func(_ params) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorContains(t, err, "returned a nil *goinject.flakyInventory")
+
+		injector, err = NewInjector(
+			Provide(func() *flakyInventory { return nil }, As(Type[Inventory]()), In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(_ Inventory) {})
+		assert.Nil(t, err)
+	})
+
+	t.Run("proxied calls", func(t *testing.T) {
+		injector, err := NewInjector(
+			CheckInterfaceBindings(),
+			Provide(func() *flakyInventory { return &flakyInventory{} }, As(Type[Inventory]()),
+				WithProxy(newInventoryProxy)),
+			Provide(func() panickingInventory { return panickingInventory{} }, As(Type[Inventory]()),
+				Named("panicking"), WithProxy(newInventoryProxy)),
+		)
+		assert.Nil(t, err)
+
+		err = injector.Invoke(ctx, func(inventory Inventory) {
+			_, _ = inventory.Stock(ctx, "apple")
+			_, _ = inventory.Stock(ctx, "pear")
+		})
+		assert.Nil(t, err)
+		stacks := injector.FirstCallStacks(KeyOf[Inventory]())
+		assert.Len(t, stacks, 1)
+		assert.Contains(t, stacks["Stock"], "conformance_test.go")
+
+		type params struct {
+			Params
+			Inventory Inventory `inject:"panicking"`
+		}
+		err = injector.Invoke(ctx, func(p params) {
+			defer func() {
+				r := recover()
+				callPanic, ok := r.(*ProxiedCallPanic)
+				if assert.True(t, ok) {
+					assert.Equal(t, KeyOf[Inventory]("panicking"), callPanic.Key)
+					assert.Equal(t, "Stock", callPanic.Method)
+					assert.Equal(t, "inventory not initialized", callPanic.Value)
+					assert.Contains(t, callPanic.Location, "conformance_test.go")
+					assert.ErrorContains(t, callPanic, "call of Stock on binding goinject.Inventory(\"panicking\")")
+				}
+			}()
+			_, _ = p.Inventory.Stock(ctx, "apple")
+		})
+		assert.Nil(t, err)
+	})
+}
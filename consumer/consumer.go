@@ -0,0 +1,70 @@
+// Package consumer wraps message handling (Kafka, AMQP, ...) in goinject contextual scopes.
+//
+// Each message is handled within a fresh message scope in which the raw message is injectable:
+//
+//	injector, _ := goinject.NewInjector(consumer.Module[*kafka.Message]("message", messageScopeKey), ...)
+//	err := consumer.ConsumeScoped(ctx, injector, messageScopeKey, msg, func(msg *kafka.Message, repo *Repo) error {
+//		...
+//	})
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// Acknowledger is implemented by messages that must be acknowledged once handled
+type Acknowledger interface {
+	// Ack is called when the handler succeeded
+	Ack() error
+	// Nack is called with the handler error when it failed
+	Nack(cause error) error
+}
+
+// ErrNoMessage is returned when resolving the message outside ConsumeScoped
+var ErrNoMessage = errors.New("no message in context")
+
+// messageContextKey store the message of the scope identified by scopeKey
+type messageContextKey struct {
+	scopeKey any
+}
+
+// Module return a goinject module registering a contextual scope named scope for the given scope key, and
+// providing the message of type M being consumed within that scope
+func Module[M any](scope string, scopeKey any) goinject.Option {
+	return goinject.Module(fmt.Sprintf("consumer(%s)", scope),
+		goinject.RegisterScope(scope, goinject.NewContextualScope(scopeKey)),
+		goinject.Provide(func(ctx goinject.InvocationContext) (M, error) {
+			msg, ok := ctx.Value(messageContextKey{scopeKey}).(M)
+			if !ok {
+				var zero M
+				return zero, ErrNoMessage
+			}
+			return msg, nil
+		}, goinject.In(scope)),
+	)
+}
+
+// ConsumeScoped handle msg by invoking handler within a fresh scope identified by scopeKey, in which msg is
+// injectable. If msg implements Acknowledger, it is acked or nacked according to the handler result before the
+// scope is shut down.
+func ConsumeScoped[M any](ctx context.Context, injector *goinject.Injector, scopeKey any, msg M, handler any) error {
+	ctx = context.WithValue(ctx, messageContextKey{scopeKey}, msg)
+	ctx = goinject.WithContextualScopeEnabled(ctx, scopeKey)
+	defer goinject.ShutdownContextualScope(ctx, scopeKey)
+
+	err := injector.Invoke(ctx, handler)
+	if ack, ok := any(msg).(Acknowledger); ok {
+		if err == nil {
+			if ackErr := ack.Ack(); ackErr != nil {
+				return fmt.Errorf("failed to ack message: %w", ackErr)
+			}
+		} else if nackErr := ack.Nack(err); nackErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to nack message: %w", nackErr))
+		}
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+type messageScopeKey int
+
+const messageScopeKeyVal messageScopeKey = 0
+
+type Message struct {
+	Body   string
+	acked  bool
+	nacked error
+}
+
+func (m *Message) Ack() error {
+	m.acked = true
+	return nil
+}
+
+func (m *Message) Nack(cause error) error {
+	m.nacked = cause
+	return nil
+}
+
+type Handler struct {
+	msg    *Message
+	closed bool
+}
+
+func TestConsumeScoped(t *testing.T) {
+	var handlers []*Handler
+	injector, err := goinject.NewInjector(
+		Module[*Message]("message", messageScopeKeyVal),
+		goinject.Provide(func(msg *Message) *Handler {
+			h := &Handler{msg: msg}
+			handlers = append(handlers, h)
+			return h
+		}, goinject.In("message"), goinject.WithDestroy(func(h *Handler) {
+			h.closed = true
+		})),
+	)
+	assert.Nil(t, err)
+
+	t.Run("Should handle each message in its own scope and ack", func(t *testing.T) {
+		first := &Message{Body: "first"}
+		second := &Message{Body: "second"}
+		for _, msg := range []*Message{first, second} {
+			err := ConsumeScoped(context.Background(), injector, messageScopeKeyVal, msg, func(h *Handler, m *Message) {
+				assert.Same(t, m, h.msg)
+			})
+			assert.Nil(t, err)
+		}
+		assert.True(t, first.acked)
+		assert.True(t, second.acked)
+		assert.Len(t, handlers, 2)
+		assert.Equal(t, "first", handlers[0].msg.Body)
+		assert.Equal(t, "second", handlers[1].msg.Body)
+		assert.True(t, handlers[0].closed)
+		assert.True(t, handlers[1].closed)
+	})
+
+	t.Run("Should nack on handler error", func(t *testing.T) {
+		msg := &Message{Body: "poison"}
+		handlerErr := errors.New("cannot handle")
+		err := ConsumeScoped(context.Background(), injector, messageScopeKeyVal, msg, func(_ *Handler) error {
+			return handlerErr
+		})
+		assert.ErrorIs(t, err, handlerErr)
+		assert.False(t, msg.acked)
+		assert.ErrorIs(t, msg.nacked, handlerErr)
+	})
+
+	t.Run("Should not provide message outside ConsumeScoped", func(t *testing.T) {
+		ctx := goinject.WithContextualScopeEnabled(context.Background(), messageScopeKeyVal)
+		defer goinject.ShutdownContextualScope(ctx, messageScopeKeyVal)
+		err := injector.Invoke(ctx, func(_ *Message) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorIs(t, err, ErrNoMessage)
+	})
+}
@@ -0,0 +1,41 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ContextValueError is returned when resolving a binding of ProvideFromContext whose value is missing from the
+// invocation context, or is not of the binding type
+type ContextValueError struct {
+	Key   any          // key of the context value
+	Type  reflect.Type // type of the binding
+	Value any          // value under the key, nil if missing
+}
+
+var _ error = &ContextValueError{}
+
+func (e *ContextValueError) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("no context value under key %v (%T), expected %s", e.Key, e.Key, e.Type)
+	}
+	return fmt.Sprintf("context value under key %v (%T) is a %T, expected %s", e.Key, e.Key, e.Value, e.Type)
+}
+
+// ProvideFromContext return an Option providing T from the value of the invocation context under key (see
+// context.WithValue), instead of a provider type-asserting ctx.Value by hand. The resolution fails with a
+// *ContextValueError if the value is missing or is not a T. The binding is PerLookUp unless annotated with In.
+func ProvideFromContext[T any](key any, annotations ...Annotation) Option {
+	return &provideOption{
+		constructor: func(ctx InvocationContext) (T, error) {
+			value := ctx.Value(key)
+			res, ok := value.(T)
+			if !ok {
+				return res, &ContextValueError{Key: key, Type: reflect.TypeFor[T](), Value: value}
+			}
+			return res, nil
+		},
+		annotations: append([]Annotation{In(PerLookUp)}, annotations...),
+		location:    callerLocation(),
+	}
+}
@@ -0,0 +1,39 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvideFromContext(t *testing.T) {
+	injector, err := NewInjector(
+		ProvideFromContext[*Request](requestKey),
+		ProvideFromContext[string](tenantKey{}, Named("tenant")),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.WithValue(context.Background(), requestKey, &Request{ID: 42})
+	type ContextParams struct {
+		Params
+		Request *Request `inject:""`
+		Tenant  string   `inject:"tenant"`
+	}
+	err = injector.Invoke(context.WithValue(ctx, tenantKey{}, "acme"), func(p ContextParams) {
+		assert.Equal(t, 42, p.Request.ID)
+		assert.Equal(t, "acme", p.Tenant)
+	})
+	assert.Nil(t, err)
+
+	err = injector.Invoke(context.Background(), func(_ *Request) {})
+	var valueErr *ContextValueError
+	if assert.True(t, errors.As(err, &valueErr)) {
+		assert.Equal(t, requestKey, valueErr.Key)
+		assert.Nil(t, valueErr.Value)
+	}
+
+	err = injector.Invoke(context.WithValue(ctx, tenantKey{}, 12), func(_ ContextParams) {})
+	assert.ErrorContains(t, err, "is a int, expected string")
+}
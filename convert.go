@@ -0,0 +1,75 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type conversionAnnotation struct {
+	source BindingKey
+}
+
+func (a *conversionAnnotation) apply(b *binding) error {
+	b.fallback = true
+	b.conversionFrom = &a.source
+	return nil
+}
+
+type convertOption struct {
+	provide *provideOption
+	target  reflect.Type
+	err     error // invalid annotations
+}
+
+func (o *convertOption) apply(mod *configuration) error {
+	if o.err != nil {
+		return newConfigurationProblemError(InvalidProvider, "", mod.modules, o.provide.location,
+			fmt.Errorf("cannot convert to %s: %w", o.target, o.err))
+	}
+	return o.provide.apply(mod)
+}
+
+// Convert return an Option registering convert as an adapter providing B from the A binding (with the same
+// annotation), used only when B is requested but has no other binding, e.g. to derive an options struct from a
+// configuration struct without bridge providers. The conversion is ignored if A has no binding.
+// The annotations apply to the B binding, which is PerLookUp: convert is called on each injection.
+func Convert[A, B any](convert func(A) B, annotations ...Annotation) Option {
+	name, err := annotationName(annotations)
+	source := BindingKey{Type: reflect.TypeFor[A](), Annotation: name}
+	return &convertOption{target: reflect.TypeFor[B](), err: err, provide: &provideOption{
+		constructor: func(ctx InvocationContext, injector *Injector) (B, error) {
+			var res B
+			instance, err := injector.getInstanceOfAnnotatedType(ctx, source.Type, source.Annotation, false)
+			if err != nil {
+				return res, err
+			}
+			var a A
+			if instance.IsValid() {
+				a, _ = instance.Interface().(A)
+			}
+			return convert(a), nil
+		},
+		annotations: append(append([]Annotation{}, annotations...), In(PerLookUp), &conversionAnnotation{source: source}),
+		location:    callerLocation(),
+	}}
+}
+
+// withoutUnusableConversions remove the conversion bindings whose source has no binding
+func withoutUnusableConversions(bindings []*binding) []*binding {
+	for {
+		bound := make(map[BindingKey]bool)
+		for _, b := range bindings {
+			bound[b.key()] = true
+		}
+		res := make([]*binding, 0, len(bindings))
+		for _, b := range bindings {
+			if b.conversionFrom == nil || bound[*b.conversionFrom] {
+				res = append(res, b)
+			}
+		}
+		if len(res) == len(bindings) {
+			return res
+		}
+		bindings = res
+	}
+}
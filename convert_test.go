@@ -0,0 +1,81 @@
+package goinject
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ServerConfig struct {
+	Host string
+	Port int
+}
+
+type ListenOptions struct {
+	Addr string
+}
+
+func TestConvert(t *testing.T) {
+	toListenOptions := func(c *ServerConfig) ListenOptions {
+		return ListenOptions{Addr: c.Host + ":" + strconv.Itoa(c.Port)}
+	}
+	ctx := context.Background()
+
+	injector, err := NewInjector(
+		Provide(func() *ServerConfig { return &ServerConfig{Host: "localhost", Port: 8} }),
+		Convert(toListenOptions),
+	)
+	assert.Nil(t, err)
+	err = injector.Invoke(ctx, func(o ListenOptions) {
+		assert.Equal(t, "localhost:8", o.Addr)
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, injector.Warnings())
+
+	t.Run("Regular bindings should take precedence", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func() *ServerConfig { return &ServerConfig{Host: "localhost", Port: 8} }),
+			Provide(func() ListenOptions { return ListenOptions{Addr: ":9"} }),
+			Convert(toListenOptions),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(o ListenOptions) {
+			assert.Equal(t, ":9", o.Addr)
+		})
+		assert.Nil(t, err)
+		assert.Empty(t, injector.Warnings())
+	})
+
+	t.Run("Conversions without source binding should be ignored", func(t *testing.T) {
+		injector, err := NewInjector(Convert(toListenOptions))
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(o Optional[ListenOptions]) {
+			assert.Equal(t, OptionalMissing, o.State())
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Annotated conversions should convert the binding with the same annotation", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func() *ServerConfig { return &ServerConfig{Host: "admin", Port: 1} }, Named("admin")),
+			Convert(toListenOptions, Named("admin")),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(p struct {
+			Params
+			Options ListenOptions `inject:"admin"`
+		}) {
+			assert.Equal(t, "admin:1", p.Options.Addr)
+		})
+		assert.Nil(t, err)
+	})
+	t.Run("Conversions should reject As", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *ServerConfig { return &ServerConfig{} }),
+			Convert(toListenOptions, As(Type[any]())),
+		)
+		assert.ErrorContains(t, err, "cannot convert to goinject.ListenOptions: As(interface {}) cannot be used here")
+	})
+}
@@ -0,0 +1,214 @@
+// Package debugpage provides a goinject module serving debug pages of the injector over HTTP: bindings, dependency
+// graph, live singletons, startup report and recent invocation errors.
+//
+// The module registers an http.Handler in the Group group, serving the pages under Path:
+//
+//	goinject.NewInjector(
+//		debugpage.Module(goinject.OnEnvironmentVariable("DEBUG_PAGES", "true", false)),
+//		...
+//	)
+//
+// Pages expose the internals of the application, they should only be enabled on private listeners.
+package debugpage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// Group is the group the debug page http.Handler is added to using goinject.IntoGroup
+const Group = "http.handlers"
+
+// Path is the path prefix of the debug pages, the handler must be mounted on it
+const Path = "/debug/goinject/"
+
+const defaultMaxErrors = 50
+
+// InvocationError is an error returned by Invoke, as listed on the errors page
+type InvocationError struct {
+	Time         time.Time `json:"time"`
+	InvocationID string    `json:"invocationId"`
+	Message      string    `json:"message"`
+}
+
+// errorLog keep the last invocation errors notified to the injector observers
+type errorLog struct {
+	mu     sync.Mutex
+	max    int
+	errors []InvocationError
+}
+
+func (l *errorLog) observe(event goinject.Event) {
+	failed, ok := event.(goinject.InvocationFailedEvent)
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, InvocationError{
+		Time:         time.Now(),
+		InvocationID: failed.InvocationID,
+		Message:      failed.Err.Error(),
+	})
+	if len(l.errors) > l.max {
+		l.errors = append([]InvocationError(nil), l.errors[len(l.errors)-l.max:]...)
+	}
+}
+
+// recent return the logged errors, most recent first
+func (l *errorLog) recent() []InvocationError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res := make([]InvocationError, len(l.errors))
+	for i, e := range l.errors {
+		res[len(l.errors)-1-i] = e
+	}
+	return res
+}
+
+// Option configure the debug pages
+type Option func(l *errorLog)
+
+// WithMaxErrors set the number of invocation errors kept for the errors page, 50 by default (also used if n <= 0)
+func WithMaxErrors(n int) Option {
+	return func(l *errorLog) {
+		if n <= 0 {
+			n = defaultMaxErrors
+		}
+		l.max = n
+	}
+}
+
+// Module return a goinject module serving the debug pages if enabled evaluate to true, see Group and Path.
+// It registers an observer recording errors, so it must be given to goinject.NewInjector rather than installed in
+// another module.
+func Module(enabled goinject.Conditional, opts ...Option) goinject.Option {
+	log := &errorLog{max: defaultMaxErrors}
+	for _, opt := range opts {
+		opt(log)
+	}
+
+	return goinject.When(enabled,
+		goinject.WithObserver(log.observe),
+		goinject.Module("debugpage",
+			goinject.Provide(func(injector *goinject.Injector) http.Handler {
+				return newHandler(injector, log)
+			}, goinject.IntoGroup(Group), goinject.In(goinject.Singleton)),
+		),
+	)
+}
+
+type page struct {
+	name        string
+	description string
+	render      func(w http.ResponseWriter) error
+}
+
+func newHandler(injector *goinject.Injector, log *errorLog) http.Handler {
+	pages := []page{
+		{"bindings", "configured bindings", func(w http.ResponseWriter) error {
+			return writeBindings(w, injector.Graph())
+		}},
+		{"graph", "dependency graph, as JSON", func(w http.ResponseWriter) error {
+			return writeJSON(w, injector.Graph())
+		}},
+		{"singletons", "created singletons", func(w http.ResponseWriter) error {
+			return writeLines(w, injector.LiveSingletons())
+		}},
+		{"startup", "startup report", func(w http.ResponseWriter) error {
+			return writeStartupReport(w, injector)
+		}},
+		{"errors", "recent invocation errors, as JSON", func(w http.ResponseWriter) error {
+			return writeJSON(w, log.recent())
+		}},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+Path+"{$}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		var sb strings.Builder
+		sb.WriteString("<html><body><ul>\n")
+		for _, p := range pages {
+			fmt.Fprintf(&sb, "<li><a href=\"%s%s\">%s</a>: %s</li>\n", Path, p.name, p.name, p.description)
+		}
+		sb.WriteString("</ul></body></html>\n")
+		_, _ = w.Write([]byte(sb.String()))
+	})
+	for _, p := range pages {
+		mux.HandleFunc("GET "+Path+p.name, func(w http.ResponseWriter, _ *http.Request) {
+			if err := p.render(w); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		})
+	}
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	return err
+}
+
+func writeLines[T fmt.Stringer](w http.ResponseWriter, values []T) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	var sb strings.Builder
+	for _, v := range values {
+		sb.WriteString(v.String())
+		sb.WriteString("\n")
+	}
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+func writeBindings(w http.ResponseWriter, graph *goinject.Graph) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	var sb strings.Builder
+	for _, b := range graph.Bindings {
+		fmt.Fprintf(&sb, "%s (%s, provides %s)", b.Key, b.Scope, b.ProvidedType)
+		if b.Toggle != "" {
+			fmt.Fprintf(&sb, " toggle %s", b.Toggle)
+		}
+		sb.WriteString("\n")
+	}
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+func writeStartupReport(w http.ResponseWriter, injector *goinject.Injector) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	var sb strings.Builder
+	sb.WriteString("health:\n")
+	if err := injector.CheckHealth(); err != nil {
+		fmt.Fprintf(&sb, "  %s\n", strings.ReplaceAll(err.Error(), "\n", "\n  "))
+	} else {
+		sb.WriteString("  ok\n")
+	}
+	sb.WriteString("warnings:\n")
+	for _, warning := range injector.Warnings() {
+		fmt.Fprintf(&sb, "  %s\n", warning)
+	}
+	sb.WriteString("active scopes:\n")
+	scopes := injector.ActiveScopes()
+	names := make([]string, 0, len(scopes))
+	for name := range scopes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&sb, "  %s: %d\n", name, scopes[name])
+	}
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
@@ -0,0 +1,86 @@
+package debugpage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+type Repository struct{}
+
+type Mailer struct{}
+
+type handlersParams struct {
+	goinject.Params
+	Handlers []http.Handler `inject:"http.handlers,optional"`
+}
+
+func get(t *testing.T, handler http.Handler, path string) string {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestModule(t *testing.T) {
+	t.Setenv("DEBUG_PAGES", "true")
+	mailerErr := errors.New("smtp unavailable")
+	injector, err := goinject.NewInjector(
+		Module(goinject.OnEnvironmentVariable("DEBUG_PAGES", "true", false), WithMaxErrors(1)),
+		goinject.Provide(func() *Repository { return &Repository{} }),
+		goinject.Provide(func() (*Mailer, error) { return nil, mailerErr }, goinject.In(goinject.PerLookUp)),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	_ = injector.Invoke(goinject.WithInvocationID(ctx, "first"), func(_ *Mailer) {})
+	_ = injector.Invoke(goinject.WithInvocationID(ctx, "second"), func(_ *Mailer) {})
+
+	var handlers []http.Handler
+	err = injector.Invoke(ctx, func(params handlersParams) {
+		handlers = params.Handlers
+	})
+	assert.Nil(t, err)
+	assert.Len(t, handlers, 1)
+	handler := handlers[0]
+
+	assert.Contains(t, get(t, handler, Path), "href=\"/debug/goinject/graph\"")
+	assert.Contains(t, get(t, handler, Path+"bindings"),
+		"*debugpage.Mailer (inject.PerLookUp, provides *debugpage.Mailer)")
+	assert.Contains(t, get(t, handler, Path+"graph"), "\"key\": \"*debugpage.Repository\"")
+	assert.Contains(t, get(t, handler, Path+"singletons"), "*debugpage.Repository\n")
+	assert.Contains(t, get(t, handler, Path+"startup"), "health:\n  ok\n")
+	errorsPage := get(t, handler, Path+"errors")
+	assert.Contains(t, errorsPage, "\"invocationId\": \"second\"")
+	assert.Contains(t, errorsPage, "smtp unavailable")
+	assert.NotContains(t, errorsPage, "\"invocationId\": \"first\"")
+}
+
+func TestModuleDisabled(t *testing.T) {
+	assert.Nil(t, os.Unsetenv("DEBUG_PAGES"))
+	injector, err := goinject.NewInjector(
+		Module(goinject.OnEnvironmentVariable("DEBUG_PAGES", "true", false)),
+	)
+	assert.Nil(t, err)
+	err = injector.Invoke(context.Background(), func(params handlersParams) {
+		assert.Empty(t, params.Handlers)
+	})
+	assert.Nil(t, err)
+}
+
+func TestWithMaxErrors(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		log := &errorLog{}
+		WithMaxErrors(n)(log)
+		assert.Equal(t, defaultMaxErrors, log.max)
+		log.observe(goinject.InvocationFailedEvent{Err: errors.New("failed")})
+		assert.Len(t, log.recent(), 1)
+	}
+}
@@ -0,0 +1,34 @@
+package goinject
+
+// ErrorDecorator rewrite or enrich an error before it is returned by NewInjector or Injector.Invoke.
+// path is the resolution path at which the error occurred, it is empty for configuration errors
+// and errors returned by the invoked function itself.
+type ErrorDecorator func(err error, path ResolutionPath) error
+
+type errorDecoratorOption struct {
+	decorator ErrorDecorator
+}
+
+func (o *errorDecoratorOption) apply(mod *configuration) error {
+	if o.decorator == nil {
+		return newInjectorConfigurationError("cannot accept nil error decorator", nil)
+	}
+	mod.errorDecorators = append(mod.errorDecorators, o.decorator)
+	return nil
+}
+
+// WithErrorDecorator register an ErrorDecorator applied to errors returned by the injector.
+// Decorators are applied in registration order, each one receiving the error returned by the previous one.
+// Configuration errors are only decorated by decorators registered before the failing Option,
+// each problem of the ConfigurationReport being decorated separately.
+func WithErrorDecorator(decorator ErrorDecorator) InjectorOption {
+	return newInjectorOption("WithErrorDecorator", &errorDecoratorOption{decorator: decorator})
+}
+
+func decorateError(decorators []ErrorDecorator, err error) error {
+	path := resolutionPathOf(err)
+	for _, decorator := range decorators {
+		err = decorator(err, path)
+	}
+	return err
+}
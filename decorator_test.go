@@ -0,0 +1,83 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type codedError struct {
+	code  string
+	cause error
+}
+
+func (e *codedError) Error() string { return fmt.Sprintf("[%s] %s", e.code, e.cause) }
+
+func (e *codedError) Unwrap() error { return e.cause }
+
+func TestErrorDecorator(t *testing.T) {
+	t.Run("Decorator should receive resolution path of invoke errors", func(t *testing.T) {
+		var receivedPath ResolutionPath
+		injector, err := NewInjector(
+			Provide(func(_ *Parent) *Child { return &Child{} }, In(PerLookUp)),
+			WithErrorDecorator(func(err error, path ResolutionPath) error {
+				receivedPath = path
+				return &codedError{code: "E42", cause: err}
+			}),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func(_ *Child) {
+			assert.Fail(t, "should not be reached")
+		})
+		var coded *codedError
+		assert.ErrorAs(t, err, &coded)
+		assert.Equal(t, "E42", coded.code)
+		var expectedErrorType *injectionError
+		assert.ErrorAs(t, err, &expectedErrorType)
+		assert.Equal(t, "*goinject.Child -> *goinject.Parent", receivedPath.String())
+	})
+
+	t.Run("Decorators should be chained in registration order", func(t *testing.T) {
+		returnedErr := fmt.Errorf("provider error")
+		var receivedPath ResolutionPath
+		_, err := NewInjector(
+			WithErrorDecorator(func(err error, _ ResolutionPath) error {
+				return &codedError{code: "first", cause: err}
+			}),
+			WithErrorDecorator(func(err error, path ResolutionPath) error {
+				receivedPath = path
+				return &codedError{code: "second", cause: err}
+			}),
+			Provide(func() (*Parent, error) { return nil, returnedErr }, Named("parent")),
+		)
+		assert.ErrorIs(t, err, returnedErr)
+		assert.Equal(t, "[second] [first] failed to get singleton instance: provider for type "+
+			"\"*goinject.Parent\" returned error: provider error", err.Error())
+		assert.Equal(t, "*goinject.Parent(\"parent\")", receivedPath.String())
+	})
+
+	t.Run("Invocation errors should be decorated with an empty path", func(t *testing.T) {
+		var receivedPath ResolutionPath
+		called := false
+		injector, err := NewInjector(
+			WithErrorDecorator(func(err error, path ResolutionPath) error {
+				called = true
+				receivedPath = path
+				return err
+			}),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func() error { return fmt.Errorf("returned error") })
+		assert.NotNil(t, err)
+		assert.True(t, called)
+		assert.Empty(t, receivedPath)
+	})
+
+	t.Run("WithErrorDecorator should not accept nil", func(t *testing.T) {
+		_, err := NewInjector(WithErrorDecorator(nil))
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.Equal(t, "cannot accept nil error decorator", err.Error())
+	})
+}
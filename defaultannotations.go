@@ -0,0 +1,34 @@
+package goinject
+
+type defaultAnnotationsOption struct {
+	annotations []Annotation
+}
+
+func (o *defaultAnnotationsOption) apply(mod *configuration) error {
+	if len(mod.modules) == 0 {
+		return newInjectorConfigurationError("DefaultAnnotations must be given to a Module", nil)
+	}
+	return nil // installed by the module, see withDefaultAnnotations
+}
+
+// DefaultAnnotations return an Option, to be given to a Module, applying the annotations to all the bindings of the
+// module (including those of its nested modules) before their own annotations, which thus override them:
+//
+//	Module("repositories", DefaultAnnotations(In("request")), Provide(NewUserRepository), ...)
+//
+// Annotations that add up (e.g. IntoGroup) are applied both from the defaults and from the binding.
+func DefaultAnnotations(annotations ...Annotation) Option {
+	return &defaultAnnotationsOption{annotations: annotations}
+}
+
+// withDefaultAnnotations add the DefaultAnnotations among the options of a module to the default annotations, and
+// return the function restoring the default annotations of the enclosing module
+func (mod *configuration) withDefaultAnnotations(options []Option) func() {
+	enclosing := mod.moduleDefaults
+	for _, option := range options {
+		if defaults, ok := option.(*defaultAnnotationsOption); ok {
+			mod.moduleDefaults = append(append([]Annotation{}, mod.moduleDefaults...), defaults.annotations...)
+		}
+	}
+	return func() { mod.moduleDefaults = enclosing }
+}
@@ -0,0 +1,46 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultAnnotations(t *testing.T) {
+	injector, err := NewInjector(
+		Module("repositories",
+			Provide(func() *Parent { return &Parent{} }),
+			DefaultAnnotations(In(PerLookUp), Named("repository")),
+			Provide(func() *Child { return &Child{} }, In(Singleton)),
+			Module("nested",
+				DefaultAnnotations(Named("nested")),
+				Provide(func() *AppConfig { return &AppConfig{} }),
+			),
+		),
+		Provide(func() *Request { return &Request{} }),
+	)
+	assert.Nil(t, err)
+
+	scopes := make(map[string]string)
+	for _, b := range injector.Graph().Bindings {
+		scopes[b.Key] = b.Scope
+	}
+	assert.Equal(t, map[string]string{
+		`*goinject.Parent("repository")`: PerLookUp,
+		`*goinject.Child("repository")`:  Singleton,
+		`*goinject.AppConfig("nested")`:  PerLookUp,
+		`*goinject.Request`:              Singleton,
+	}, scopes)
+
+	err = injector.Invoke(context.Background(), func(p struct {
+		Params
+		Parent *Parent `inject:"repository"`
+	}) {
+		assert.NotNil(t, p.Parent)
+	})
+	assert.Nil(t, err)
+
+	_, err = NewInjector(DefaultAnnotations(In(PerLookUp)))
+	assert.ErrorContains(t, err, "DefaultAnnotations must be given to a Module")
+}
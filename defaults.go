@@ -0,0 +1,69 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationReflectType = reflect.TypeFor[time.Duration]()
+
+// parseLiteral convert a literal to a value of type t, it supports strings, booleans, numbers, durations and
+// slices of those (comma separated).
+func parseLiteral(t reflect.Type, literal string) (reflect.Value, error) {
+	res := reflect.New(t).Elem()
+	if t == durationReflectType {
+		d, err := time.ParseDuration(literal)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		res.SetInt(int64(d))
+		return res, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		res.SetString(literal)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(literal)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		res.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(literal, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		res.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(literal, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		res.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(literal, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		res.SetFloat(v)
+	case reflect.Slice:
+		if literal == "" {
+			return reflect.MakeSlice(t, 0, 0), nil
+		}
+		parts := strings.Split(literal, ",")
+		res = reflect.MakeSlice(t, 0, len(parts))
+		for _, part := range parts {
+			v, err := parseLiteral(t.Elem(), strings.TrimSpace(part))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			res = reflect.Append(res, v)
+		}
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s", t)
+	}
+	return res, nil
+}
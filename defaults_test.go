@@ -0,0 +1,50 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type HTTPPort int
+
+type ServerParams struct {
+	Params
+	Host    string        `inject:"http.host" default:"localhost"`
+	Port    HTTPPort      `inject:"http.port" default:"8080"`
+	Timeout time.Duration `inject:"http.timeout" default:"30s"`
+	Debug   bool          `inject:"http.debug" default:"true"`
+	Origins []string      `inject:"http.origins" default:"a.com, b.com"`
+}
+
+func TestParamsDefaultValues(t *testing.T) {
+	t.Run("Should use default values of missing bindings", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func() string { return "0.0.0.0" }, Named("http.host")),
+		)
+		assert.Nil(t, err)
+
+		err = injector.Invoke(context.Background(), func(p ServerParams) {
+			assert.Equal(t, "0.0.0.0", p.Host)
+			assert.Equal(t, HTTPPort(8080), p.Port)
+			assert.Equal(t, 30*time.Second, p.Timeout)
+			assert.True(t, p.Debug)
+			assert.Equal(t, []string{"a.com", "b.com"}, p.Origins)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should return error on invalid default value", func(t *testing.T) {
+		injector, err := NewInjector()
+		assert.Nil(t, err)
+
+		err = injector.Invoke(context.Background(), func(_ struct {
+			Params
+			Port int `inject:"port" default:"http"`
+		}) {
+		})
+		assert.ErrorContains(t, err, "invalid default value \"http\"")
+	})
+}
@@ -0,0 +1,40 @@
+package goinject
+
+import "fmt"
+
+type defaultScopeOption struct {
+	scope string
+}
+
+func (o *defaultScopeOption) apply(mod *configuration) error {
+	mod.defaultScope = o.scope
+	return nil
+}
+
+// DefaultScope return an InjectorOption changing the scope of the bindings provided without In annotation (see
+// also DefaultAnnotations), Singleton otherwise. It suits injectors that are themselves request-scoped or
+// job-scoped, e.g. in serverless runtimes. The scope must be registered (see RegisterScope) unless it is a builtin
+// one. Bindings that must be singletons, such as configuration bindings, file watchers or the EventBus, are
+// explicitly bound in Singleton and are not affected.
+func DefaultScope(scope string) InjectorOption {
+	return newInjectorOption("DefaultScope", &defaultScopeOption{scope: scope})
+}
+
+// applyDefaultScope set the default scope of the bindings without explicit scope
+func (mod *configuration) applyDefaultScope() []error {
+	if mod.defaultScope == "" {
+		return nil
+	}
+	switch _, registered := mod.scopes[mod.defaultScope]; {
+	case registered, mod.defaultScope == Singleton, mod.defaultScope == PerLookUp,
+		mod.defaultScope == PerInvocation:
+	default:
+		return []error{newInjectorConfigurationError(fmt.Sprintf("unknown default scope %q", mod.defaultScope), nil)}
+	}
+	for b := range mod.bindings {
+		if !b.explicitScope {
+			b.scope = mod.defaultScope
+		}
+	}
+	return nil
+}
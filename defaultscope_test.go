@@ -0,0 +1,76 @@
+package goinject
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultScope(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func() *Request { return &Request{ID: 1} }),
+		Provide(func() *Session { return &Session{ID: 2} }, In(Singleton)),
+		DefaultScope(PerLookUp),
+	)
+	assert.Nil(t, err)
+
+	var requests []*Request
+	var sessions []*Session
+	for i := 0; i < 2; i++ {
+		err = injector.Invoke(context.Background(), func(r *Request, s *Session) {
+			requests = append(requests, r)
+			sessions = append(sessions, s)
+		})
+		assert.Nil(t, err)
+	}
+	assert.NotSame(t, requests[0], requests[1])
+	assert.Same(t, sessions[0], sessions[1])
+	assert.Equal(t, map[BindingKey]string{KeyOf[*Request](): PerLookUp, KeyOf[*Session](): Singleton},
+		map[BindingKey]string{
+			injector.Metadata()[0].Key: injector.Metadata()[0].Scope,
+			injector.Metadata()[1].Key: injector.Metadata()[1].Scope,
+		})
+}
+
+func TestDefaultScopeShouldBeKnown(t *testing.T) {
+	_, err := NewInjector(DefaultScope("request"))
+	assert.ErrorContains(t, err, `unknown default scope "request"`)
+
+	_, err = NewInjector(
+		DefaultScope("request"),
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+	)
+	assert.Nil(t, err)
+}
+
+func TestDefaultScopeShouldKeepConfigurationSingletons(t *testing.T) {
+	t.Setenv("APP_LISTEN_ADDR", "0.0.0.0")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte("name: first\n"), 0o600))
+	injector, err := NewInjector(
+		DefaultScope(PerLookUp),
+		ProvideFromEnv[AppConfig]("APP_"),
+		ProvideFromFile[FileConfig](path, WatchAndRefresh(), PollInterval(5*time.Millisecond)),
+	)
+	assert.Nil(t, err)
+	defer injector.Shutdown()
+	for _, b := range injector.Metadata() {
+		assert.Equal(t, Singleton, b.Scope, b.Key.String())
+	}
+
+	ctx := context.Background()
+	t.Setenv("APP_LISTEN_ADDR", "127.0.0.1")
+	assert.Nil(t, injector.Refresh(ctx, KeyOf[*AppConfig]()))
+	assert.Nil(t, injector.Invoke(ctx, func(c *AppConfig) { assert.Equal(t, "127.0.0.1", c.ListenAddr) }))
+
+	assert.Nil(t, os.WriteFile(path, []byte("name: second\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		var name string
+		assert.Nil(t, injector.Invoke(ctx, func(c *FileConfig) { name = c.Name }))
+		return name == "second"
+	}, time.Second, 5*time.Millisecond)
+}
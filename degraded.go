@@ -0,0 +1,69 @@
+package goinject
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDegraded is matched (using errors.Is) by errors returned when resolving a NonCritical binding whose eager
+// creation failed
+var ErrDegraded = errors.New("binding is degraded")
+
+type degradedBindingError struct {
+	key   BindingKey
+	cause error
+}
+
+var _ error = &degradedBindingError{}
+
+func newDegradedBindingError(key BindingKey, cause error) *degradedBindingError {
+	return &degradedBindingError{key, cause}
+}
+
+func (e *degradedBindingError) Error() string {
+	return fmt.Sprintf("binding %s is degraded: %s", e.key, e.cause)
+}
+
+func (e *degradedBindingError) Unwrap() []error { return []error{ErrDegraded, e.cause} }
+
+type nonCriticalAnnotation struct{}
+
+func (a *nonCriticalAnnotation) apply(b *binding) error {
+	b.nonCritical = true
+	return nil
+}
+
+// NonCritical return an annotation allowing the eager creation of a singleton to fail without failing
+// NewInjector. The binding is then degraded: resolving it return an error matching ErrDegraded, the failure is
+// notified to observers as a BindingDegradedEvent and reported by Injector.CheckHealth.
+func NonCritical() Annotation {
+	return &nonCriticalAnnotation{}
+}
+
+// CheckHealth return an error joining the errors of degraded bindings, or nil if no binding is degraded
+func (injector *Injector) CheckHealth() error {
+	injector.bindingsMu.RLock()
+	defer injector.bindingsMu.RUnlock()
+	errs := make([]error, 0, len(injector.degraded))
+	for _, b := range injector.eagerBindings {
+		if err, ok := injector.degraded[b]; ok {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type deferEagerErrorsOption struct{}
+
+func (o *deferEagerErrorsOption) apply(mod *configuration) error {
+	mod.deferEagerErrors = true
+	return nil
+}
+
+// DeferEagerErrors return an InjectorOption making every singleton NonCritical: a failing eager creation does not fail
+// NewInjector, the error is returned (wrapped, matching ErrDegraded) only when the binding is resolved.
+// It suits CLIs with many subcommands, which should not fail to start because an unrelated subsystem is
+// misconfigured.
+func DeferEagerErrors() InjectorOption {
+	return newInjectorOption("DeferEagerErrors", &deferEagerErrorsOption{})
+}
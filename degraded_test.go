@@ -0,0 +1,82 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type SidecarClient struct {
+}
+
+func TestNonCritical(t *testing.T) {
+	sidecarErr := errors.New("sidecar unreachable")
+	var events []Event
+	injector, err := NewInjector(
+		WithObserver(func(event Event) {
+			events = append(events, event)
+		}),
+		Provide(func() (*SidecarClient, error) { return nil, sidecarErr }, NonCritical()),
+		Provide(func() *Parent { return &Parent{} }),
+	)
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, BindingDegradedEvent{
+		Key: BindingKey{Type: reflect.TypeFor[*SidecarClient]()},
+		Err: events[0].(BindingDegradedEvent).Err,
+	}, events[0])
+	assert.ErrorIs(t, events[0].(BindingDegradedEvent).Err, sidecarErr)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(p *Parent) {
+		assert.NotNil(t, p)
+	})
+	assert.Nil(t, err)
+
+	err = injector.Invoke(ctx, func(_ *SidecarClient) {
+		assert.Fail(t, "should not be reached")
+	})
+	assert.ErrorIs(t, err, ErrDegraded)
+	assert.ErrorIs(t, err, sidecarErr)
+
+	healthErr := injector.CheckHealth()
+	assert.ErrorIs(t, healthErr, ErrDegraded)
+	assert.ErrorContains(t, healthErr, "binding *goinject.SidecarClient is degraded")
+
+	t.Run("Critical singletons should still fail injector creation", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() (*SidecarClient, error) { return nil, sidecarErr }),
+		)
+		assert.ErrorIs(t, err, sidecarErr)
+	})
+
+	t.Run("Healthy injector should report no error", func(t *testing.T) {
+		healthy, err := NewInjector(Provide(func() *Parent { return &Parent{} }, NonCritical()))
+		assert.Nil(t, err)
+		assert.Nil(t, healthy.CheckHealth())
+	})
+
+	t.Run("DeferEagerErrors should defer errors of every singleton to their resolution", func(t *testing.T) {
+		deferred, err := NewInjector(
+			DeferEagerErrors(),
+			Provide(func() (*SidecarClient, error) { return nil, sidecarErr }),
+			Provide(func(_ *SidecarClient) *Child { return &Child{} }),
+			Provide(func() *Parent { return &Parent{} }),
+		)
+		assert.Nil(t, err)
+		assert.Nil(t, deferred.Invoke(ctx, func(_ *Parent) {}))
+		err = deferred.Invoke(ctx, func(_ *Child) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorIs(t, err, ErrDegraded)
+		assert.ErrorIs(t, err, sidecarErr)
+	})
+
+	t.Run("WithObserver should not accept nil", func(t *testing.T) {
+		_, err := NewInjector(WithObserver(nil))
+		assert.IsType(t, err, &ConfigurationReport{})
+	})
+}
@@ -0,0 +1,41 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+)
+
+type deprecatedAnnotation struct {
+	message string
+}
+
+func (a *deprecatedAnnotation) apply(b *binding) error {
+	if a.message == "" {
+		return newInjectorConfigurationError("argument of Deprecated must not be empty", nil)
+	}
+	b.deprecation = a.message
+	return nil
+}
+
+// Deprecated return an annotation marking the binding as deprecated, message telling what to use instead (e.g.
+// "use *NewClient instead, removal in v3"). Each injection point of the binding (consumer type and parameter or
+// field) is reported once as a WarningEvent of kind WarningDeprecatedBinding.
+func Deprecated(message string) Annotation {
+	return &deprecatedAnnotation{message: message}
+}
+
+// warnDeprecated report the injection of the deprecated binding b at the pending injection point of ctx
+func (injector *Injector) warnDeprecated(ctx context.Context, b *binding) {
+	callSite := "outside of an injection point"
+	if ip := pendingInjectionPoint(ctx); ip.target != nil && ip.field != "" {
+		callSite = fmt.Sprintf("in field %s of %s", ip.field, ip.target)
+	} else if ip.target != nil {
+		callSite = fmt.Sprintf("in argument #%d of %s", ip.position, ip.target)
+	}
+	injector.warn(WarningEvent{
+		Kind:     WarningDeprecatedBinding,
+		Key:      b.key(),
+		Location: b.location,
+		Message:  fmt.Sprintf("deprecated binding injected %s: %s", callSite, b.deprecation),
+	})
+}
@@ -0,0 +1,44 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated(t *testing.T) {
+	type consumer struct{}
+	type params struct {
+		Params
+		Parent *Parent `inject:""`
+	}
+	var events []Event
+	injector, err := NewInjector(
+		WithObserver(func(event Event) {
+			events = append(events, event)
+		}),
+		Provide(func() *Parent { return &Parent{} }, Deprecated("use *Child instead")),
+		Provide(func(_ *Parent) *consumer { return &consumer{} }, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		err = injector.Invoke(ctx, func(_ *consumer, _ params) {})
+		assert.Nil(t, err)
+	}
+	warnings := injector.Warnings()
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, WarningDeprecatedBinding, warnings[0].Kind)
+	assert.Equal(t, KeyOf[*Parent](), warnings[0].Key)
+	assert.Contains(t, warnings[0].Location, "deprecated_test.go")
+	assert.Equal(t, "deprecated binding injected in argument #0 of *goinject.consumer: use *Child instead",
+		warnings[0].Message)
+	assert.Regexp(t, `^deprecated binding injected in field Parent of func\(.*\): use \*Child instead$`,
+		warnings[1].Message)
+	assert.Len(t, events, 2)
+
+	_, err = NewInjector(Provide(func() *Parent { return &Parent{} }, Deprecated("")))
+	assert.ErrorContains(t, err, "argument of Deprecated must not be empty")
+}
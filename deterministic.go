@@ -0,0 +1,33 @@
+package goinject
+
+import "sort"
+
+type deterministicOption struct{}
+
+func (o *deterministicOption) apply(mod *configuration) error {
+	mod.deterministic = true
+	return nil
+}
+
+// Deterministic return an InjectorOption forcing the injector to create eager singletons one at a time in registration
+// order and to keep multi-bindings in registration order.
+// Without it, both orders are unspecified and may change between runs. It is intended for golden tests and for
+// reproducing wiring bugs observed in production.
+func Deterministic() InjectorOption {
+	return newInjectorOption("Deterministic", &deterministicOption{})
+}
+
+// orderedBindings return the configured bindings, sorted by registration order if the configuration is
+// deterministic
+func (mod *configuration) orderedBindings() []*binding {
+	res := make([]*binding, 0, len(mod.bindings))
+	for b := range mod.bindings {
+		res = append(res, b)
+	}
+	if mod.deterministic {
+		sort.Slice(res, func(i, j int) bool {
+			return res[i].order < res[j].order
+		})
+	}
+	return res
+}
@@ -0,0 +1,101 @@
+package goinject
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Circle struct {
+}
+
+func (c *Circle) Name() string {
+	return "circle"
+}
+
+func TestDeterministic(t *testing.T) {
+	t.Run("Multi-bindings should keep registration order", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			injector, err := NewInjector(
+				Deterministic(),
+				Provide(func() *Square { return &Square{} }, As(Type[Shape]())),
+				Provide(func() *Rectangle { return &Rectangle{} }, As(Type[Shape]())),
+				Provide(func() *Circle { return &Circle{} }, As(Type[Shape]())),
+			)
+			assert.Nil(t, err)
+			err = injector.Invoke(context.Background(), func(shapes []Shape) {
+				var names []string
+				for _, shape := range shapes {
+					names = append(names, shape.Name())
+				}
+				assert.Equal(t, []string{"square", "rectangle", "circle"}, names)
+			})
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("Eager singletons should be created in registration order", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			var created []string
+			_, err := NewInjector(
+				Deterministic(),
+				Provide(func() *Square {
+					created = append(created, "square")
+					return &Square{}
+				}),
+				Provide(func() *Rectangle {
+					created = append(created, "rectangle")
+					return &Rectangle{}
+				}),
+				Provide(func() *Circle {
+					created = append(created, "circle")
+					return &Circle{}
+				}),
+			)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"square", "rectangle", "circle"}, created)
+		}
+	})
+
+	t.Run("Registration order should be unique after replacing a module", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			injector, err := NewInjector(
+				Deterministic(),
+				Provide(func() *Square { return &Square{} }, As(Type[Shape]())),
+				Module("legacy",
+					Provide(func() *Rectangle { return &Rectangle{} }, Named("legacy")),
+					Provide(func() *Parent { return &Parent{} }),
+				),
+				Provide(func() *Rectangle { return &Rectangle{} }, As(Type[Shape]())),
+				ReplaceModule("legacy"),
+				Provide(func() *Circle { return &Circle{} }, As(Type[Shape]())),
+				Provide(func() *Child { return &Child{} }),
+			)
+			assert.Nil(t, err)
+			orders := make(map[int]*binding)
+			for typ, named := range injector.bindings {
+				if typ == reflect.TypeOf(injector) {
+					continue // the injector binding is not registered by Provide
+				}
+				for _, bindings := range named {
+					for _, b := range bindings {
+						if other, ok := orders[b.order]; ok {
+							assert.Same(t, other, b, "duplicate registration order %d", b.order)
+						}
+						orders[b.order] = b
+					}
+				}
+			}
+			err = injector.Invoke(context.Background(), func(shapes []Shape) {
+				var names []string
+				for _, shape := range shapes {
+					names = append(names, shape.Name())
+				}
+				assert.Equal(t, []string{"square", "rectangle", "circle"}, names)
+			})
+			assert.Nil(t, err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package goinject
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type debugResolutionsOption struct{}
+
+func (o *debugResolutionsOption) apply(mod *configuration) error {
+	mod.debugResolutions = true
+	return nil
+}
+
+// DebugResolutions return an InjectorOption enabling the tracking of in-flight resolutions, reported by Injector.Dump.
+// Tracking has a cost on each resolution, it is intended for diagnosing startup hangs and scope deadlocks.
+func DebugResolutions() InjectorOption {
+	return newInjectorOption("DebugResolutions", &debugResolutionsOption{})
+}
+
+// resolutionTracker keep track of in-flight resolutions
+type resolutionTracker struct {
+	mu       sync.Mutex
+	inFlight map[*trackedResolution]bool
+}
+
+type trackedResolution struct {
+	goroutine uint64
+	path      ResolutionPath
+	since     time.Time
+	creating  bool // true when the goroutine is creating the instance, holding the scope creation lock
+}
+
+func newResolutionTracker() *resolutionTracker {
+	return &resolutionTracker{inFlight: make(map[*trackedResolution]bool)}
+}
+
+func (t *resolutionTracker) begin(path ResolutionPath) *trackedResolution {
+	r := &trackedResolution{goroutine: currentGoroutineID(), path: path, since: time.Now()}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inFlight[r] = true
+	return r
+}
+
+func (t *resolutionTracker) markCreating(r *trackedResolution) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r.creating = true
+}
+
+func (t *resolutionTracker) end(r *trackedResolution) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.inFlight, r)
+}
+
+// currentGoroutineID parse the goroutine ID from the stack trace header ("goroutine 42 [running]:")
+func currentGoroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
+
+// Dump write the in-flight resolutions of each goroutine, from the outermost to the innermost binding, telling
+// which ones are being created (the goroutine holding the scope creation lock) and which ones are waiting.
+// It requires the DebugResolutions option.
+func (injector *Injector) Dump(w io.Writer) error {
+	if injector.tracker == nil {
+		_, err := fmt.Fprintln(w, "resolution tracking is disabled, use DebugResolutions option to enable it")
+		return err
+	}
+
+	injector.tracker.mu.Lock()
+	byGoroutine := make(map[uint64][]trackedResolution)
+	for r := range injector.tracker.inFlight {
+		byGoroutine[r.goroutine] = append(byGoroutine[r.goroutine], *r)
+	}
+	injector.tracker.mu.Unlock()
+
+	goroutines := make([]uint64, 0, len(byGoroutine))
+	for g := range byGoroutine {
+		goroutines = append(goroutines, g)
+	}
+	sort.Slice(goroutines, func(i, j int) bool { return goroutines[i] < goroutines[j] })
+
+	now := time.Now()
+	if _, err := fmt.Fprintf(w, "%d goroutine(s) resolving bindings\n", len(goroutines)); err != nil {
+		return err
+	}
+	for _, g := range goroutines {
+		resolutions := byGoroutine[g]
+		sort.Slice(resolutions, func(i, j int) bool { return len(resolutions[i].path) < len(resolutions[j].path) })
+		if _, err := fmt.Fprintf(w, "\ngoroutine %d:\n", g); err != nil {
+			return err
+		}
+		for _, r := range resolutions {
+			state := "waiting for instance"
+			if r.creating {
+				state = "creating (holds creation lock)"
+			}
+			if _, err := fmt.Fprintf(w, "  %s: %s for %s\n",
+				r.path[len(r.path)-1], state, now.Sub(r.since).Round(time.Millisecond)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package goinject
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDump(t *testing.T) {
+	t.Run("Should report in-flight resolutions", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		injector, err := NewInjector(
+			DebugResolutions(),
+			RegisterScope("lazy", newSingletonScope()),
+			Provide(func() *Parent {
+				close(started)
+				<-release
+				return &Parent{}
+			}, In("lazy")),
+			Provide(func(p *Parent) *Child { return &Child{parent: p} }, In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, injector.Invoke(context.Background(), func(_ *Child) {}))
+		}()
+		<-started
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, injector.Invoke(context.Background(), func(_ *Parent) {}))
+		}()
+
+		var out bytes.Buffer
+		assert.Eventually(t, func() bool {
+			out.Reset()
+			assert.Nil(t, injector.Dump(&out))
+			return strings.Contains(out.String(), "2 goroutine(s) resolving bindings")
+		}, time.Second, time.Millisecond)
+		dump := out.String()
+		assert.Contains(t, dump, "*goinject.Child: creating (holds creation lock)")
+		assert.Contains(t, dump, "*goinject.Parent: creating (holds creation lock)")
+		assert.Contains(t, dump, "*goinject.Parent: waiting for instance")
+
+		close(release)
+		wg.Wait()
+		out.Reset()
+		assert.Nil(t, injector.Dump(&out))
+		assert.Equal(t, "0 goroutine(s) resolving bindings\n", out.String())
+	})
+
+	t.Run("Should tell when tracking is disabled", func(t *testing.T) {
+		injector, err := NewInjector()
+		assert.Nil(t, err)
+		var out bytes.Buffer
+		assert.Nil(t, injector.Dump(&out))
+		assert.Contains(t, out.String(), "resolution tracking is disabled")
+	})
+}
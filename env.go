@@ -0,0 +1,86 @@
+package goinject
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+type envOption struct {
+	valueType reflect.Type
+	prefix    string
+	options   []ConfigOption
+	location  string
+}
+
+func (o *envOption) apply(mod *configuration) error {
+	if o.valueType.Kind() != reflect.Struct {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("ProvideFromEnv type parameter must be a struct, got %s", o.valueType), nil)
+	}
+	source, err := newConfigSource(mod, o.valueType, o.options, o.location)
+	if err != nil {
+		return err
+	}
+	return source.provideOption(func() (reflect.Value, error) {
+		return readEnv(o.valueType, o.prefix)
+	}).apply(mod)
+}
+
+// ProvideFromEnv return an Option providing a *T singleton read from environment variables.
+// Each field of the struct T is read from the variable named prefix followed by the env tag of the field or,
+// if missing, by its name in upper snake case (e.g. "APP_" prefix and ListenAddr field read APP_LISTEN_ADDR).
+// Fields may be strings, booleans, numbers, time.Duration or slices of those (comma separated), the default tag
+// declares the value of unset variables. The binding is Refreshable: call Injector.Refresh with KeyOf[*T]()
+// to read the environment again.
+func ProvideFromEnv[T any](prefix string, opts ...ConfigOption) Option {
+	return &envOption{
+		valueType: reflect.TypeFor[T](),
+		prefix:    prefix,
+		options:   opts,
+		location:  callerLocation(),
+	}
+}
+
+func readEnv(t reflect.Type, prefix string) (reflect.Value, error) {
+	res := reflect.New(t)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			name = upperSnakeCase(field.Name)
+		}
+		literal, ok := os.LookupEnv(prefix + name)
+		if !ok {
+			if literal, ok = field.Tag.Lookup("default"); !ok {
+				continue
+			}
+		}
+		value, err := parseLiteral(field.Type, literal)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("failed to read environment variable %s: %w", prefix+name, err)
+		}
+		res.Elem().Field(i).Set(value)
+	}
+	return res, nil
+}
+
+// upperSnakeCase convert a Go identifier to upper snake case ("ListenAddr" and "HTTPPort" become "LISTEN_ADDR"
+// and "HTTP_PORT")
+func upperSnakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
@@ -0,0 +1,98 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type AppConfig struct {
+	ListenAddr string
+	HTTPPort   int           `default:"8080"`
+	Debug      bool          `env:"VERBOSE"`
+	Timeout    time.Duration `default:"5s"`
+	Origins    []string
+}
+
+type AppServer struct {
+	config *AppConfig
+}
+
+func TestProvideFromEnv(t *testing.T) {
+	validator := WithValidator(func(c *AppConfig) error {
+		if c.ListenAddr == "" {
+			return errors.New("listen address is required")
+		}
+		return nil
+	})
+
+	t.Run("Should read the configuration from environment", func(t *testing.T) {
+		t.Setenv("APP_LISTEN_ADDR", "0.0.0.0")
+		t.Setenv("APP_VERBOSE", "true")
+		t.Setenv("APP_ORIGINS", "a.com,b.com")
+		injector, err := NewInjector(ProvideFromEnv[AppConfig]("APP_", validator))
+		assert.Nil(t, err)
+
+		err = injector.Invoke(context.Background(), func(c *AppConfig) {
+			assert.Equal(t, &AppConfig{
+				ListenAddr: "0.0.0.0",
+				HTTPPort:   8080,
+				Debug:      true,
+				Timeout:    5 * time.Second,
+				Origins:    []string{"a.com", "b.com"},
+			}, c)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should run validators", func(t *testing.T) {
+		_, err := NewInjector(ProvideFromEnv[AppConfig]("APP_", validator))
+		assert.ErrorContains(t, err, "invalid configuration *goinject.AppConfig: listen address is required")
+	})
+
+	t.Run("Should reject validator of another type", func(t *testing.T) {
+		_, err := NewInjector(ProvideFromEnv[AppConfig]("APP_", WithValidator(func(_ *Parent) error { return nil })))
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.ErrorContains(t, err, "argument of WithValidator must be a function accepting *goinject.AppConfig")
+	})
+
+	t.Run("Should refresh the configuration and its refreshable dependents", func(t *testing.T) {
+		t.Setenv("APP_LISTEN_ADDR", "0.0.0.0")
+		var refreshed []BindingKey
+		injector, err := NewInjector(
+			ProvideFromEnv[AppConfig]("APP_", validator),
+			Provide(func(c *AppConfig) *AppServer { return &AppServer{config: c} }, Refreshable()),
+			WithObserver(func(event Event) {
+				if e, ok := event.(BindingRefreshedEvent); ok {
+					refreshed = append(refreshed, e.Key)
+				}
+			}),
+		)
+		assert.Nil(t, err)
+
+		t.Setenv("APP_LISTEN_ADDR", "127.0.0.1")
+		assert.Nil(t, injector.Refresh(context.Background(), KeyOf[*AppConfig]()))
+		assert.Equal(t, []BindingKey{KeyOf[*AppConfig](), KeyOf[*AppServer]()}, refreshed)
+		err = injector.Invoke(context.Background(), func(s *AppServer) {
+			assert.Equal(t, "127.0.0.1", s.config.ListenAddr)
+		})
+		assert.Nil(t, err)
+
+		t.Setenv("APP_LISTEN_ADDR", "")
+		err = injector.Refresh(context.Background(), KeyOf[*AppConfig]())
+		assert.ErrorContains(t, err, "listen address is required")
+		err = injector.Invoke(context.Background(), func(s *AppServer) {
+			assert.Equal(t, "127.0.0.1", s.config.ListenAddr)
+		})
+		assert.Nil(t, err)
+	})
+}
+
+func TestUpperSnakeCase(t *testing.T) {
+	assert.Equal(t, "LISTEN_ADDR", upperSnakeCase("ListenAddr"))
+	assert.Equal(t, "HTTP_PORT", upperSnakeCase("HTTPPort"))
+	assert.Equal(t, "PORT", upperSnakeCase("Port"))
+}
@@ -1,6 +1,7 @@
 package goinject
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 )
@@ -66,3 +67,42 @@ func (e *injectorConfigurationError) Error() string {
 }
 
 func (e *injectorConfigurationError) Unwrap() error { return e.cause }
+
+// resolutionPathError carries the resolution path at which the cause error occurred,
+// it does not alter the cause message.
+type resolutionPathError struct {
+	path  ResolutionPath
+	cause error
+}
+
+var _ error = &resolutionPathError{}
+
+func newResolutionPathError(path ResolutionPath, cause error) *resolutionPathError {
+	return &resolutionPathError{path, cause}
+}
+
+func (e *resolutionPathError) Error() string { return e.cause.Error() }
+
+func (e *resolutionPathError) Unwrap() error { return e.cause }
+
+// errQuotaExceeded is returned by instance registries when an instance cannot be created because of their quota
+var errQuotaExceeded = errors.New("instance quota exceeded")
+
+// InstanceQuotaError is returned when resolving a binding would create more instances than allowed in its scope
+// (see WithMaxInstances and MaxCachedInstances)
+type InstanceQuotaError struct {
+	Scope        string     // name of the scope of the binding
+	Key          BindingKey // key of the binding that could not be created
+	MaxInstances int
+}
+
+var _ error = &InstanceQuotaError{}
+
+func newInstanceQuotaError(binding *binding, maxInstances int) *InstanceQuotaError {
+	return &InstanceQuotaError{Scope: binding.scope, Key: binding.key(), MaxInstances: maxInstances}
+}
+
+func (e *InstanceQuotaError) Error() string {
+	return fmt.Sprintf("cannot create instance of binding %s: scope %q reached its quota of %d instances",
+		e.Key, e.Scope, e.MaxInstances)
+}
@@ -0,0 +1,159 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Subscriber is implemented by bindings that want to receive events of type E published through the Publisher
+// provided by the EventBus module. Such bindings are registered automatically, whatever their scope.
+type Subscriber[E any] interface {
+	HandleEvent(ctx context.Context, event E) error
+}
+
+// Publisher publish events to all bindings implementing Subscriber for the event type
+type Publisher interface {
+	// Publish call HandleEvent of every subscriber of the dynamic type of event (in registration order),
+	// subscriber instances are resolved using ctx. Errors returned by subscribers are joined.
+	Publish(ctx context.Context, event any) error
+}
+
+const handleEventMethodName = "HandleEvent"
+
+var contextReflectType = reflect.TypeFor[context.Context]()
+
+type eventBus struct {
+	injector    *Injector
+	subscribers sync.Map // subscriberList by event reflect.Type
+}
+
+// subscriberList hold the bindings subscribing to an event type, computed for a version of the injector bindings
+type subscriberList struct {
+	version  uint64
+	bindings []*binding
+}
+
+var _ Publisher = new(eventBus)
+
+// EventBus return a module providing an in-process Publisher
+func EventBus() Option {
+	return Module("goinject.EventBus",
+		Provide(func(injector *Injector) *eventBus {
+			return &eventBus{injector: injector}
+		}, As(Type[Publisher]()), In(Singleton)),
+	)
+}
+
+func (bus *eventBus) Publish(ctx context.Context, event any) error {
+	if event == nil {
+		return newInvalidInputError("can't publish nil event")
+	}
+	var errs []error
+	for _, b := range bus.activeSubscribers(ctx, reflect.TypeOf(event)) {
+		if b.removed.Load() {
+			continue
+		}
+		instance, err := bus.injector.getInjectedInstance(ctx, b)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get subscriber %s: %w", b.key(), err))
+			continue
+		}
+		if instance.Kind() == reflect.Interface {
+			instance = instance.Elem()
+		}
+		handleEvent := instance.MethodByName(handleEventMethodName)
+		if !handleEvent.IsValid() {
+			errs = append(errs, fmt.Errorf("subscriber %s: injected %s does not implement %s",
+				b.key(), instance.Type(), handleEventMethodName))
+			continue
+		}
+		res := handleEvent.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(event)})
+		if errValue := res[0].Interface(); errValue != nil {
+			errs = append(errs, fmt.Errorf("subscriber %s returned error: %w", b.key(), errValue.(error)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// activeSubscribers return the subscribers of eventType which are active in ctx (see Injector.activeBindings),
+// in registration order
+func (bus *eventBus) activeSubscribers(ctx context.Context, eventType reflect.Type) []*binding {
+	byKey := make(map[BindingKey][]*binding)
+	for _, b := range bus.subscriberBindings(eventType) {
+		byKey[b.key()] = append(byKey[b.key()], b)
+	}
+	var res []*binding
+	for _, bindings := range byKey {
+		res = append(res, bus.injector.activeBindings(ctx, bindings)...)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].order < res[j].order
+	})
+	return res
+}
+
+// subscriberBindings return the subscribers of eventType, they are cached until the injector bindings are updated
+// (e.g. by Swap, Stub or Remove)
+func (bus *eventBus) subscriberBindings(eventType reflect.Type) []*binding {
+	version := bus.injector.bindingsVersion.Load()
+	if cached, ok := bus.subscribers.Load(eventType); ok && cached.(subscriberList).version == version {
+		return cached.(subscriberList).bindings
+	}
+	var res []*binding
+	for _, b := range bus.injector.allBindings() {
+		if isSubscriberOf(b.typeof, eventType) || slices.Contains(b.subscribes, eventType) {
+			res = append(res, b)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].order < res[j].order
+	})
+	bus.subscribers.Store(eventType, subscriberList{version: version, bindings: res})
+	return res
+}
+
+// isSubscriberOf check if t implements Subscriber[E] where E is eventType
+func isSubscriberOf(t, eventType reflect.Type) bool {
+	method, ok := t.MethodByName(handleEventMethodName)
+	if !ok {
+		return false
+	}
+	methodType := method.Type
+	offset := 1 // method of concrete type have the receiver as first argument
+	if t.Kind() == reflect.Interface {
+		offset = 0
+	}
+	return methodType.NumIn() == offset+2 &&
+		methodType.In(offset) == contextReflectType &&
+		methodType.In(offset+1) == eventType &&
+		methodType.NumOut() == 1 &&
+		methodType.Out(0) == errorReflectType
+}
+
+type subscribeAnnotation struct {
+	eventType      reflect.Type
+	subscriberType reflect.Type
+}
+
+func (a *subscribeAnnotation) apply(b *binding) error {
+	if !b.providedType.Implements(a.subscriberType) {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("%s does not implement %s as specified in Subscribe argument", b.providedType, a.subscriberType),
+			nil,
+		)
+	}
+	b.subscribes = append(b.subscribes, a.eventType)
+	return nil
+}
+
+// Subscribe return an annotation registering the binding as a subscriber of events of type E, even if it is bound
+// to a type that does not implement Subscriber[E] (see As). The configuration fails if the provided type does not
+// implement Subscriber[E].
+func Subscribe[E any]() Annotation {
+	return &subscribeAnnotation{eventType: reflect.TypeFor[E](), subscriberType: reflect.TypeFor[Subscriber[E]]()}
+}
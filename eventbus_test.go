@@ -0,0 +1,132 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type UserCreated struct {
+	Name string
+}
+
+type UserDeleted struct {
+	Name string
+}
+
+type WelcomeMailer struct {
+	sent []string
+}
+
+func (m *WelcomeMailer) HandleEvent(_ context.Context, event UserCreated) error {
+	m.sent = append(m.sent, event.Name)
+	return nil
+}
+
+type FailingAuditor struct {
+}
+
+func (a *FailingAuditor) HandleEvent(_ context.Context, _ UserCreated) error {
+	return errors.New("audit sink unavailable")
+}
+
+type UserService struct {
+	publisher Publisher
+}
+
+func TestEventBus(t *testing.T) {
+	t.Run("Should dispatch events to subscribers", func(t *testing.T) {
+		injector, err := NewInjector(
+			EventBus(),
+			Provide(func() *WelcomeMailer { return &WelcomeMailer{} }, Subscribe[UserCreated]()),
+			Provide(func(p Publisher) *UserService { return &UserService{publisher: p} }),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		err = injector.Invoke(ctx, func(s *UserService, m *WelcomeMailer) error {
+			if err := s.publisher.Publish(ctx, UserCreated{Name: "alice"}); err != nil {
+				return err
+			}
+			if err := s.publisher.Publish(ctx, UserDeleted{Name: "bob"}); err != nil {
+				return err
+			}
+			assert.Equal(t, []string{"alice"}, m.sent)
+			return nil
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should join subscriber errors", func(t *testing.T) {
+		injector, err := NewInjector(
+			EventBus(),
+			Provide(func() *WelcomeMailer { return &WelcomeMailer{} }),
+			Provide(func() *FailingAuditor { return &FailingAuditor{} }),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		err = injector.Invoke(ctx, func(p Publisher, m *WelcomeMailer) {
+			publishErr := p.Publish(ctx, UserCreated{Name: "alice"})
+			assert.ErrorContains(t, publishErr, "audit sink unavailable")
+			assert.Equal(t, []string{"alice"}, m.sent)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should dispatch events to subscribers bound after the first Publish", func(t *testing.T) {
+		injector, err := NewInjector(EventBus())
+		assert.Nil(t, err)
+		ctx := context.Background()
+		stubbed := &WelcomeMailer{}
+		err = injector.Invoke(ctx, func(p Publisher) {
+			assert.Nil(t, p.Publish(ctx, UserCreated{Name: "alice"}))
+			restore, stubErr := injector.Stub(KeyOf[*WelcomeMailer](), stubbed)
+			assert.Nil(t, stubErr)
+			assert.Nil(t, p.Publish(ctx, UserCreated{Name: "bob"}))
+			restore()
+			assert.Nil(t, p.Publish(ctx, UserCreated{Name: "carol"}))
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"bob"}, stubbed.sent)
+	})
+
+	t.Run("Subscribe should register bindings bound to another type", func(t *testing.T) {
+		type Mailer interface{}
+		injector, err := NewInjector(
+			EventBus(),
+			Provide(func() *WelcomeMailer { return &WelcomeMailer{} }, As(Type[Mailer]()), Subscribe[UserCreated]()),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		err = injector.Invoke(ctx, func(p Publisher, m Mailer) {
+			assert.Nil(t, p.Publish(ctx, UserCreated{Name: "alice"}))
+			assert.Equal(t, []string{"alice"}, m.(*WelcomeMailer).sent)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should ignore disabled subscribers", func(t *testing.T) {
+		injector, err := NewInjector(
+			EventBus(),
+			Provide(func() *WelcomeMailer { return &WelcomeMailer{} }),
+			Provide(func() *FailingAuditor { return &FailingAuditor{} }, Toggleable("audit")),
+		)
+		assert.Nil(t, err)
+		injector.SetToggle("audit", false)
+		ctx := context.Background()
+		err = injector.Invoke(ctx, func(p Publisher, m *WelcomeMailer) {
+			assert.Nil(t, p.Publish(ctx, UserCreated{Name: "alice"}))
+			assert.Equal(t, []string{"alice"}, m.sent)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Subscribe should raise an error if type is not a subscriber", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *WelcomeMailer { return &WelcomeMailer{} }, Subscribe[UserDeleted]()),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.ErrorContains(t, err, "*goinject.WelcomeMailer does not implement goinject.Subscriber[")
+	})
+}
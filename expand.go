@@ -0,0 +1,75 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// provideTag is the struct tag marking the fields registered as bindings by ExpandFields
+const provideTag = "provide"
+
+type expandFieldsAnnotation struct{}
+
+func (a *expandFieldsAnnotation) apply(b *binding) error {
+	structType := b.providedType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("ExpandFields require a provider returning a struct, got %s", b.providedType), nil)
+	}
+	b.expandFields = true
+	return nil
+}
+
+// ExpandFields return an annotation registering the exported fields of the provided struct (or pointer to struct)
+// tagged with `provide:"true"` as additional bindings of their type, in the scope of the binding. It avoids
+// writing forwarding providers for each member of a bundle of clients built by a single constructor.
+func ExpandFields() Annotation {
+	return &expandFieldsAnnotation{}
+}
+
+// fieldBindings return the bindings of the fields of the struct provided by b, see ExpandFields
+func fieldBindings(b *binding) []*binding {
+	structType := b.providedType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	var res []*binding
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Tag.Get(provideTag) != "true" || !field.IsExported() {
+			continue
+		}
+		fieldIndex := i
+		providerType := reflect.FuncOf(
+			[]reflect.Type{invocationContextReflectType, reflect.TypeFor[*Injector]()},
+			[]reflect.Type{field.Type, errorReflectType},
+			false,
+		)
+		provider := reflect.MakeFunc(providerType, func(args []reflect.Value) []reflect.Value {
+			ctx := args[0].Interface().(context.Context)
+			injector := args[1].Interface().(*Injector)
+			bundle, err := injector.getScopedInstanceFromBinding(ctx, b)
+			if err == nil && bundle.Kind() == reflect.Ptr && bundle.IsNil() {
+				err = fmt.Errorf("provider for type %q returned nil", b.providedType)
+			}
+			if err != nil {
+				return []reflect.Value{reflect.Zero(field.Type), reflect.ValueOf(&err).Elem()}
+			}
+			return []reflect.Value{reflect.Indirect(bundle).Field(fieldIndex), reflect.Zero(errorReflectType)}
+		})
+		res = append(res, &binding{
+			typeof:        field.Type,
+			provider:      provider,
+			providedType:  field.Type,
+			scope:         b.scope,
+			explicitScope: b.explicitScope,
+			modules:       b.modules,
+			location:      b.location,
+		})
+	}
+	return res
+}
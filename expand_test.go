@@ -0,0 +1,58 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ClientBundle struct {
+	Parent    *Parent    `provide:"true"`
+	Rectangle *Rectangle `provide:"true"`
+	Square    *Square
+}
+
+func TestExpandFields(t *testing.T) {
+	created := 0
+	injector, err := NewInjector(
+		Provide(func() *ClientBundle {
+			created++
+			return &ClientBundle{Parent: &Parent{}, Rectangle: &Rectangle{}, Square: &Square{}}
+		}, ExpandFields()),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(bundle *ClientBundle, p *Parent, r *Rectangle) {
+		assert.Same(t, bundle.Parent, p)
+		assert.Same(t, bundle.Rectangle, r)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, created)
+
+	err = injector.Invoke(ctx, func(_ *Square) {
+		assert.Fail(t, "should not be reached")
+	})
+	assert.NotNil(t, err)
+
+	t.Run("Field bindings should return the bundle error", func(t *testing.T) {
+		bundleErr := errors.New("failed to build bundle")
+		injector, err := NewInjector(
+			Provide(func() (ClientBundle, error) {
+				return ClientBundle{}, bundleErr
+			}, ExpandFields(), In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(_ *Parent) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorIs(t, err, bundleErr)
+	})
+
+	t.Run("ExpandFields should require a struct", func(t *testing.T) {
+		_, err := NewInjector(Provide(func() string { return "" }, ExpandFields()))
+		assert.IsType(t, err, &ConfigurationReport{})
+	})
+}
@@ -0,0 +1,51 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type exposeOption struct {
+	provide *provideOption
+	target  reflect.Type
+	narrow  reflect.Type
+	err     error // invalid annotations
+}
+
+func (o *exposeOption) apply(mod *configuration) error {
+	if o.narrow.Kind() != reflect.Interface || !o.target.Implements(o.narrow) {
+		return newConfigurationProblemError(InvalidProvider, "", mod.modules, o.provide.location,
+			fmt.Errorf("cannot expose %s as %s: it must be an interface implemented by %s", o.target, o.narrow, o.target))
+	}
+	if o.err != nil {
+		return newConfigurationProblemError(InvalidProvider, "", mod.modules, o.provide.location,
+			fmt.Errorf("cannot expose %s as %s: %w", o.target, o.narrow, o.err))
+	}
+	return o.provide.apply(mod)
+}
+
+// Expose return an Option binding the Narrow interface to the instance of the T binding, so that consumers depend
+// on narrow interfaces without adapter providers. The annotations apply to the Narrow binding, T being resolved with
+// the same annotation. The Narrow binding is PerLookUp: the instance is shared according to the scope of T.
+// Go generics cannot express that T implements Narrow, it is checked when the injector is created.
+func Expose[T, Narrow any](annotations ...Annotation) Option {
+	name, err := annotationName(annotations)
+	return &exposeOption{
+		provide: &provideOption{
+			constructor: func(ctx InvocationContext, injector *Injector) (Narrow, error) {
+				var res Narrow
+				instance, err := injector.getInstanceOfAnnotatedType(ctx, reflect.TypeFor[T](), name, false)
+				if err != nil || !instance.IsValid() {
+					return res, err
+				}
+				res, _ = instance.Interface().(Narrow)
+				return res, nil
+			},
+			annotations: append(append([]Annotation{}, annotations...), In(PerLookUp)),
+			location:    callerLocation(),
+		},
+		target: reflect.TypeFor[T](),
+		narrow: reflect.TypeFor[Narrow](),
+		err:    err,
+	}
+}
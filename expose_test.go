@@ -0,0 +1,53 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type StockReader interface {
+	Stock(ctx context.Context, item string) (int, error)
+}
+
+func TestExpose(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func() *flakyInventory { return &flakyInventory{} }),
+		Provide(func() *flakyInventory { return &flakyInventory{} }, Named("backup")),
+		Expose[*flakyInventory, StockReader](),
+		Expose[*flakyInventory, StockReader](Named("backup")),
+	)
+	assert.Nil(t, err)
+
+	type params struct {
+		Params
+		Reader       StockReader     `inject:""`
+		Backup       StockReader     `inject:"backup"`
+		Inventory    *flakyInventory `inject:""`
+		BackupTarget *flakyInventory `inject:"backup"`
+	}
+	err = injector.Invoke(context.Background(), func(p params) {
+		assert.Same(t, p.Inventory, p.Reader)
+		assert.Same(t, p.BackupTarget, p.Backup)
+		assert.NotSame(t, p.Inventory, p.BackupTarget)
+	})
+	assert.Nil(t, err)
+
+	_, err = NewInjector(
+		Provide(func() *Parent { return &Parent{} }),
+		Expose[*Parent, StockReader](),
+	)
+	assert.ErrorContains(t, err,
+		"cannot expose *goinject.Parent as goinject.StockReader: it must be an interface implemented by *goinject.Parent")
+	var report *ConfigurationReport
+	assert.ErrorAs(t, err, &report)
+	assert.Contains(t, report.Problems[0].Location, "expose_test.go")
+
+	_, err = NewInjector(
+		Provide(func() *flakyInventory { return &flakyInventory{} }),
+		Expose[*flakyInventory, StockReader](As(Type[any]())),
+	)
+	assert.ErrorContains(t, err,
+		"cannot expose *goinject.flakyInventory as goinject.StockReader: As(interface {}) cannot be used here")
+}
@@ -0,0 +1,71 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type fakeInTestsAnnotation struct {
+	constructor any
+}
+
+func (a *fakeInTestsAnnotation) apply(b *binding) error {
+	fakeValue := reflect.ValueOf(a.constructor)
+	if fakeValue.Kind() != reflect.Func || fakeValue.Type().NumOut() == 0 || fakeValue.Type().NumOut() > 2 ||
+		(fakeValue.Type().NumOut() == 2 && fakeValue.Type().Out(1) != errorReflectType) {
+		return newInjectorConfigurationError(
+			"argument of FakeInTests must be a function that return an instance and optionally an error", nil)
+	}
+	b.fake = fakeValue
+	return nil
+}
+
+// FakeInTests return an annotation substituting the fake constructor for the binding provider when fakes are
+// enabled, so that the production wiring of external services and their in-memory fakes are declared side by side.
+// Fakes are enabled with WithFakes, or with injecttest.Fakes when running under go test. The fake constructor
+// arguments are resolved by the injector, it must return a value assignable to the binding type.
+func FakeInTests(constructor any) Annotation {
+	return &fakeInTestsAnnotation{constructor: constructor}
+}
+
+type fakesOption struct {
+	enabled bool
+}
+
+func (o *fakesOption) apply(mod *configuration) error {
+	mod.fakes = o.enabled
+	return nil
+}
+
+// WithFakes return an InjectorOption enabling or disabling (the default) the fake constructors declared with
+// FakeInTests. The injecttest package provides an option enabling them when running under go test.
+func WithFakes(enabled bool) InjectorOption {
+	return newInjectorOption("WithFakes", &fakesOption{enabled: enabled})
+}
+
+// checkFake return an error if the fake constructor of b does not return a value assignable to the binding type
+func (b *binding) checkFake() error {
+	if b.fake.IsValid() && !b.fake.Type().Out(0).AssignableTo(b.typeof) {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("fake of FakeInTests must return a value assignable to %s", b.typeof), nil)
+	}
+	return nil
+}
+
+// applyFakes substitute the fake constructors for the providers of the bindings if fakes are enabled
+func (mod *configuration) applyFakes() {
+	if !mod.fakes {
+		return
+	}
+	for b := range mod.bindings {
+		if !b.fake.IsValid() {
+			continue
+		}
+		b.provider = b.fake
+		if fakeType := b.fake.Type().Out(0); fakeType != b.providedType {
+			// the destroy method accepts the real provided type only
+			b.providedType = fakeType
+			b.destroyMethod = nil
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type PaymentClient interface {
+	Charge(amount int) error
+}
+
+type stripeClient struct{}
+
+func (c *stripeClient) Charge(_ int) error { return nil }
+
+type fakePaymentClient struct {
+	charges []int
+}
+
+func (c *fakePaymentClient) Charge(amount int) error {
+	c.charges = append(c.charges, amount)
+	return nil
+}
+
+func TestFakeInTests(t *testing.T) {
+	paymentsModule := Module("payments",
+		Provide(func() *stripeClient { return &stripeClient{} }, As(Type[PaymentClient]()),
+			WithDestroy(func(_ *stripeClient) {}),
+			FakeInTests(func() *fakePaymentClient { return &fakePaymentClient{} })),
+	)
+	ctx := context.Background()
+
+	injector, err := NewInjector(paymentsModule, WithFakes(true))
+	assert.Nil(t, err)
+	err = injector.Invoke(ctx, func(c PaymentClient) {
+		assert.IsType(t, &fakePaymentClient{}, c)
+	})
+	assert.Nil(t, err)
+	injector.Shutdown()
+
+	for _, options := range [][]Option{{paymentsModule}, {paymentsModule, WithFakes(false)}} {
+		injector, err = NewInjector(options...)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(c PaymentClient) {
+			assert.IsType(t, &stripeClient{}, c)
+		})
+		assert.Nil(t, err)
+	}
+
+	t.Run("Fake should return a value assignable to the binding type", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *stripeClient { return &stripeClient{} }, FakeInTests(func() *fakePaymentClient {
+				return &fakePaymentClient{}
+			})),
+		)
+		assert.ErrorContains(t, err, "fake of FakeInTests must return a value assignable to *goinject.stripeClient")
+	})
+}
@@ -0,0 +1,38 @@
+package goinject
+
+type fallbackAnnotation struct{}
+
+func (a *fallbackAnnotation) apply(b *binding) error {
+	b.fallback = true
+	return nil
+}
+
+// NoOpFallback return an Option providing noop as the fallback implementation of T: it is only bound if no other
+// binding is registered for T (with the same annotation), so that optional integrations such as metrics or audit
+// sinks do not fail the resolution when they are not installed.
+// The no-op implementation (whose methods return zero values or the errors of your choice) must be given, for the
+// reason explained in WithProxy.
+func NoOpFallback[T any](noop T, annotations ...Annotation) Option {
+	return &provideOption{
+		constructor: func() T { return noop },
+		annotations: append(annotations, &fallbackAnnotation{}),
+		location:    callerLocation(),
+	}
+}
+
+// withoutShadowedFallbacks remove the fallback bindings having a regular binding with the same key
+func withoutShadowedFallbacks(bindings []*binding) []*binding {
+	bound := make(map[BindingKey]bool)
+	for _, b := range bindings {
+		if !b.fallback {
+			bound[b.key()] = true
+		}
+	}
+	res := make([]*binding, 0, len(bindings))
+	for _, b := range bindings {
+		if !b.fallback || !bound[b.key()] {
+			res = append(res, b)
+		}
+	}
+	return res
+}
@@ -0,0 +1,64 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type AuditSink interface {
+	Record(event string) error
+}
+
+type noOpAuditSink struct{}
+
+func (noOpAuditSink) Record(_ string) error { return nil }
+
+type disabledAuditSink struct{}
+
+func (disabledAuditSink) Record(_ string) error { return errors.New("audit is disabled") }
+
+type memoryAuditSink struct {
+	events []string
+}
+
+func (s *memoryAuditSink) Record(event string) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestNoOpFallback(t *testing.T) {
+	t.Run("Should use the fallback if there is no other binding", func(t *testing.T) {
+		injector, err := NewInjector(
+			NoOpFallback[AuditSink](noOpAuditSink{}),
+			NoOpFallback[AuditSink](disabledAuditSink{}, Named("strict")),
+		)
+		assert.Nil(t, err)
+
+		err = injector.Invoke(context.Background(), func(sink AuditSink, strict struct {
+			Params
+			Sink AuditSink `inject:"strict"`
+		}) {
+			assert.Nil(t, sink.Record("login"))
+			assert.EqualError(t, strict.Sink.Record("login"), "audit is disabled")
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should prefer the regular binding", func(t *testing.T) {
+		sink := &memoryAuditSink{}
+		injector, err := NewInjector(
+			NoOpFallback[AuditSink](noOpAuditSink{}),
+			Provide(func() *memoryAuditSink { return sink }, As(Type[AuditSink]())),
+		)
+		assert.Nil(t, err)
+
+		err = injector.Invoke(context.Background(), func(s AuditSink) error {
+			return s.Record("login")
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"login"}, sink.events)
+	})
+}
@@ -0,0 +1,123 @@
+package goinject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileDecoders are the decoders of configuration files by extension
+var fileDecoders = map[string]func(data []byte, v any) error{
+	".json": json.Unmarshal,
+	".yaml": yaml.Unmarshal,
+	".yml":  yaml.Unmarshal,
+}
+
+type fileOption struct {
+	valueType reflect.Type
+	path      string
+	options   []ConfigOption
+	location  string
+}
+
+func (o *fileOption) apply(mod *configuration) error {
+	decode, ok := fileDecoders[filepath.Ext(o.path)]
+	if !ok {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("unsupported configuration file %s, expected a .json, .yaml or .yml file", o.path), nil)
+	}
+	source, err := newConfigSource(mod, o.valueType, o.options, o.location)
+	if err != nil {
+		return err
+	}
+	err = source.provideOption(func() (reflect.Value, error) {
+		data, err := os.ReadFile(o.path)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("failed to read configuration file: %w", err)
+		}
+		value := reflect.New(o.valueType)
+		if err = decode(data, value.Interface()); err != nil {
+			return reflect.Value{}, fmt.Errorf("failed to parse configuration file %s: %w", o.path, err)
+		}
+		return value, nil
+	}).apply(mod)
+	if err != nil || !source.watch {
+		return err
+	}
+
+	key := BindingKey{Type: reflect.PointerTo(o.valueType)}
+	watcher := &provideOption{
+		constructor: func(injector *Injector) *fileWatcher {
+			return newFileWatcher(o.path, source.pollInterval, func() {
+				if err := injector.Refresh(context.Background(), key); err != nil {
+					injector.notify(RefreshFailedEvent{Key: key, Err: err})
+				}
+			})
+		},
+		annotations: []Annotation{Named(o.path), In(Singleton), WithDestroy((*fileWatcher).stop)},
+		location:    o.location,
+	}
+	return watcher.apply(mod)
+}
+
+// ProvideFromFile return an Option providing a *T singleton parsed from a JSON or YAML configuration file
+// (depending on the file extension). The binding is Refreshable: with the WatchAndRefresh option, the file is
+// polled and the binding is refreshed when the file changes, failed refreshes being notified to observers
+// with RefreshFailedEvent.
+func ProvideFromFile[T any](path string, opts ...ConfigOption) Option {
+	return &fileOption{
+		valueType: reflect.TypeFor[T](),
+		path:      path,
+		options:   opts,
+		location:  callerLocation(),
+	}
+}
+
+// fileWatcher poll the modification time and size of a file, calling onChange when they change
+type fileWatcher struct {
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newFileWatcher(path string, interval time.Duration, onChange func()) *fileWatcher {
+	w := &fileWatcher{done: make(chan struct{})}
+	modTime, size := fileVersion(path)
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.done:
+				return
+			case <-ticker.C:
+				if t, s := fileVersion(path); !t.Equal(modTime) || s != size {
+					modTime, size = t, s
+					onChange()
+				}
+			}
+		}
+	}()
+	return w
+}
+
+func (w *fileWatcher) stop() {
+	close(w.done)
+	w.wg.Wait()
+}
+
+func fileVersion(path string) (time.Time, int64) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, -1
+	}
+	return info.ModTime(), info.Size()
+}
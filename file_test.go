@@ -0,0 +1,84 @@
+package goinject
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FileConfig struct {
+	Name    string   `json:"name" yaml:"name"`
+	Workers int      `json:"workers" yaml:"workers"`
+	Tags    []string `json:"tags" yaml:"tags"`
+}
+
+func TestProvideFromFile(t *testing.T) {
+	t.Run("Should parse JSON and YAML files", func(t *testing.T) {
+		dir := t.TempDir()
+		jsonPath := filepath.Join(dir, "config.json")
+		yamlPath := filepath.Join(dir, "config.yaml")
+		assert.Nil(t, os.WriteFile(jsonPath, []byte(`{"name": "json", "workers": 2}`), 0o600))
+		assert.Nil(t, os.WriteFile(yamlPath, []byte("name: yaml\nworkers: 3\ntags: [a, b]\n"), 0o600))
+
+		for path, expected := range map[string]*FileConfig{
+			jsonPath: {Name: "json", Workers: 2},
+			yamlPath: {Name: "yaml", Workers: 3, Tags: []string{"a", "b"}},
+		} {
+			injector, err := NewInjector(ProvideFromFile[FileConfig](path))
+			assert.Nil(t, err)
+			err = injector.Invoke(context.Background(), func(c *FileConfig) {
+				assert.Equal(t, expected, c)
+			})
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("Should reject unsupported files", func(t *testing.T) {
+		_, err := NewInjector(ProvideFromFile[FileConfig]("config.toml"))
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.ErrorContains(t, err, "unsupported configuration file config.toml")
+	})
+
+	t.Run("Should refresh the binding when the file changes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		assert.Nil(t, os.WriteFile(path, []byte("name: first\n"), 0o600))
+
+		var mu sync.Mutex
+		var events []Event
+		injector, err := NewInjector(
+			ProvideFromFile[FileConfig](path, WatchAndRefresh(), PollInterval(5*time.Millisecond)),
+			WithObserver(func(event Event) {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, event)
+			}),
+		)
+		assert.Nil(t, err)
+		defer injector.Shutdown()
+		name := func() string {
+			var res string
+			assert.Nil(t, injector.Invoke(context.Background(), func(c *FileConfig) { res = c.Name }))
+			return res
+		}
+
+		assert.Nil(t, os.WriteFile(path, []byte("name: second\n"), 0o600))
+		assert.Eventually(t, func() bool { return name() == "second" }, time.Second, 5*time.Millisecond)
+
+		assert.Nil(t, os.WriteFile(path, []byte("name: [invalid\n"), 0o600))
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(events) == 0 {
+				return false
+			}
+			_, ok := events[len(events)-1].(RefreshFailedEvent)
+			return ok
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, "second", name())
+	})
+}
@@ -0,0 +1,121 @@
+package goinject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// graphFormatVersion is the version of the exported Graph format, it is increased on incompatible changes
+const graphFormatVersion = 1
+
+// ErrIncompatibleGraph is returned by Injector.CheckCompatibility when the bindings differ from the expected graph
+var ErrIncompatibleGraph = errors.New("incompatible binding graph")
+
+// Graph is a portable description of the bindings of an injector (not their instances).
+// It is exported by a coordinator process and checked by workers to detect wiring drift between services.
+type Graph struct {
+	Version  int            `json:"version"`
+	Bindings []GraphBinding `json:"bindings"`
+}
+
+// GraphBinding describes a binding of a Graph
+type GraphBinding struct {
+	Key          string   `json:"key"`
+	Scope        string   `json:"scope"`
+	ProvidedType string   `json:"providedType"`
+	Dependencies []string `json:"dependencies,omitempty"` // keys of the bindings requested by the provider
+	Toggle       string   `json:"toggle,omitempty"`       // feature toggle of the binding, see Toggleable
+}
+
+// Graph return the description of the injector bindings, sorted by key and scope
+func (injector *Injector) Graph() *Graph {
+	graph := &Graph{Version: graphFormatVersion, Bindings: []GraphBinding{}}
+	for _, b := range injector.allBindings() {
+		graph.Bindings = append(graph.Bindings, newGraphBinding(b))
+	}
+	sort.SliceStable(graph.Bindings, func(i, j int) bool {
+		if graph.Bindings[i].Key != graph.Bindings[j].Key {
+			return graph.Bindings[i].Key < graph.Bindings[j].Key
+		}
+		return graph.Bindings[i].Scope < graph.Bindings[j].Scope
+	})
+	return graph
+}
+
+func newGraphBinding(b *binding) GraphBinding {
+	var dependencies []string
+	for _, key := range dependenciesOf(b.provider.Type()) {
+		dependencies = append(dependencies, key.String())
+	}
+	return GraphBinding{
+		Key:          b.key().String(),
+		Scope:        b.scope,
+		ProvidedType: b.providedType.String(),
+		Dependencies: dependencies,
+		Toggle:       b.toggle,
+	}
+}
+
+// dependenciesOf return the keys of the bindings requested by the arguments of the function type fnType
+func dependenciesOf(fnType reflect.Type) []BindingKey {
+	var keys []BindingKey
+	for _, plan := range planOf(fnType) {
+		if plan.Kind != ParamContextual {
+			keys = append(keys, plan.Key)
+		}
+	}
+	return keys
+}
+
+// JSON return the graph as a JSON document
+func (g *Graph) JSON() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+// ParseGraph parse a Graph exported with Graph.JSON
+func ParseGraph(data []byte) (*Graph, error) {
+	graph := &Graph{}
+	if err := json.Unmarshal(data, graph); err != nil {
+		return nil, fmt.Errorf("failed to parse binding graph: %w", err)
+	}
+	if graph.Version != graphFormatVersion {
+		return nil, fmt.Errorf("unsupported binding graph version %d, expected %d", graph.Version, graphFormatVersion)
+	}
+	return graph, nil
+}
+
+// CheckCompatibility verify that the injector is structurally compatible with the expected graph:
+// it must have the same binding keys, with the same scopes. The returned error wraps ErrIncompatibleGraph
+// and lists every difference.
+func (injector *Injector) CheckCompatibility(expected *Graph) error {
+	count := func(graph *Graph) map[[2]string]int {
+		res := make(map[[2]string]int)
+		for _, b := range graph.Bindings {
+			res[[2]string{b.Key, b.Scope}]++
+		}
+		return res
+	}
+	expectedBindings := count(expected)
+	actualBindings := count(injector.Graph())
+
+	var differences []string
+	for k, n := range expectedBindings {
+		if actualBindings[k] < n {
+			differences = append(differences, fmt.Sprintf("missing binding %s in scope %q", k[0], k[1]))
+		}
+	}
+	for k, n := range actualBindings {
+		if expectedBindings[k] < n {
+			differences = append(differences, fmt.Sprintf("unexpected binding %s in scope %q", k[0], k[1]))
+		}
+	}
+	if len(differences) == 0 {
+		return nil
+	}
+	sort.Strings(differences)
+	return fmt.Errorf("%w:\n%s", ErrIncompatibleGraph, strings.Join(differences, "\n"))
+}
@@ -0,0 +1,78 @@
+package goinject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type GraphParams struct {
+	Params
+	Colors []*Color `inject:"colors"`
+	Square *Square  `inject:"square,optional"`
+}
+
+func TestGraph(t *testing.T) {
+	coordinator, err := NewInjector(
+		Provide(func() *Color { return &Color{} }, IntoGroup("colors")),
+		Provide(func() *Square { return &Square{} }, Named("square"), In(PerLookUp)),
+		Provide(func(_ GraphParams, _ Provider[*Child]) *Parent { return &Parent{} }),
+		Provide(func() *Child { return &Child{} }),
+	)
+	assert.Nil(t, err)
+
+	t.Run("Should export the bindings and their dependencies", func(t *testing.T) {
+		graph := coordinator.Graph()
+		assert.Equal(t, graphFormatVersion, graph.Version)
+		assert.Equal(t, []GraphBinding{
+			{Key: "*goinject.Child", Scope: Singleton, ProvidedType: "*goinject.Child"},
+			{Key: "*goinject.Color(\"colors\")", Scope: Singleton, ProvidedType: "*goinject.Color"},
+			{
+				Key:          "*goinject.Parent",
+				Scope:        Singleton,
+				ProvidedType: "*goinject.Parent",
+				Dependencies: []string{"*goinject.Color(\"colors\")", "*goinject.Square(\"square\")", "*goinject.Child"},
+			},
+			{Key: "*goinject.Square(\"square\")", Scope: PerLookUp, ProvidedType: "*goinject.Square"},
+		}, graph.Bindings)
+	})
+
+	t.Run("Should accept a compatible injector", func(t *testing.T) {
+		data, err := coordinator.Graph().JSON()
+		assert.Nil(t, err)
+		graph, err := ParseGraph(data)
+		assert.Nil(t, err)
+
+		worker, err := NewInjector(
+			Provide(func() *Child { return &Child{} }),
+			Provide(func() *Parent { return &Parent{} }),
+			Provide(func() *Square { return &Square{} }, Named("square"), In(PerLookUp)),
+			Provide(func() *Color { return &Color{} }, IntoGroup("colors")),
+		)
+		assert.Nil(t, err)
+		assert.Nil(t, worker.CheckCompatibility(graph))
+	})
+
+	t.Run("Should report differences with an incompatible injector", func(t *testing.T) {
+		worker, err := NewInjector(
+			Provide(func() *Child { return &Child{} }),
+			Provide(func() *Parent { return &Parent{} }),
+			Provide(func() *Square { return &Square{} }, Named("square")),
+			Provide(func() *Rectangle { return &Rectangle{} }),
+		)
+		assert.Nil(t, err)
+
+		err = worker.CheckCompatibility(coordinator.Graph())
+		assert.ErrorIs(t, err, ErrIncompatibleGraph)
+		assert.Equal(t, "incompatible binding graph:\n"+
+			"missing binding *goinject.Color(\"colors\") in scope \"inject.Singleton\"\n"+
+			"missing binding *goinject.Square(\"square\") in scope \"inject.PerLookUp\"\n"+
+			"unexpected binding *goinject.Rectangle in scope \"inject.Singleton\"\n"+
+			"unexpected binding *goinject.Square(\"square\") in scope \"inject.Singleton\"", err.Error())
+	})
+
+	t.Run("Should reject unsupported versions", func(t *testing.T) {
+		_, err := ParseGraph([]byte(`{"version": 42, "bindings": []}`))
+		assert.ErrorContains(t, err, "unsupported binding graph version 42, expected 1")
+	})
+}
@@ -0,0 +1,159 @@
+package goinject
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// GraphDiff is the difference between two binding graphs, see DiffGraphs
+type GraphDiff struct {
+	Added   []GraphBinding       `json:"added,omitempty"`
+	Removed []GraphBinding       `json:"removed,omitempty"`
+	Changed []GraphBindingChange `json:"changed,omitempty"`
+}
+
+// GraphBindingChange describes a binding present in both graphs whose scope, provided type or dependencies changed
+type GraphBindingChange struct {
+	Old                 GraphBinding `json:"old"`
+	New                 GraphBinding `json:"new"`
+	AddedDependencies   []string     `json:"addedDependencies,omitempty"`   // new edges of the binding
+	RemovedDependencies []string     `json:"removedDependencies,omitempty"` // edges the binding no longer has
+}
+
+// ScopeChanged tell if the binding moved to another scope, which is usually the riskiest wiring change
+func (c GraphBindingChange) ScopeChanged() bool {
+	return c.Old.Scope != c.New.Scope
+}
+
+// DiffGraphs compare two graphs exported with Injector.Graph (e.g. by two releases) and report added, removed and
+// changed bindings. Bindings are matched by key, multi-bindings sharing a key being matched by provided type.
+func DiffGraphs(oldGraph, newGraph *Graph) *GraphDiff {
+	diff := &GraphDiff{}
+	oldByKey := groupGraphBindings(oldGraph)
+	newByKey := groupGraphBindings(newGraph)
+	for key, oldBindings := range oldByKey {
+		newBindings := newByKey[key]
+		oldBindings, newBindings = removeUnchangedBindings(oldBindings, newBindings)
+		// match the remaining bindings of the key by provided type first, then in order
+		for _, matchByType := range []bool{true, false} {
+			for i := 0; i < len(oldBindings); i++ {
+				j := slices.IndexFunc(newBindings, func(b GraphBinding) bool {
+					return !matchByType || b.ProvidedType == oldBindings[i].ProvidedType
+				})
+				if j < 0 {
+					continue
+				}
+				diff.Changed = append(diff.Changed, newGraphBindingChange(oldBindings[i], newBindings[j]))
+				oldBindings = slices.Delete(oldBindings, i, i+1)
+				newBindings = slices.Delete(newBindings, j, j+1)
+				i--
+			}
+		}
+		diff.Removed = append(diff.Removed, oldBindings...)
+		newByKey[key] = newBindings
+	}
+	for _, newBindings := range newByKey {
+		diff.Added = append(diff.Added, newBindings...)
+	}
+
+	sortGraphBindings(diff.Added)
+	sortGraphBindings(diff.Removed)
+	sort.SliceStable(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].New.Key < diff.Changed[j].New.Key
+	})
+	return diff
+}
+
+func groupGraphBindings(graph *Graph) map[string][]GraphBinding {
+	res := make(map[string][]GraphBinding)
+	if graph == nil {
+		return res
+	}
+	for _, b := range graph.Bindings {
+		res[b.Key] = append(res[b.Key], b)
+	}
+	return res
+}
+
+// removeUnchangedBindings remove the bindings present in both lists
+func removeUnchangedBindings(oldBindings, newBindings []GraphBinding) ([]GraphBinding, []GraphBinding) {
+	newBindings = slices.Clone(newBindings)
+	var remaining []GraphBinding
+	for _, b := range oldBindings {
+		j := slices.IndexFunc(newBindings, func(n GraphBinding) bool {
+			return n.Scope == b.Scope && n.ProvidedType == b.ProvidedType && sameDependencies(n, b)
+		})
+		if j < 0 {
+			remaining = append(remaining, b)
+			continue
+		}
+		newBindings = slices.Delete(newBindings, j, j+1)
+	}
+	return remaining, newBindings
+}
+
+func sameDependencies(a, b GraphBinding) bool {
+	aDependencies, bDependencies := slices.Clone(a.Dependencies), slices.Clone(b.Dependencies)
+	slices.Sort(aDependencies)
+	slices.Sort(bDependencies)
+	return slices.Equal(aDependencies, bDependencies)
+}
+
+func newGraphBindingChange(from, to GraphBinding) GraphBindingChange {
+	change := GraphBindingChange{Old: from, New: to}
+	for _, d := range to.Dependencies {
+		if !slices.Contains(from.Dependencies, d) {
+			change.AddedDependencies = append(change.AddedDependencies, d)
+		}
+	}
+	for _, d := range from.Dependencies {
+		if !slices.Contains(to.Dependencies, d) {
+			change.RemovedDependencies = append(change.RemovedDependencies, d)
+		}
+	}
+	return change
+}
+
+func sortGraphBindings(bindings []GraphBinding) {
+	sort.SliceStable(bindings, func(i, j int) bool {
+		if bindings[i].Key != bindings[j].Key {
+			return bindings[i].Key < bindings[j].Key
+		}
+		return bindings[i].Scope < bindings[j].Scope
+	})
+}
+
+// IsEmpty tell if the graphs have the same bindings
+func (d *GraphDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String return a human-readable report of the differences, one line per difference
+func (d *GraphDiff) String() string {
+	var lines []string
+	for _, b := range d.Added {
+		lines = append(lines, fmt.Sprintf("added binding %s in scope %q", b.Key, b.Scope))
+	}
+	for _, b := range d.Removed {
+		lines = append(lines, fmt.Sprintf("removed binding %s in scope %q", b.Key, b.Scope))
+	}
+	for _, c := range d.Changed {
+		if c.ScopeChanged() {
+			lines = append(lines, fmt.Sprintf("binding %s moved from scope %q to scope %q",
+				c.New.Key, c.Old.Scope, c.New.Scope))
+		}
+		if c.Old.ProvidedType != c.New.ProvidedType {
+			lines = append(lines, fmt.Sprintf("binding %s now provides %s instead of %s",
+				c.New.Key, c.New.ProvidedType, c.Old.ProvidedType))
+		}
+		for _, dependency := range c.AddedDependencies {
+			lines = append(lines, fmt.Sprintf("binding %s now depends on %s", c.New.Key, dependency))
+		}
+		for _, dependency := range c.RemovedDependencies {
+			lines = append(lines, fmt.Sprintf("binding %s no longer depends on %s", c.New.Key, dependency))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,77 @@
+package goinject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffGraphs(t *testing.T) {
+	oldGraph := &Graph{Version: graphFormatVersion, Bindings: []GraphBinding{
+		{Key: "*goinject.Child", Scope: Singleton, ProvidedType: "*goinject.Child"},
+		{Key: "*goinject.Color", Scope: Singleton, ProvidedType: "*goinject.Color"},
+		{
+			Key:          "*goinject.Parent",
+			Scope:        Singleton,
+			ProvidedType: "*goinject.Parent",
+			Dependencies: []string{"*goinject.Child", "*goinject.Color"},
+		},
+		{Key: "*goinject.Square", Scope: Singleton, ProvidedType: "*goinject.Square"},
+	}}
+	newGraph := &Graph{Version: graphFormatVersion, Bindings: []GraphBinding{
+		{Key: "*goinject.Child", Scope: Singleton, ProvidedType: "*goinject.Child"},
+		{
+			Key:          "*goinject.Parent",
+			Scope:        Singleton,
+			ProvidedType: "*goinject.Parent",
+			Dependencies: []string{"*goinject.Rectangle", "*goinject.Child"},
+		},
+		{Key: "*goinject.Rectangle", Scope: PerLookUp, ProvidedType: "*goinject.Rectangle"},
+		{Key: "*goinject.Square", Scope: PerLookUp, ProvidedType: "*goinject.Square"},
+	}}
+
+	t.Run("Should report added, removed and changed bindings", func(t *testing.T) {
+		diff := DiffGraphs(oldGraph, newGraph)
+		assert.False(t, diff.IsEmpty())
+		assert.Equal(t, []GraphBinding{newGraph.Bindings[2]}, diff.Added)
+		assert.Equal(t, []GraphBinding{oldGraph.Bindings[1]}, diff.Removed)
+		assert.Equal(t, []GraphBindingChange{
+			{
+				Old:                 oldGraph.Bindings[2],
+				New:                 newGraph.Bindings[1],
+				AddedDependencies:   []string{"*goinject.Rectangle"},
+				RemovedDependencies: []string{"*goinject.Color"},
+			},
+			{Old: oldGraph.Bindings[3], New: newGraph.Bindings[3]},
+		}, diff.Changed)
+		assert.True(t, diff.Changed[1].ScopeChanged())
+		assert.Equal(t, "added binding *goinject.Rectangle in scope \"inject.PerLookUp\"\n"+
+			"removed binding *goinject.Color in scope \"inject.Singleton\"\n"+
+			"binding *goinject.Parent now depends on *goinject.Rectangle\n"+
+			"binding *goinject.Parent no longer depends on *goinject.Color\n"+
+			"binding *goinject.Square moved from scope \"inject.Singleton\" to scope \"inject.PerLookUp\"",
+			diff.String())
+	})
+
+	t.Run("Should match multi-bindings by provided type", func(t *testing.T) {
+		diff := DiffGraphs(
+			&Graph{Bindings: []GraphBinding{
+				{Key: "goinject.Shape", Scope: Singleton, ProvidedType: "*goinject.Square"},
+				{Key: "goinject.Shape", Scope: Singleton, ProvidedType: "*goinject.Rectangle"},
+			}},
+			&Graph{Bindings: []GraphBinding{
+				{Key: "goinject.Shape", Scope: PerLookUp, ProvidedType: "*goinject.Rectangle"},
+				{Key: "goinject.Shape", Scope: Singleton, ProvidedType: "*goinject.Square"},
+			}},
+		)
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Removed)
+		assert.Len(t, diff.Changed, 1)
+		assert.Equal(t, "binding goinject.Shape moved from scope \"inject.Singleton\" to scope \"inject.PerLookUp\"",
+			diff.String())
+	})
+
+	t.Run("Should report no difference between identical graphs", func(t *testing.T) {
+		assert.True(t, DiffGraphs(oldGraph, oldGraph).IsEmpty())
+	})
+}
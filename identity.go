@@ -0,0 +1,126 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+const (
+	// WarningConflictingScopes report an instance created by bindings of different scopes, e.g. a singleton pointer
+	// also returned by a provider of a request scoped binding
+	WarningConflictingScopes WarningKind = "conflicting-scopes"
+	// WarningDuplicateDestroy report an instance created by several bindings having a destroy method, e.g. the same
+	// instance bound via As to two types. Only the destroy method of the first binding is called.
+	WarningDuplicateDestroy WarningKind = "duplicate-destroy"
+)
+
+type detectDuplicateInstancesOption struct{}
+
+func (o *detectDuplicateInstancesOption) apply(mod *configuration) error {
+	mod.detectDuplicates = true
+	return nil
+}
+
+// DetectDuplicateInstances return a debug InjectorOption tracking the created instances (pointers, maps and channels)
+// by identity, reporting the instances created by several bindings as WarningEvent (see WarningConflictingScopes and
+// WarningDuplicateDestroy) and preventing their destroy callback from running twice. Tracked instances are retained
+// until the injector is garbage collected.
+func DetectDuplicateInstances() InjectorOption {
+	return newInjectorOption("DetectDuplicateInstances", &detectDuplicateInstancesOption{})
+}
+
+// instanceIdentity identify an instance by address and type, the type telling apart a struct and its first field
+type instanceIdentity struct {
+	address uintptr
+	typeof  reflect.Type
+}
+
+// trackedInstance is an instance of the identity map
+type trackedInstance struct {
+	binding   *binding
+	value     reflect.Value // retain the instance so that its address is not reused
+	destroyed bool          // a destroy callback is registered for the instance
+}
+
+// identityMap hold the instances created by an injector, by identity
+type identityMap struct {
+	mu        sync.Mutex
+	instances map[instanceIdentity]*trackedInstance
+}
+
+// trackInstance record the instance val created by b if duplicate instances are detected, it return false if the
+// destroy method of b must not be registered because the instance already has a destroy callback
+func (injector *Injector) trackInstance(b *binding, val reflect.Value) bool {
+	if !injector.detectDuplicates {
+		return true
+	}
+	identity, ok := identityOf(val)
+	if !ok {
+		return true
+	}
+	injector.identities.mu.Lock()
+	if injector.identities.instances == nil {
+		injector.identities.instances = make(map[instanceIdentity]*trackedInstance)
+	}
+	tracked, found := injector.identities.instances[identity]
+	if !found {
+		injector.identities.instances[identity] = &trackedInstance{
+			binding:   b,
+			value:     val,
+			destroyed: b.destroyMethod != nil,
+		}
+		injector.identities.mu.Unlock()
+		return true
+	}
+	first := tracked.binding
+	duplicateDestroy := tracked.destroyed && b.destroyMethod != nil && first != b
+	tracked.destroyed = tracked.destroyed || b.destroyMethod != nil
+	injector.identities.mu.Unlock()
+
+	if first == b {
+		return true
+	}
+	if first.scope != b.scope {
+		injector.warn(WarningEvent{
+			Kind:     WarningConflictingScopes,
+			Key:      b.key(),
+			Location: b.location,
+			Message: fmt.Sprintf("instance of scope %s is also created in scope %s by binding %s",
+				b.scope, first.scope, first.key()),
+		})
+	}
+	if duplicateDestroy {
+		injector.warn(WarningEvent{
+			Kind:     WarningDuplicateDestroy,
+			Key:      b.key(),
+			Location: b.location,
+			Message:  fmt.Sprintf("instance is already destroyed by binding %s, destroy method is ignored", first.key()),
+		})
+	}
+	return !duplicateDestroy
+}
+
+// identityOf return the identity of val if it is a non nil reference to a non zero-sized value (zero-sized values
+// may share their address)
+func identityOf(val reflect.Value) (instanceIdentity, bool) {
+	for val.IsValid() && val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return instanceIdentity{}, false
+	}
+	switch val.Kind() {
+	case reflect.Pointer:
+		if val.IsNil() || val.Type().Elem().Size() == 0 {
+			return instanceIdentity{}, false
+		}
+	case reflect.Map, reflect.Chan:
+		if val.IsNil() {
+			return instanceIdentity{}, false
+		}
+	default:
+		return instanceIdentity{}, false
+	}
+	return instanceIdentity{address: val.Pointer(), typeof: val.Type()}, true
+}
@@ -0,0 +1,57 @@
+package goinject
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Connection struct {
+	closes int
+}
+
+func (c *Connection) Close() error {
+	c.closes++
+	return nil
+}
+
+type ConnectionHolder interface {
+	Conn() *Connection
+}
+
+func (c *Connection) Conn() *Connection { return c }
+
+func TestDetectDuplicateInstances(t *testing.T) {
+	conn := &Connection{}
+	injector, err := NewInjector(
+		DetectDuplicateInstances(),
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+		Provide(func() *Connection { return conn }, As(Type[io.Closer]()),
+			WithDestroy(func(c *Connection) { _ = c.Close() })),
+		Provide(func() *Connection { return conn }, As(Type[ConnectionHolder]()),
+			WithDestroy(func(c *Connection) { _ = c.Close() })),
+		Provide(func(c ConnectionHolder) *Connection { return c.Conn() }, In("request")),
+	)
+	assert.Nil(t, err)
+	ctx := WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+	assert.Nil(t, injector.Invoke(ctx, func(_ *Connection) {}))
+	ShutdownContextualScope(ctx, requestScopeKeyVal)
+	injector.Shutdown()
+	assert.Equal(t, 1, conn.closes)
+
+	var kinds []WarningKind
+	for _, w := range injector.Warnings() {
+		kinds = append(kinds, w.Kind)
+	}
+	assert.ElementsMatch(t, []WarningKind{WarningDuplicateDestroy, WarningConflictingScopes}, kinds)
+
+	t.Run("Zero-sized instances should not be tracked", func(t *testing.T) {
+		_, ok := identityOf(reflect.ValueOf(&struct{}{}))
+		assert.False(t, ok)
+		_, ok = identityOf(reflect.ValueOf(conn))
+		assert.True(t, ok)
+	})
+}
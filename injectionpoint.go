@@ -0,0 +1,63 @@
+package goinject
+
+import (
+	"context"
+	"reflect"
+)
+
+var injectionPointReflectType = reflect.TypeFor[InjectionPoint]()
+
+// InjectionPoint describe where the instance being created is injected: the consumer type and the function
+// parameter or Params field receiving the instance.
+// Providers can request an InjectionPoint argument (or call InjectionPointOf with their InvocationContext) to
+// tailor the instance to its consumer, e.g. a *slog.Logger tagged with the consumer type. Such bindings should be
+// in PerLookUp scope, as instances of other scopes are shared between injection points.
+type InjectionPoint struct {
+	target   reflect.Type
+	field    string
+	position int
+}
+
+// TargetType return the type of the consumer: the type provided by the provider requesting the instance, or the
+// type of the invoked function
+func (ip InjectionPoint) TargetType() reflect.Type { return ip.target }
+
+// Field return the name of the Params field receiving the instance, or an empty string for function parameters
+func (ip InjectionPoint) Field() string { return ip.field }
+
+// Position return the index of the function parameter, or of the Params field, receiving the instance
+func (ip InjectionPoint) Position() int { return ip.position }
+
+// InjectionPointOf return the InjectionPoint of the instance being created, given the InvocationContext received
+// by its provider. The second result is false if the instance is not created for an injection point (e.g. an
+// eagerly created singleton) or if ctx is not the context of a provider call.
+func InjectionPointOf(ctx context.Context) (InjectionPoint, bool) {
+	step, ok := creationStepFromContext(ctx)
+	if !ok || step.injectionPoint.target == nil {
+		return InjectionPoint{}, false
+	}
+	return step.injectionPoint, true
+}
+
+type pendingInjectionPointContextKey struct{}
+
+// withPendingInjectionPoint return the context used to resolve the dependency injected at ip
+func withPendingInjectionPoint(ctx context.Context, ip InjectionPoint) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, pendingInjectionPointContextKey{}, ip)
+}
+
+func pendingInjectionPoint(ctx context.Context) InjectionPoint {
+	if ctx == nil {
+		return InjectionPoint{}
+	}
+	ip, _ := ctx.Value(pendingInjectionPointContextKey{}).(InjectionPoint)
+	return ip
+}
+
+// isContextualArgument tell if t is resolved from the resolution context rather than from a binding
+func isContextualArgument(t reflect.Type) bool {
+	return t == invocationContextReflectType || t == injectionPointReflectType || t == bindingNameReflectType
+}
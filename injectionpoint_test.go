@@ -0,0 +1,66 @@
+package goinject
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Logger struct {
+	component string
+	field     string
+	position  int
+}
+
+type LoggedService struct {
+	logger *Logger
+}
+
+type LoggedParams struct {
+	Params
+	Color  *Color  `inject:""`
+	Logger *Logger `inject:""`
+}
+
+func TestInjectionPoint(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func(ip InjectionPoint) *Logger {
+			return &Logger{component: ip.TargetType().String(), field: ip.Field(), position: ip.Position()}
+		}, In(PerLookUp)),
+		Provide(func(_ *Color, logger *Logger) *LoggedService {
+			return &LoggedService{logger: logger}
+		}),
+		Provide(func() *Color { return &Color{name: "red"} }),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(s *LoggedService, params LoggedParams) {
+		assert.Equal(t, &Logger{component: "*goinject.LoggedService", position: 1}, s.logger)
+		assert.Equal(t, &Logger{
+			component: "func(*goinject.LoggedService, goinject.LoggedParams)",
+			field:     "Logger",
+			position:  2,
+		}, params.Logger)
+	})
+	assert.Nil(t, err)
+
+	t.Run("InjectionPointOf should return the injection point from the InvocationContext", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func(ctx InvocationContext) *Logger {
+				ip, ok := InjectionPointOf(ctx)
+				assert.True(t, ok)
+				return &Logger{component: ip.TargetType().String()}
+			}, In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(l *Logger, ctx InvocationContext) {
+			assert.Equal(t, reflect.TypeFor[func(*Logger, InvocationContext)]().String(), l.component)
+			_, ok := InjectionPointOf(ctx)
+			assert.False(t, ok)
+		})
+		assert.Nil(t, err)
+	})
+}
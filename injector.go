@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 var errorReflectType = reflect.TypeFor[error]()
@@ -12,33 +13,90 @@ var invocationContextReflectType = reflect.TypeFor[InvocationContext]()
 
 // Injector defines bindings & scopes
 type Injector struct {
-	bindings       map[reflect.Type]map[string][]*binding // list of available bindings by type and annotations
-	scopes         map[string]Scope                       // Scope by names
-	singletonScope *singletonScope
+	bindings          map[reflect.Type]map[string][]*binding // list of available bindings by type and annotations
+	scopes            map[string]Scope                       // Scope by names
+	singletonScope    *singletonScope
+	errorDecorators   []ErrorDecorator
+	eagerBindings     []*binding         // singleton bindings created eagerly, in creation order
+	tracker           *resolutionTracker // in-flight resolutions, if DebugResolutions is enabled
+	observers         []func(event Event)
+	degraded          map[*binding]error // NonCritical bindings whose eager creation failed
+	deferEagerErrors  bool               // all singletons are NonCritical, see DeferEagerErrors
+	checkInterfaces   bool               // see CheckInterfaceBindings
+	strictNil         bool               // see StrictNil
+	auditContexts     bool               // see AuditContextPropagation
+	auditResolutions  bool               // see AuditResolutions
+	detectDuplicates  bool               // see DetectDuplicateInstances
+	identities        identityMap
+	lifecycle         *lifecycle
+	invokeMiddlewares []InvokeMiddleware
+	selectors         map[BindingKey]Selector
+	bindingsMu        sync.RWMutex  // guard bindings, eagerBindings and degraded, updated by Remove
+	bindingsVersion   atomic.Uint64 // incremented each time bindings are updated, see bindingsChanged
+	warnings          warnings
+	shutdownHooks     shutdownHooks
+	toggles           toggles
+	generations       generations // in-flight invocations, see Swap
+	units             units
+	state             stateMachine
 }
 
-// NewInjector builds up a new Injector out of a list of Modules with singleton scope
+// NewInjector builds up a new Injector out of a list of Modules with singleton scope.
+// If the configuration is invalid, the returned error is a *ConfigurationReport listing all the problems found.
 func NewInjector(options ...Option) (*Injector, error) {
 	mod := &configuration{
 		bindings: make(map[*binding]bool),
 		scopes:   make(map[string]Scope),
 	}
 
+	report := &ConfigurationReport{}
 	for _, o := range options {
-		err := o.apply(mod)
-		if err != nil {
-			return nil, err
+		if err := o.apply(mod); err != nil {
+			report.add(mod.errorDecorators, err)
 		}
 	}
 
+	errs := append(mod.collectImplementations(), mod.applyDefaultScope()...)
+	lifecycle, lifecycleErrs := newLifecycle(mod)
+	errs = append(errs, lifecycleErrs...)
+	errs = append(errs, mod.checkWarmUp()...)
+	errs = append(errs, mod.checkScopeConditions()...)
+	errs = append(errs, mod.checkShutdownGroups()...)
+	errs = append(errs, mod.checkResolutionAudit()...)
+	errs = append(errs, mod.checkModuleRequirements()...)
+	errs = append(errs, mod.checkCoalesced()...)
+	for _, err := range append(errs, mod.lint()...) {
+		report.add(mod.errorDecorators, err)
+	}
+	if len(report.Problems) > 0 {
+		return nil, report
+	}
+
 	singletonScope := newSingletonScope()
 	mod.scopes[Singleton] = singletonScope
 	mod.scopes[PerLookUp] = newPerLookUpScope()
+	mod.scopes[PerInvocation] = newPerInvocationScope()
 
 	injector := &Injector{
-		bindings:       make(map[reflect.Type]map[string][]*binding),
-		scopes:         make(map[string]Scope),
-		singletonScope: singletonScope,
+		bindings:          make(map[reflect.Type]map[string][]*binding),
+		scopes:            make(map[string]Scope),
+		singletonScope:    singletonScope,
+		errorDecorators:   mod.errorDecorators,
+		observers:         mod.observers,
+		degraded:          make(map[*binding]error),
+		lifecycle:         lifecycle,
+		invokeMiddlewares: mod.invokeMiddlewares,
+		selectors:         mod.selectors,
+	}
+	injector.deferEagerErrors = mod.deferEagerErrors
+	injector.checkInterfaces = mod.checkInterfaces
+	injector.strictNil = mod.strictNil
+	injector.auditContexts = mod.auditContexts
+	injector.auditResolutions = mod.auditResolutions
+	injector.detectDuplicates = mod.detectDuplicates
+	injector.shutdownHooks.hooks = mod.shutdownHooks
+	if mod.debugResolutions {
+		injector.tracker = newResolutionTracker()
 	}
 
 	injectorType := reflect.TypeFor[*Injector]()
@@ -50,146 +108,277 @@ func NewInjector(options ...Option) (*Injector, error) {
 	}
 
 	injector.scopes = mod.scopes
-	for b := range mod.bindings {
+	injector.observeScopes()
+	mod.applyFakes()
+	allBindings := mod.orderedBindings()
+	bindings := withoutUnusableConversions(withoutShadowedFallbacks(allBindings))
+	if mod.overrideMode == LastRegisteredWins {
+		bindings = withoutOverriddenBindings(bindings)
+	}
+	injector.warnBindings(allBindings, bindings)
+	for _, b := range bindings {
 		_, ok := injector.bindings[b.typeof]
 		if !ok {
 			injector.bindings[b.typeof] = make(map[string][]*binding)
 		}
 		injector.bindings[b.typeof][b.annotatedWith] = append(injector.bindings[b.typeof][b.annotatedWith], b)
+		if b.scope == Singleton && mod.isEager(b) {
+			injector.eagerBindings = append(injector.eagerBindings, b)
+		}
 	}
 
 	injector.bindings[injectorType] = make(map[string][]*binding)
 	injector.bindings[injectorType][""] = []*binding{injectorBinding}
 
+	if err := injector.verifyManifest(mod.expectedManifest); err != nil {
+		report.add(mod.errorDecorators, err)
+		return nil, report
+	}
+
 	err := injector.eagerlyCreateSingletons()
 	if err != nil {
-		return nil, err
+		return nil, decorateError(injector.errorDecorators, err)
 	}
 	return injector, nil
 }
 
-// Shutdown clear underlying singleton scope
+// Shutdown clear underlying singleton scope.
+// Registered scopes having a Shutdown() method (such as tenant scopes) are shut down before the singleton scope,
+// then the OnShutdown hooks are called.
 func (injector *Injector) Shutdown() {
+	injector.state.set(InjectorStopped)
+	for _, scope := range injector.scopes {
+		if s, ok := scope.(interface{ Shutdown() }); ok && scope != Scope(injector.singletonScope) {
+			s.Shutdown()
+		}
+	}
 	injector.singletonScope.Shutdown()
+	injector.runShutdownHooks()
+	injector.bindingsMu.Lock()
+	defer injector.bindingsMu.Unlock()
 	injector.bindings = make(map[reflect.Type]map[string][]*binding)
+	injector.bindingsChanged()
 	injector.scopes = make(map[string]Scope)
 }
 
 // Invoke will execute the parameter function (which must be a function that optionally can return an error).
 // argument of function will be resolved by the injector using configured providers & scope.
-func (injector *Injector) Invoke(ctx context.Context, function any) error {
+// opts configure the call, see WithResolutionTimeout.
+func (injector *Injector) Invoke(ctx context.Context, function any, opts ...InvokeOption) error {
+	if err := injector.checkNotStopped("Invoke"); err != nil {
+		return err
+	}
+	fvalue, err := validateInvokedFunction(function)
+	if err != nil {
+		return err
+	}
+	ftype := fvalue.Type()
+	config := &invokeConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return injector.invoke(ctx, fvalue, func(ctx context.Context) ([]reflect.Value, error) {
+		if config.resolutionTimeout > 0 {
+			return injector.callFunctionWithResolutionTimeout(ctx, fvalue, ftype, config.resolutionTimeout)
+		}
+		return injector.callFunctionWithArgumentInstance(ctx, fvalue, ftype)
+	})
+}
+
+// invoke run an invocation of fvalue, call resolving its arguments and calling it: the invocation goes through the
+// invoke middlewares, and its errors are decorated and notified (see InvocationFailedEvent)
+func (injector *Injector) invoke(
+	ctx context.Context,
+	fvalue reflect.Value,
+	call func(ctx context.Context) ([]reflect.Value, error),
+) error {
+	invoke := func(ctx context.Context) error {
+		res, err := call(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to call invokation function: %w", err)
+		}
+		if fvalue.Type().NumOut() == 1 {
+			invokationError, _ := res[0].Interface().(error)
+			if invokationError != nil {
+				return fmt.Errorf("invokation returned error: %w", invokationError)
+			}
+		}
+		return nil
+	}
+	if len(injector.invokeMiddlewares) > 0 {
+		invoke = injector.withInvokeMiddlewares(newInvokeInfo(fvalue), invoke)
+	}
+	ctx, invocationID := withInvocationID(injector.withInvocation(injector.withAuditedFunction(ctx, fvalue)))
+	ctx, shutdownInvocationScope := withPerInvocationScope(ctx)
+	defer shutdownInvocationScope()
+	defer injector.generations.enter()()
+	if err := invoke(ctx); err != nil {
+		err = decorateError(injector.errorDecorators, newInvocationError(invocationID, err))
+		injector.notify(InvocationFailedEvent{InvocationID: invocationID, Err: err})
+		return err
+	}
+	return nil
+}
+
+func validateInvokedFunction(function any) (reflect.Value, error) {
 	if function == nil {
-		return newInvalidInputError("can't invoke on nil")
+		return reflect.Value{}, newInvalidInputError("can't invoke on nil")
 	}
 	fvalue := reflect.ValueOf(function)
 	ftype := fvalue.Type()
 	if ftype.Kind() != reflect.Func {
-		return newInvalidInputError(
+		return reflect.Value{}, newInvalidInputError(
 			fmt.Sprintf("can't invoke non-function %v (type %v)", function, ftype))
 	}
 
 	if ftype.NumOut() > 1 || (ftype.NumOut() == 1 && !ftype.Out(0).AssignableTo(errorReflectType)) {
-		return newInvalidInputError("can't invoke on function whose return type is not error or no return type")
+		return reflect.Value{},
+			newInvalidInputError("can't invoke on function whose return type is not error or no return type")
 	}
+	return fvalue, nil
+}
 
-	res, err := injector.callFunctionWithArgumentInstance(ctx, fvalue)
-	if err != nil {
-		return fmt.Errorf("failed to call invokation function: %w", err)
-	}
-	if ftype.NumOut() == 1 {
-		invokationError := res[0].Interface().(error)
-		if invokationError != nil {
-			return fmt.Errorf("invokation returned error: %w", invokationError)
+func (injector *Injector) eagerlyCreateSingletons() error {
+	for _, b := range injector.eagerBindings {
+		if err := injector.createSingleton(b); err != nil {
+			return fmt.Errorf("failed to get singleton instance: %w", err)
 		}
 	}
 	return nil
 }
 
-func (injector *Injector) eagerlyCreateSingletons() error {
-	for _, bindingsByAnnotation := range injector.bindings {
-		for _, bindingList := range bindingsByAnnotation {
-			for _, b := range bindingList {
-				if b.scope == Singleton {
-					_, err := injector.getScopedInstanceFromBinding(nil, b) //nolint:staticcheck
-					if err != nil {
-						return fmt.Errorf("failed to get singleton instance: %w", err)
-					}
-				}
-			}
-		}
+// createSingleton create the instance of the singleton binding b, its creation error is only returned if b is
+// critical, otherwise b is degraded
+func (injector *Injector) createSingleton(b *binding) error {
+	_, err := injector.getScopedInstanceFromBinding(context.Background(), b)
+	if err != nil && (b.nonCritical || injector.deferEagerErrors) {
+		injector.bindingsMu.Lock()
+		injector.degraded[b] = newDegradedBindingError(b.key(), err)
+		injector.bindingsMu.Unlock()
+		injector.notify(BindingDegradedEvent{Key: b.key(), Err: err})
+		return nil
 	}
-	return nil
+	return err
 }
 
+// callFunctionWithArgumentInstance resolve the arguments of the function and call it, target is the consumer type
+// of the arguments InjectionPoint
 func (injector *Injector) callFunctionWithArgumentInstance(
 	ctx context.Context,
 	fValue reflect.Value,
+	target reflect.Type,
+) ([]reflect.Value, error) {
+	in, err := injector.resolveFunctionArguments(ctx, fValue, target)
+	if err != nil {
+		return []reflect.Value{}, err
+	}
+	return fValue.Call(in), nil
+}
+
+// resolveFunctionArguments resolve the arguments of the function, target is the consumer type of the arguments
+// InjectionPoint
+func (injector *Injector) resolveFunctionArguments(
+	ctx context.Context,
+	fValue reflect.Value,
+	target reflect.Type,
 ) ([]reflect.Value, error) {
 	fType := fValue.Type()
+	plans := planOf(fType)
 	in := make([]reflect.Value, fType.NumIn())
 	var err error
 	for i := 0; i < fType.NumIn(); i++ {
-		if in[i], err = injector.getFunctionArgumentInstance(ctx, fType.In(i)); err != nil {
+		if err = checkResolutionContext(ctx); err != nil {
+			return []reflect.Value{}, err
+		}
+		argCtx := ctx
+		if !isContextualArgument(fType.In(i)) {
+			argCtx = withPendingInjectionPoint(ctx, InjectionPoint{target: target, position: i})
+		}
+		if in[i], err = injector.getFunctionArgumentInstance(argCtx, fType.In(i), argumentPlans(plans, i)); err != nil {
 			return []reflect.Value{}, fmt.Errorf("failed to resolve function argument #%d: %w", i, err)
 		}
 	}
-
-	res := fValue.Call(in)
-	return res, nil
+	if err = checkResolutionContext(ctx); err != nil {
+		return []reflect.Value{}, err
+	}
+	return in, nil
 }
 
-func (injector *Injector) getFunctionArgumentInstance(ctx context.Context, argType reflect.Type) (reflect.Value, error) {
+// getFunctionArgumentInstance resolve a function argument of type argType, plans are its plans (see planOf)
+func (injector *Injector) getFunctionArgumentInstance(
+	ctx context.Context,
+	argType reflect.Type,
+	plans []ParamPlan,
+) (reflect.Value, error) {
 	if EmbedsParams(argType) {
-		return injector.createEmbeddedParams(ctx, argType)
+		return injector.createEmbeddedParams(ctx, argType, plans, nil)
 	} else {
 		return injector.getInstanceOfAnnotatedType(ctx, argType, "", false)
 	}
 }
 
-func (injector *Injector) createEmbeddedParams(ctx context.Context, embeddedType reflect.Type) (reflect.Value, error) {
+// createEmbeddedParams create a Params struct (or pointer to struct) and resolve its fields, described by plans.
+// If tolerate is not nil, it is called with field resolution errors and the field is left to its zero value
+// when it returns true.
+func (injector *Injector) createEmbeddedParams(
+	ctx context.Context,
+	embeddedType reflect.Type,
+	plans []ParamPlan,
+	tolerate func(err error) bool,
+) (reflect.Value, error) {
 	if embeddedType.Kind() == reflect.Ptr {
 		n := reflect.New(embeddedType.Elem())
-		return n, injector.setParamFields(ctx, n.Elem())
+		return n, injector.setParamFields(ctx, n.Elem(), plans, tolerate)
 	} else { // struct
 		n := reflect.New(embeddedType).Elem()
-		return n, injector.setParamFields(ctx, n)
+		return n, injector.setParamFields(ctx, n, plans, tolerate)
 	}
 }
 
 func (injector *Injector) setParamFields(
 	ctx context.Context,
 	paramValue reflect.Value,
+	plans []ParamPlan,
+	tolerate func(err error) bool,
 ) error {
 	embeddedType := paramValue.Type()
-	for fieldIndex := 0; fieldIndex < embeddedType.NumField(); fieldIndex++ {
-		field := paramValue.Field(fieldIndex)
-		if field.Type() == _paramType {
-			continue
+	for _, plan := range plans {
+		field := paramValue.Field(plan.FieldIndex)
+		structField := embeddedType.Field(plan.FieldIndex)
+		tag, optional := parseInjectTag(structField.Tag.Get("inject"))
+		if !field.CanSet() {
+			return newInjectionError(field.Type(), tag, fmt.Errorf("use inject tag on unsettable field"))
+		}
+		if optional {
+			injector.warnUnusedOptional(structField, tag, plan.HasDefault)
 		}
-		if tag, ok := embeddedType.Field(fieldIndex).Tag.Lookup("inject"); ok {
-			if !field.CanSet() {
-				return newInjectionError(field.Type(), tag, fmt.Errorf("use inject tag on unsettable field"))
-			}
-
-			var optional bool
-			for _, option := range strings.Split(tag, ",") {
-				if strings.TrimSpace(option) == "optional" {
-					optional = true
-				}
-			}
-			tag = strings.Split(tag, ",")[0]
 
-			instance, err := injector.getInstanceOfAnnotatedType(ctx, field.Type(), tag, optional)
-			if err != nil {
-				return newInjectionError(field.Type(), tag, err)
-			}
-			if instance.IsValid() {
-				field.Set(instance)
-			} else if optional {
+		fieldCtx := ctx
+		if plan.Kind != ParamContextual {
+			fieldCtx = withPendingInjectionPoint(ctx, InjectionPoint{
+				target:   pendingInjectionPoint(ctx).target,
+				field:    plan.Field,
+				position: plan.FieldIndex,
+			})
+		}
+		instance, err := injector.getInstanceOfAnnotatedType(fieldCtx, plan.Type, tag, plan.Optional)
+		if err != nil {
+			if tolerate != nil && tolerate(newInjectionError(plan.Type, tag, err)) {
 				continue
-			} else {
-				return newInjectionError(field.Type(), tag, fmt.Errorf("cannot get valid instance from scope"))
 			}
+			return newInjectionError(plan.Type, tag, err)
+		}
+		if plan.HasDefault && (!instance.IsValid() || (instance.Kind() == reflect.Slice && instance.Len() == 0)) {
+			if instance, err = parseLiteral(plan.Type, plan.Default); err != nil {
+				return newInjectionError(plan.Type, tag, fmt.Errorf("invalid default value %q: %w", plan.Default, err))
+			}
+		}
+		if instance.IsValid() {
+			field.Set(instance)
+		} else if optional {
+			continue
+		} else {
+			return newInjectionError(plan.Type, tag, fmt.Errorf("cannot get valid instance from scope"))
 		}
 	}
 	return nil
@@ -204,11 +393,11 @@ func (injector *Injector) getInstanceOfAnnotatedType(
 ) (reflect.Value, error) {
 	// if is slice, return as multi bindings
 	if t.Kind() == reflect.Slice {
-		bindings := injector.findBindingsForAnnotatedType(t.Elem(), annotation)
+		bindings := injector.activeBindings(ctx, injector.findBindingsForAnnotatedType(t.Elem(), annotation))
 		if len(bindings) > 0 {
 			n := reflect.MakeSlice(t, 0, len(bindings))
 			for _, binding := range bindings {
-				r, err := injector.getScopedInstanceFromBinding(ctx, binding)
+				r, err := injector.getInjectedInstance(ctx, binding)
 				if err != nil {
 					return reflect.Value{}, err
 				}
@@ -218,27 +407,48 @@ func (injector *Injector) getInstanceOfAnnotatedType(
 		} else if optional {
 			return reflect.MakeSlice(t, 0, 0), nil
 		} else {
-			return reflect.MakeSlice(t, 0, 0), newInjectionError(t.Elem(), annotation,
-				fmt.Errorf("did not found binding, expected at least one"))
+			return reflect.MakeSlice(t, 0, 0), withResolutionPath(ctx, BindingKey{t.Elem(), annotation},
+				injector.disabledBindingError(t.Elem(), annotation, newInjectionError(t.Elem(), annotation,
+					fmt.Errorf("did not found binding, expected at least one"))))
 		}
 	}
 
 	// check if there is a binding for this type & annotation
-	bindings := injector.findBindingsForAnnotatedType(t, annotation)
-	if len(bindings) > 1 {
-		return reflect.Value{},
-			newInjectionError(t, annotation, fmt.Errorf("found multiple bindings expected one"))
+	bindings := injector.activeBindings(ctx, injector.findBindingsForAnnotatedType(t, annotation))
+	if selector, ok := injector.selectors[BindingKey{t, annotation}]; ok && len(bindings) > 1 {
+		selected, err := selectBinding(ctx, selector, bindings)
+		if err != nil {
+			return reflect.Value{}, withResolutionPath(ctx, BindingKey{t, annotation},
+				newInjectionError(t, annotation, err))
+		}
+		return injector.getInjectedInstance(ctx, selected)
+	} else if len(bindings) > 1 {
+		return reflect.Value{}, withResolutionPath(ctx, BindingKey{t, annotation},
+			newInjectionError(t, annotation, fmt.Errorf("found multiple bindings expected one")))
 	} else if len(bindings) == 1 {
-		return injector.getScopedInstanceFromBinding(ctx, bindings[0])
+		return injector.getInjectedInstance(ctx, bindings[0])
 	} else if injector.isProviderType(t) {
 		return injector.createProviderValue(t, annotation, optional), nil
+	} else if isOptionalType(t) {
+		return injector.createOptionalValue(ctx, t, annotation), nil
+	} else if isBoundProviderType(t) {
+		return injector.createBoundProviderValue(ctx, t, annotation, optional), nil
+	} else if isRegistryType(t) {
+		return injector.createRegistryValue(ctx, t, annotation), nil
 	} else if t == invocationContextReflectType {
 		return reflect.ValueOf(ctx), nil
+	} else if t == injectionPointReflectType {
+		ip, _ := InjectionPointOf(ctx)
+		return reflect.ValueOf(ip), nil
+	} else if t == bindingNameReflectType {
+		step, _ := creationStepFromContext(ctx)
+		return reflect.ValueOf(BindingName(step.binding.Key.Annotation)), nil
 	} else if optional {
 		return reflect.Value{}, nil
 	} else {
-		return reflect.Value{},
-			newInjectionError(t, annotation, fmt.Errorf("did not found binding, expected one"))
+		return reflect.Value{}, withResolutionPath(ctx, BindingKey{t, annotation},
+			injector.disabledBindingError(t, annotation,
+				newInjectionError(t, annotation, fmt.Errorf("did not found binding, expected one"))))
 	}
 }
 
@@ -276,10 +486,36 @@ func (injector *Injector) createProviderValue(
 	})
 }
 
+// bindingsChanged invalidate the caches of bindings (e.g. the subscribers of the event bus), bindingsMu must be held
+func (injector *Injector) bindingsChanged() {
+	injector.bindingsVersion.Add(1)
+}
+
+// allBindings return all the bindings of the injector (except the *Injector one)
+func (injector *Injector) allBindings() []*binding {
+	injector.bindingsMu.RLock()
+	defer injector.bindingsMu.RUnlock()
+	var res []*binding
+	for t, byAnnotation := range injector.bindings {
+		if t == reflect.TypeFor[*Injector]() {
+			continue
+		}
+		for _, bindings := range byAnnotation {
+			res = append(res, bindings...)
+		}
+	}
+	return res
+}
+
 func (injector *Injector) findBindingsForAnnotatedType(
 	t reflect.Type,
 	annotation string,
 ) []*binding {
+	injector.bindingsMu.RLock()
+	defer injector.bindingsMu.RUnlock()
+	if isAnnotationPattern(annotation) {
+		return findBindingsMatchingAnnotation(injector.bindings[t], annotation)
+	}
 	if _, ok := injector.bindings[t]; ok && len(injector.bindings[t][annotation]) > 0 {
 		bindings := injector.bindings[t][annotation]
 		res := make([]*binding, len(bindings))
@@ -294,22 +530,104 @@ func (injector *Injector) getScopedInstanceFromBinding(
 	ctx context.Context,
 	binding *binding,
 ) (reflect.Value, error) {
+	injector.bindingsMu.RLock()
+	err, degraded := injector.degraded[binding]
+	injector.bindingsMu.RUnlock()
+	if degraded {
+		return reflect.Value{}, withResolutionPath(ctx, binding.key(), err)
+	}
+	if path := resolutionPathFromContext(ctx); path.contains(binding.key()) &&
+		(binding.activeInScope != "" || !isDecorating(ctx, binding.key())) {
+		return reflect.Value{}, withResolutionPath(ctx, binding.key(), newInjectionError(
+			binding.typeof, binding.annotatedWith,
+			fmt.Errorf("dependency cycle detected: %s", path.appendPath(binding.key()))))
+	}
 	scope, err := injector.getScopeFromBinding(binding)
 	if err != nil {
-		return reflect.Value{}, err
-	}
-	val, err := scope.ResolveBinding(ctx, binding, func() (Instance, error) {
-		val, creationError := binding.create(ctx, injector)
-		destroyMethod := binding.destroyMethod
-		if creationError == nil && destroyMethod != nil && !val.IsZero() {
-			scope.RegisterDestructionCallback(
-				ctx,
-				func() { destroyMethod(val) },
-			)
+		return reflect.Value{}, withResolutionPath(ctx, binding.key(), err)
+	}
+	injector.auditResolution(ctx, binding, scope)
+	creationCtx := withCreationStep(ctx, binding)
+	defer trackResolutionProgress(ctx, resolutionPathFromContext(creationCtx))()
+	var tracked *trackedResolution
+	if injector.tracker != nil {
+		tracked = injector.tracker.begin(resolutionPathFromContext(creationCtx))
+		defer injector.tracker.end(tracked)
+	}
+	instanceCreator := func() (Instance, error) {
+		if tracked != nil {
+			injector.tracker.markCreating(tracked)
+		}
+		val, destroy, creationError := injector.createInstance(ctx, creationCtx, binding)
+		if destroy != nil {
+			injector.registerDestroy(ctx, binding, scope, destroy)
 		}
 		return Instance(val), creationError
-	})
-	return reflect.Value(val), err
+	}
+	resolve := func(instanceCreator func() (Instance, error)) (Instance, error) {
+		if binding.cacheKey != nil {
+			return resolveCachedInstance(ctx, scope, binding, instanceCreator)
+		}
+		if batch := batchRegistry(ctx); batch != nil && binding.sharedInBatch() {
+			return batch.resolveBinding(binding, instanceCreator)
+		}
+		return scope.ResolveBinding(ctx, binding, instanceCreator)
+	}
+	var val Instance
+	if binding.validity != nil {
+		val, err = resolveValidatedInstance(ctx, scope, binding, resolve, func() (reflect.Value, func(), error) {
+			if tracked != nil {
+				injector.tracker.markCreating(tracked)
+			}
+			return injector.createInstance(ctx, creationCtx, binding)
+		})
+	} else {
+		val, err = resolve(instanceCreator)
+	}
+	return reflect.Value(val), withResolutionPath(ctx, binding.key(), err)
+}
+
+// createInstance create an instance of the binding with creationCtx and record it for the lifecycle hooks, running
+// its start hook if the injector is already started (the instance is destroyed if the hook fails). It returns the
+// function destroying the instance, nil if the instance must not be destroyed (no destroy method, or an instance
+// already destroyed by another binding, see DetectDuplicateInstances)
+func (injector *Injector) createInstance(
+	ctx context.Context,
+	creationCtx context.Context,
+	binding *binding,
+) (reflect.Value, func(), error) {
+	injector.auditCreation(ctx, binding)
+	val, err := binding.create(creationCtx, injector)
+	if err != nil {
+		return val, nil, err
+	}
+	injector.lifecycle.recordCreated(binding)
+	var destroy func()
+	destroyMethod := binding.destroyMethod
+	if injector.trackInstance(binding, val) && destroyMethod != nil && !val.IsZero() {
+		destroy = func() { destroyMethod(val) }
+	}
+	if err = injector.startCreated(ctx, binding, val); err != nil {
+		if destroy != nil {
+			destroy()
+		}
+		return reflect.Value{}, nil, err
+	}
+	return val, destroy, nil
+}
+
+// registerDestroy register the destroy function of an instance of the binding in scope, or in the invocation for
+// DestroyOnReturn bindings. Singleton instances are destroyed at most once, see destroySingleton.
+func (injector *Injector) registerDestroy(ctx context.Context, binding *binding, scope Scope, destroy func()) {
+	if binding.scope == Singleton {
+		destroy = sync.OnceFunc(destroy)
+		binding.destroyCurrent.Store(&destroy)
+	}
+	if binding.destroyOnExit {
+		registerInvocationDestructionCallback(withShutdownOrder(ctx, binding.shutdownOrder), destroy)
+	} else {
+		scope.RegisterDestructionCallback(withShutdownOrder(ctx, binding.shutdownOrder), destroy)
+	}
 }
 
 func (injector *Injector) getScopeFromBinding(
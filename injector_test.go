@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -212,6 +213,50 @@ func TestInstallModuleShouldInstallBindingsOnce(t *testing.T) {
 	})
 }
 
+func parentModule() Option {
+	return Module("parent", Provide(func() *Parent { return &Parent{} }))
+}
+
+func TestInstallModuleShouldDedupeSameDefinition(t *testing.T) {
+	parent := parentModule()
+	injector, err := NewInjector(
+		Module("a", parent),
+		Module("b", parent),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(injector.bindings[reflect.TypeFor[*Parent]()][""]))
+}
+
+func TestInstallModuleShouldFailOnDifferentDefinitions(t *testing.T) {
+	_, err := NewInjector(
+		parentModule(),
+		Module("parent", Provide(func() *Parent { return &Parent{} })),
+	)
+	assert.ErrorContains(t, err, `module "parent" installed twice with different definitions`)
+}
+
+func TestInstallModuleShouldFailOnFactoryCalledTwice(t *testing.T) {
+	db := func(name string) Option {
+		return Module("db", Provide(func() *Database { return &Database{} }, Named(name)))
+	}
+	_, err := NewInjector(db("primary"), db("replica"))
+	assert.ErrorContains(t, err, `module "db" installed twice with different definitions`)
+
+	_, err = NewInjector(parentModule(), parentModule())
+	assert.ErrorContains(t, err, `module "parent" installed twice with different definitions`)
+}
+
+func TestInjectorOptionShouldNotBeInstalledInModule(t *testing.T) {
+	observer := func(_ Event) {}
+	_, err := NewInjector(When(OnEnvironmentVariable("GOINJECT_UNSET", "", true), WithObserver(observer), Deterministic()))
+	assert.Nil(t, err)
+
+	_, err = NewInjector(Module("app", When(OnEnvironmentVariable("GOINJECT_UNSET", "", true), WithObserver(observer))))
+	assert.ErrorContains(t, err, "injector option WithObserver cannot be installed in module app")
+
+	var _ Option = DebugResolutions()
+}
+
 type Shape interface {
 	Name() string
 }
@@ -367,6 +412,28 @@ func TestMultiBind(t *testing.T) {
 	})
 }
 
+func TestIntoGroupShouldMarkGroupMembers(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func() *Color { return &Color{name: "red"} }, IntoGroup("colors")),
+		Provide(func() *Color { return &Color{name: "blue"} }, Named("primary")),
+	)
+	assert.Nil(t, err)
+	grouped := make(map[string]bool)
+	for _, b := range injector.allBindings() {
+		grouped[b.annotatedWith] = b.grouped
+	}
+	assert.Equal(t, map[string]bool{"colors": true, "primary": false}, grouped)
+	err = injector.Invoke(context.Background(), func(p MultiColorParams) {
+		assert.Len(t, p.Colors, 1)
+	})
+	assert.Nil(t, err)
+}
+
+type MultiColorParams struct {
+	Params
+	Colors []*Color `inject:"colors"`
+}
+
 type WithProvider struct {
 	provider Provider[*WithRefCount]
 }
@@ -532,9 +599,42 @@ func TestConditional(t *testing.T) {
 			),
 		)
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t, "cannot accept nil provider", err.Error())
 	})
+
+	t.Run("Test conditional variant should register binding if variant is enabled", func(t *testing.T) {
+		injector, err := NewInjector(
+			WithVariants("enterprise", "cloud"),
+			When(OnVariant("enterprise"),
+				Provide(func() *Parent { return &Parent{} }),
+			),
+			When(OnVariant("community"),
+				Provide(func() *Child { return &Child{} }),
+			),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		err = injector.Invoke(ctx, func(parent *Parent) {
+			assert.NotNil(t, parent)
+		})
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(_ *Child) {
+			assert.Fail(t, "inaccessible")
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Test WithVariants should be given before conditional variants", func(t *testing.T) {
+		_, err := NewInjector(
+			When(OnVariant("enterprise"),
+				Provide(func() *Parent { return &Parent{} }),
+			),
+			WithVariants("enterprise"),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.Equal(t, "variants enterprise must be enabled before the options conditional on variants", err.Error())
+	})
 }
 
 func TestInvokeError(t *testing.T) {
@@ -577,6 +677,16 @@ func TestInvokeError(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.ErrorIs(t, err, invokationFnReturnedError)
 	})
+
+	t.Run("Invoke should return nil if function return a nil error", func(t *testing.T) {
+		injector, err := NewInjector()
+		assert.Nil(t, err)
+		ctx := context.Background()
+		assert.NotPanics(t, func() {
+			err = injector.Invoke(ctx, func() error { return nil })
+		})
+		assert.Nil(t, err)
+	})
 }
 
 func TestInjectorConfigurationError(t *testing.T) {
@@ -584,7 +694,7 @@ func TestInjectorConfigurationError(t *testing.T) {
 		_, err := NewInjector(
 			Provide(nil))
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t, "cannot accept nil provider", err.Error())
 	})
 
@@ -592,7 +702,7 @@ func TestInjectorConfigurationError(t *testing.T) {
 		_, err := NewInjector(
 			Provide(true))
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t, "provider argument should be a function", err.Error())
 	})
 
@@ -600,7 +710,7 @@ func TestInjectorConfigurationError(t *testing.T) {
 		_, err := NewInjector(
 			Provide(func() {}))
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t, "expected a function that return an instance and optionally an error", err.Error())
 	})
 
@@ -610,7 +720,7 @@ func TestInjectorConfigurationError(t *testing.T) {
 				return &Parent{}, &Child{}
 			}))
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t, "second return type of provider should be an error", err.Error())
 	})
 
@@ -620,7 +730,7 @@ func TestInjectorConfigurationError(t *testing.T) {
 				Provide(nil)),
 		)
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t, "error while installing module test.Module:\ncannot accept nil provider", err.Error())
 	})
 
@@ -631,7 +741,7 @@ func TestInjectorConfigurationError(t *testing.T) {
 			}, As(Type[*Child]())),
 		)
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t,
 			"got error while configuring provider for provided type *goinject.Parent:\ncannot assign "+
 				"*goinject.Parent to *goinject.Child as specified in As argument",
@@ -646,7 +756,7 @@ func TestInjectorConfigurationError(t *testing.T) {
 			}, WithDestroy(true)),
 		)
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t,
 			"got error while configuring provider for provided type *goinject.Parent:\nargument of WithDestroy"+
 				" must be a function with one argument returning void",
@@ -661,7 +771,7 @@ func TestInjectorConfigurationError(t *testing.T) {
 			}, WithDestroy(func(_ *Child) {})),
 		)
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t,
 			"got error while configuring provider for provided type *goinject.Parent:\nargument of WithDestroy"+
 				" must be a function with one argument returning void",
@@ -678,10 +788,215 @@ func TestInjectorConfigurationError(t *testing.T) {
 			})),
 		)
 		assert.NotNil(t, err)
-		assert.IsType(t, err, &injectorConfigurationError{})
+		assert.IsType(t, err, &ConfigurationReport{})
 		assert.Equal(t,
 			"got error while configuring provider for provided type *goinject.Parent:\nargument of WithDestroy "+
 				"must be a function with one argument returning void", err.Error(),
 		)
 	})
 }
+
+func TestInvokeShouldAbortResolutionWhenContextIsDone(t *testing.T) {
+	t.Run("Canceled context", func(t *testing.T) {
+		parentCreated := false
+		injector, err := NewInjector(
+			Provide(func() *Parent {
+				parentCreated = true
+				return &Parent{}
+			}, In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err = injector.Invoke(ctx, func(_ *Parent) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, parentCreated)
+	})
+
+	t.Run("Context canceled by a provider should abort the remaining resolution", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		childCreated := false
+		injector, err := NewInjector(
+			Provide(func() *Parent {
+				cancel()
+				return &Parent{}
+			}, In(PerLookUp)),
+			Provide(func(_ *Parent) *Child {
+				childCreated = true
+				return &Child{}
+			}, In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(_ *Child) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, childCreated)
+	})
+
+	t.Run("Singleton creation aborted by deadline should be retried", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func(_ *Parent) *Child { return &Child{} }, Named("lazy"), In("lazy")),
+			Provide(func() *Parent { return &Parent{} }, In(PerLookUp)),
+			RegisterScope("lazy", newSingletonScope()),
+		)
+		assert.Nil(t, err)
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		err = injector.Invoke(ctx, func(_ TestInvokeLazyChildParams) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		err = injector.Invoke(context.Background(), func(p TestInvokeLazyChildParams) {
+			assert.NotNil(t, p.Child)
+		})
+		assert.Nil(t, err)
+	})
+}
+
+type TestInvokeLazyChildParams struct {
+	Params
+	Child *Child `inject:"lazy"`
+}
+
+type CycleA struct{}
+
+type CycleB struct{}
+
+func TestDependencyCycle(t *testing.T) {
+	t.Run("Should detect cycle between per-lookup bindings", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func(_ *CycleB) *CycleA { return &CycleA{} }, In(PerLookUp)),
+			Provide(func(_ *CycleA) *CycleB { return &CycleB{} }, In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func(_ *CycleA) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorContains(t, err,
+			"dependency cycle detected: *goinject.CycleA -> *goinject.CycleB -> *goinject.CycleA")
+	})
+
+	t.Run("Should detect cycle between eager singletons", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func(_ *CycleB) *CycleA { return &CycleA{} }),
+			Provide(func(_ *CycleA) *CycleB { return &CycleB{} }),
+		)
+		assert.ErrorContains(t, err, "dependency cycle detected")
+	})
+}
+
+func TestReentrantInvoke(t *testing.T) {
+	t.Run("Provider should be able to invoke the injector", func(t *testing.T) {
+		injector, err := NewInjector(
+			RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+			Provide(func() *Request { return &Request{ID: 42} }, In("request")),
+			Provide(func(ctx InvocationContext, i *Injector) (*Child, error) {
+				child := &Child{}
+				err := i.Invoke(ctx, func(r *Request, p *Parent) {
+					assert.Equal(t, 42, r.ID)
+					child.parent = p
+				})
+				return child, err
+			}, In("request")),
+			Provide(func() *Parent { return &Parent{} }),
+		)
+		assert.Nil(t, err)
+		ctx := WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+		defer ShutdownContextualScope(ctx, requestScopeKeyVal)
+		err = injector.Invoke(ctx, func(c *Child) {
+			assert.NotNil(t, c.parent)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Nested invoke should detect cycles", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func(ctx InvocationContext, i *Injector) (*Child, error) {
+				return &Child{}, i.Invoke(ctx, func(_ *Child) {})
+			}, In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func(_ *Child) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorContains(t, err, "dependency cycle detected: *goinject.Child -> *goinject.Child")
+	})
+}
+
+const Green Name = "green"
+
+type TestInvokeParamTypedName struct {
+	Params
+	Color *Color `inject:"green"`
+}
+
+func TestTypedAnnotationName(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func() *Color { return &Color{name: "green"} }, Named(Green)),
+		Provide(func() *Color { return &Color{name: "blue"} }, Named("blue")),
+	)
+	assert.Nil(t, err)
+	err = injector.Invoke(context.Background(), func(param TestInvokeParamTypedName) {
+		assert.Equal(t, "green", param.Color.name)
+	})
+	assert.Nil(t, err)
+}
+
+func TestCurrentBinding(t *testing.T) {
+	colorByBinding := func(ctx InvocationContext) *Color {
+		info, ok := CurrentBinding(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, Singleton, info.Scope)
+		return &Color{name: info.Key.Annotation}
+	}
+	injector, err := NewInjector(
+		Provide(colorByBinding, Named("red")),
+		Provide(colorByBinding, Named("blue")),
+	)
+	assert.Nil(t, err)
+
+	err = injector.Invoke(context.Background(), func(param TestInvokeParamAnnotated, ctx InvocationContext) {
+		assert.Equal(t, "red", param.Color.name)
+		_, ok := CurrentBinding(ctx)
+		assert.False(t, ok)
+	})
+	assert.Nil(t, err)
+}
+
+func TestBindingName(t *testing.T) {
+	type redisConfig struct {
+		Params
+		Sections map[string]string `inject:""`
+	}
+	newRedis := func(name BindingName, config redisConfig) *Color {
+		return &Color{name: config.Sections[string(name)]}
+	}
+	injector, err := NewInjector(
+		Provide(func() map[string]string {
+			return map[string]string{"cache": "redis://cache", "sessions": "redis://sessions"}
+		}),
+		Provide(newRedis, Named("cache")),
+		Provide(newRedis, Named("sessions")),
+	)
+	assert.Nil(t, err)
+
+	err = injector.Invoke(context.Background(), func(p struct {
+		Params
+		Cache    *Color `inject:"cache"`
+		Sessions *Color `inject:"sessions"`
+	}, name BindingName) {
+		assert.Equal(t, "redis://cache", p.Cache.name)
+		assert.Equal(t, "redis://sessions", p.Sessions.name)
+		assert.Equal(t, BindingName(""), name)
+	})
+	assert.Nil(t, err)
+
+	for _, b := range injector.Graph().Bindings {
+		if b.Key == `*goinject.Color("cache")` {
+			assert.Equal(t, []string{"map[string]string"}, b.Dependencies)
+		}
+	}
+}
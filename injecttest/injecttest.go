@@ -0,0 +1,34 @@
+// Package injecttest provides helpers to test code wired by a goinject Injector.
+//
+// Stub replaces a binding for the duration of a test:
+//
+//	func TestCheckout(t *testing.T) {
+//		injecttest.Stub[PaymentGateway](t, injector, &fakeGateway{})
+//		...
+//	}
+package injecttest
+
+import (
+	"testing"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// Fakes return an InjectorOption enabling the fake constructors declared with goinject.FakeInTests when running
+// under go test (see testing.Testing), so that the test wiring can be shared with the production one. The core
+// package only provides the explicit goinject.WithFakes, to keep the testing package out of production binaries.
+func Fakes() goinject.InjectorOption {
+	return goinject.WithFakes(testing.Testing())
+}
+
+// Stub replace the binding of T (annotated with the given annotation, if any) with value for the duration of the
+// test, restoring the original binding and instances with t.Cleanup. Singletons depending on T are re-created
+// with value while the stub is active, see goinject.Injector.Stub.
+func Stub[T any](t testing.TB, injector *goinject.Injector, value T, annotation ...string) {
+	t.Helper()
+	restore, err := injector.Stub(goinject.KeyOf[T](annotation...), value)
+	if err != nil {
+		t.Fatalf("failed to stub %s: %v", goinject.KeyOf[T](annotation...), err)
+	}
+	t.Cleanup(restore)
+}
@@ -0,0 +1,84 @@
+package injecttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+type Greeter interface {
+	Greet() string
+}
+
+type englishGreeter struct{}
+
+func (englishGreeter) Greet() string { return "hello" }
+
+type frenchGreeter struct{}
+
+func (frenchGreeter) Greet() string { return "bonjour" }
+
+type Welcome struct {
+	greeter Greeter
+}
+
+func TestStub(t *testing.T) {
+	var destroyed int
+	injector, err := goinject.NewInjector(
+		goinject.Provide(func() Greeter { return englishGreeter{} }),
+		goinject.Provide(func(g Greeter) *Welcome { return &Welcome{greeter: g} },
+			goinject.WithDestroy(func(_ *Welcome) { destroyed++ })),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	var original *Welcome
+	err = injector.Invoke(ctx, func(w *Welcome) { original = w })
+	assert.Nil(t, err)
+
+	t.Run("stubbed", func(t *testing.T) {
+		Stub[Greeter](t, injector, frenchGreeter{})
+		err := injector.Invoke(ctx, func(g Greeter, w *Welcome) {
+			assert.Equal(t, "bonjour", g.Greet())
+			assert.Equal(t, "bonjour", w.greeter.Greet())
+			assert.NotSame(t, original, w)
+		})
+		assert.Nil(t, err)
+	})
+
+	err = injector.Invoke(ctx, func(g Greeter, w *Welcome) {
+		assert.Equal(t, "hello", g.Greet())
+		assert.Same(t, original, w)
+	})
+	assert.Nil(t, err)
+
+	t.Run("unbound annotation", func(t *testing.T) {
+		Stub[Greeter](t, injector, frenchGreeter{}, "french")
+		err := injector.Invoke(ctx, func(p struct {
+			goinject.Params
+			Greeter Greeter `inject:"french"`
+		}) {
+			assert.Equal(t, "bonjour", p.Greeter.Greet())
+		})
+		assert.Nil(t, err)
+	})
+
+	injector.Shutdown()
+	assert.Equal(t, 2, destroyed)
+}
+
+func TestFakes(t *testing.T) {
+	injector, err := goinject.NewInjector(
+		Fakes(),
+		goinject.Provide(func() Greeter { return englishGreeter{} },
+			goinject.FakeInTests(func() Greeter { return frenchGreeter{} })),
+	)
+	assert.Nil(t, err)
+	err = injector.Invoke(context.Background(), func(g Greeter) {
+		assert.Equal(t, "bonjour", g.Greet())
+	})
+	assert.Nil(t, err)
+}
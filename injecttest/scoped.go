@@ -0,0 +1,62 @@
+package injecttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// ScopeOption configure ScopedTest
+type ScopeOption func(s *scopedTest)
+
+type scopedTest struct {
+	seeds []func(ctx context.Context, injector *goinject.Injector, scopeKey any) error
+}
+
+// WithScoped return a ScopeOption setting value as the instance of the binding of T (annotated with the given
+// annotation, if any) in the scope activated by ScopedTest. T must be bound in that scope.
+func WithScoped[T any](value T, annotation ...string) ScopeOption {
+	return func(s *scopedTest) {
+		s.seeds = append(s.seeds, func(ctx context.Context, injector *goinject.Injector, scopeKey any) error {
+			return injector.SeedContextualScope(ctx, scopeKey, goinject.KeyOf[T](annotation...), value)
+		})
+	}
+}
+
+// ScopedTest run test with a context in which the contextual scope identified by scopeKey (see
+// goinject.NewContextualScope) is enabled and seeded with the WithScoped values. The scope is shut down when test
+// returns, even if it panics or calls t.FailNow, and the test fails if some destroy callbacks of the scope did not
+// run.
+func ScopedTest(
+	t testing.TB,
+	injector *goinject.Injector,
+	scopeKey any,
+	test func(ctx context.Context),
+	opts ...ScopeOption,
+) {
+	t.Helper()
+	s := &scopedTest{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	ctx := goinject.WithContextualScopeEnabled(context.Background(), scopeKey)
+	for _, seed := range s.seeds {
+		if err := seed(ctx, injector, scopeKey); err != nil {
+			t.Fatalf("failed to seed scope: %v", err)
+		}
+	}
+	defer func() {
+		t.Helper()
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("scope shutdown panicked: %v", r)
+			}
+			if pending := goinject.PendingDestroyCallbacks(ctx, scopeKey); pending > 0 {
+				t.Errorf("%d destroy callbacks of the scope did not run", pending)
+			}
+		}()
+		goinject.ShutdownContextualScope(ctx, scopeKey)
+	}()
+	test(ctx)
+}
@@ -0,0 +1,75 @@
+package injecttest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+type requestScopeKey struct{}
+
+type Request struct {
+	ID int
+}
+
+type Session struct {
+	request *Request
+}
+
+// recorder record the errors reported by ScopedTest
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestScopedTest(t *testing.T) {
+	var destroyed []int
+	injector, err := goinject.NewInjector(
+		goinject.RegisterScope("request", goinject.NewContextualScope(requestScopeKey{})),
+		goinject.Provide(func() *Request { return &Request{} }, goinject.In("request")),
+		goinject.Provide(func(r *Request) *Session { return &Session{request: r} }, goinject.In("request"),
+			goinject.WithDestroy(func(s *Session) {
+				destroyed = append(destroyed, s.request.ID)
+			})),
+	)
+	assert.Nil(t, err)
+
+	ScopedTest(t, injector, requestScopeKey{}, func(ctx context.Context) {
+		err := injector.Invoke(ctx, func(s *Session) {
+			assert.Equal(t, 42, s.request.ID)
+		})
+		assert.Nil(t, err)
+	}, WithScoped(&Request{ID: 42}))
+	assert.Equal(t, []int{42}, destroyed)
+
+	t.Run("panicking destroy callback", func(t *testing.T) {
+		injector, err := goinject.NewInjector(
+			goinject.RegisterScope("request", goinject.NewContextualScope(requestScopeKey{})),
+			goinject.Provide(func() *Request { return &Request{} }, goinject.In("request"),
+				goinject.WithDestroy(func(_ *Request) {})),
+			goinject.Provide(func(r *Request) *Session { return &Session{request: r} }, goinject.In("request"),
+				goinject.WithDestroy(func(_ *Session) { panic("cannot close session") })),
+		)
+		assert.Nil(t, err)
+
+		r := &recorder{TB: t}
+		ScopedTest(r, injector, requestScopeKey{}, func(ctx context.Context) {
+			err := injector.Invoke(ctx, func(_ *Session) {})
+			assert.Nil(t, err)
+		})
+		assert.Equal(t, []string{
+			"scope shutdown panicked: cannot close session",
+			"1 destroy callbacks of the scope did not run",
+		}, r.errors)
+	})
+}
@@ -0,0 +1,45 @@
+package goinject
+
+import (
+	"context"
+)
+
+type destroyOnReturnAnnotation struct{}
+
+func (a *destroyOnReturnAnnotation) apply(b *binding) error {
+	b.destroyOnExit = true
+	return nil
+}
+
+// DestroyOnReturn return an annotation making the destroy method (see WithDestroy) of a PerLookUp binding called
+// when the invocation (Invoke, InvokeBestEffort, or each function of InvokeAllFns) creating the instance returns,
+// instead of never, e.g. to close temporary connections. Instances created outside of an invocation (e.g. by a
+// Provider function called with an unrelated context) are still not destroyed. It cannot be used with Coalesced.
+func DestroyOnReturn() Annotation {
+	return &destroyOnReturnAnnotation{}
+}
+
+// checkDestroyOnReturn verify that DestroyOnReturn is only used by PerLookUp bindings having a destroy method, and
+// not with Coalesced since a shared instance would be destroyed by each invocation, while others still use it
+func (b *binding) checkDestroyOnReturn() error {
+	if !b.destroyOnExit {
+		return nil
+	}
+	if b.scope != PerLookUp || b.destroyMethod == nil {
+		return newInjectorConfigurationError("DestroyOnReturn requires a PerLookUp binding declaring WithDestroy", nil)
+	}
+	if b.coalescer != nil {
+		return newInjectorConfigurationError("DestroyOnReturn cannot be used with Coalesced", nil)
+	}
+	return nil
+}
+
+// registerInvocationDestructionCallback register destroyCallback in the registry of the invocation of ctx, if any
+func registerInvocationDestructionCallback(ctx context.Context, destroyCallback func()) {
+	if ctx == nil {
+		return
+	}
+	if registry, ok := ctx.Value(perInvocationScopeKey{}).(*instanceRegistry); ok {
+		registry.registerOrderedDestructionCallback(shutdownOrderOf(ctx), destroyCallback)
+	}
+}
@@ -0,0 +1,74 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TempFile struct {
+	closed bool
+}
+
+func TestDestroyOnReturn(t *testing.T) {
+	var files []*TempFile
+	injector, err := NewInjector(
+		Provide(func() *TempFile {
+			file := &TempFile{}
+			files = append(files, file)
+			return file
+		}, In(PerLookUp), WithDestroy(func(f *TempFile) { f.closed = true }), DestroyOnReturn()),
+	)
+	assert.Nil(t, err)
+
+	err = injector.Invoke(context.Background(), func(first *TempFile, second *TempFile) {
+		assert.False(t, first.closed)
+		assert.False(t, second.closed)
+	})
+	assert.Nil(t, err)
+	if assert.Len(t, files, 2) {
+		assert.True(t, files[0].closed)
+		assert.True(t, files[1].closed)
+	}
+}
+
+func TestDestroyOnReturnShouldNotShareInstancesInBatch(t *testing.T) {
+	var files []*TempFile
+	injector, err := NewInjector(
+		Provide(func() *TempFile {
+			file := &TempFile{}
+			files = append(files, file)
+			return file
+		}, In(PerLookUp), WithDestroy(func(f *TempFile) { f.closed = true }), DestroyOnReturn()),
+	)
+	assert.Nil(t, err)
+
+	err = injector.InvokeAllFns(context.Background(),
+		func(file *TempFile) { assert.False(t, file.closed) },
+		func(file *TempFile) { assert.False(t, file.closed) },
+	)
+	assert.Nil(t, err)
+	if assert.Len(t, files, 2) {
+		assert.True(t, files[0].closed)
+		assert.True(t, files[1].closed)
+	}
+}
+
+func TestDestroyOnReturnShouldRequirePerLookUpWithDestroy(t *testing.T) {
+	for name, annotations := range map[string][]Annotation{
+		"singleton":       {WithDestroy(func(_ *TempFile) {}), DestroyOnReturn()},
+		"without destroy": {In(PerLookUp), DestroyOnReturn()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewInjector(Provide(func() *TempFile { return &TempFile{} }, annotations...))
+			assert.ErrorContains(t, err, "DestroyOnReturn requires a PerLookUp binding")
+		})
+	}
+}
+
+func TestDestroyOnReturnShouldRejectCoalesced(t *testing.T) {
+	_, err := NewInjector(Provide(func() *TempFile { return &TempFile{} },
+		In(PerLookUp), WithDestroy(func(_ *TempFile) {}), DestroyOnReturn(), Coalesced()))
+	assert.ErrorContains(t, err, "DestroyOnReturn cannot be used with Coalesced")
+}
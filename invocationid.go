@@ -0,0 +1,81 @@
+package goinject
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+)
+
+type invocationIDContextKey struct{}
+
+// WithInvocationID return a context making Invoke use id as invocation ID instead of generating one, e.g. the
+// request ID of an HTTP server
+func WithInvocationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, invocationIDContextKey{}, id)
+}
+
+// InvocationIDOf return the ID of the invocation ctx belongs to, e.g. to correlate provider logs (given their
+// InvocationContext) with the error returned by Invoke, see InvocationIDOfError
+func InvocationIDOf(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(invocationIDContextKey{}).(string)
+	return id, ok
+}
+
+// InvocationIDOfError return the ID of the invocation that failed with err, err being returned by Invoke,
+// InvokeAllFns or InvokeBestEffort
+func InvocationIDOfError(err error) (string, bool) {
+	var invocationErr *invocationError
+	if errors.As(err, &invocationErr) {
+		return invocationErr.id, true
+	}
+	return "", false
+}
+
+// withInvocationID return ctx with an invocation ID, generated unless ctx already has one
+func withInvocationID(ctx context.Context) (context.Context, string) {
+	if id, ok := InvocationIDOf(ctx); ok {
+		return ctx, id
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	id := newInvocationID()
+	return WithInvocationID(ctx, id), id
+}
+
+func newInvocationID() string {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// invocationError attach the invocation ID to the errors of an invocation, it does not alter the cause message
+type invocationError struct {
+	id    string
+	cause error
+}
+
+var _ error = &invocationError{}
+
+func newInvocationError(id string, cause error) error {
+	if _, ok := InvocationIDOfError(cause); ok {
+		return cause
+	}
+	return &invocationError{id: id, cause: cause}
+}
+
+func (e *invocationError) Error() string { return e.cause.Error() }
+
+func (e *invocationError) Unwrap() error { return e.cause }
+
+// InvocationFailedEvent is notified when Invoke fails, Err being the returned error
+type InvocationFailedEvent struct {
+	InvocationID string
+	Err          error
+}
+
+func (InvocationFailedEvent) isEvent() {}
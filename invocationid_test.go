@@ -0,0 +1,69 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvocationID(t *testing.T) {
+	providerErr := errors.New("database unavailable")
+	var providerIDs []string
+	injector, err := NewInjector(
+		Provide(func(ctx InvocationContext) (*AppConfig, error) {
+			id, ok := InvocationIDOf(ctx)
+			assert.True(t, ok)
+			providerIDs = append(providerIDs, id)
+			return nil, providerErr
+		}, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(_ *AppConfig) {})
+	assert.ErrorIs(t, err, providerErr)
+	id, ok := InvocationIDOfError(err)
+	assert.True(t, ok)
+	assert.Len(t, id, 16)
+	assert.Equal(t, []string{id}, providerIDs)
+
+	err = injector.Invoke(WithInvocationID(ctx, "request-42"), func(_ *AppConfig) {})
+	id, _ = InvocationIDOfError(err)
+	assert.Equal(t, "request-42", id)
+	assert.Equal(t, "request-42", providerIDs[1])
+
+	warnings, err := injector.InvokeBestEffort(WithInvocationID(ctx, "diagnostic"), func(_ *AppConfig) {})
+	assert.Nil(t, err)
+	assert.Len(t, warnings, 1)
+	id, _ = InvocationIDOfError(warnings[0])
+	assert.Equal(t, "diagnostic", id)
+
+	err = injector.InvokeAllFns(WithInvocationID(ctx, "batch"), func() {}, func(_ *AppConfig) {})
+	id, _ = InvocationIDOfError(err)
+	assert.Equal(t, "batch", id)
+
+	err = injector.Invoke(ctx, func() {})
+	assert.Nil(t, err)
+	_, ok = InvocationIDOfError(errors.New("other"))
+	assert.False(t, ok)
+}
+
+func TestInvocationFailedEvent(t *testing.T) {
+	var events []InvocationFailedEvent
+	injector, err := NewInjector(
+		WithObserver(func(event Event) {
+			if failed, ok := event.(InvocationFailedEvent); ok {
+				events = append(events, failed)
+			}
+		}),
+	)
+	assert.Nil(t, err)
+
+	ctx := WithInvocationID(context.Background(), "request-1")
+	assert.Nil(t, injector.Invoke(ctx, func() {}))
+	err = injector.Invoke(ctx, func(_ *AppConfig) {})
+	assert.NotNil(t, err)
+	assert.Equal(t, []InvocationFailedEvent{{InvocationID: "request-1", Err: err}}, events)
+}
@@ -0,0 +1,118 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ErrResolutionTimeout is matched (using errors.Is) by the errors returned by Invoke when the resolution of the
+// arguments exceeds the WithResolutionTimeout limit
+var ErrResolutionTimeout = errors.New("resolution timed out")
+
+// InvokeOption configure a call of Injector.Invoke
+type InvokeOption func(c *invokeConfig)
+
+type invokeConfig struct {
+	resolutionTimeout time.Duration
+}
+
+// WithResolutionTimeout bound the resolution of the arguments of the invoked function as a whole: when it takes
+// longer than timeout, Invoke returns without waiting for the providers being called (e.g. a stuck lazy singleton
+// provider), with an error wrapping ErrResolutionTimeout and naming the binding under construction at expiry (its
+// resolution path is attached to the error). The invoked function itself is not bounded.
+func WithResolutionTimeout(timeout time.Duration) InvokeOption {
+	return func(c *invokeConfig) {
+		c.resolutionTimeout = timeout
+	}
+}
+
+// resolutionProgress track the innermost binding being resolved by an invocation
+type resolutionProgress struct {
+	mu   sync.Mutex
+	path ResolutionPath
+}
+
+type resolutionProgressContextKey struct{}
+
+// enter set the path of the binding being resolved, until the returned function is called
+func (p *resolutionProgress) enter(path ResolutionPath) func() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	previous := p.path
+	p.path = path
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.path = previous
+	}
+}
+
+func (p *resolutionProgress) current() ResolutionPath {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.path
+}
+
+// trackResolutionProgress record that the binding at the end of path is being resolved, if the resolution of ctx
+// is bounded, and return the function to call once it is resolved
+func trackResolutionProgress(ctx context.Context, path ResolutionPath) func() {
+	if ctx == nil {
+		return func() {}
+	}
+	if progress, ok := ctx.Value(resolutionProgressContextKey{}).(*resolutionProgress); ok {
+		return progress.enter(path)
+	}
+	return func() {}
+}
+
+// callFunctionWithResolutionTimeout is like callFunctionWithArgumentInstance but stop waiting for the resolution of
+// the arguments after timeout
+func (injector *Injector) callFunctionWithResolutionTimeout(
+	ctx context.Context,
+	fValue reflect.Value,
+	target reflect.Type,
+	timeout time.Duration,
+) ([]reflect.Value, error) {
+	progress := &resolutionProgress{}
+	ctx, cancel := context.WithCancelCause(context.WithValue(ctx, resolutionProgressContextKey{}, progress))
+	defer cancel(nil)
+	timer := time.AfterFunc(timeout, func() { cancel(ErrResolutionTimeout) })
+	defer timer.Stop()
+
+	var in []reflect.Value
+	var err error
+	resolved := make(chan struct{})
+	go func() {
+		defer close(resolved)
+		in, err = injector.resolveFunctionArguments(ctx, fValue, target)
+	}()
+	select {
+	case <-resolved:
+	case <-ctx.Done():
+		select {
+		case <-resolved: // resolved concurrently with the expiry
+		default:
+			if !errors.Is(context.Cause(ctx), ErrResolutionTimeout) {
+				return []reflect.Value{}, checkResolutionContext(ctx)
+			}
+			path := progress.current()
+			if len(path) == 0 {
+				return []reflect.Value{}, fmt.Errorf("%w after %s", ErrResolutionTimeout, timeout)
+			}
+			return []reflect.Value{}, newResolutionPathError(path, fmt.Errorf(
+				"%w after %s while resolving %s (resolution path: %s)", ErrResolutionTimeout, timeout, path[len(path)-1], path))
+		}
+	}
+	if !timer.Stop() && errors.Is(context.Cause(ctx), ErrResolutionTimeout) && err == nil {
+		// the timer fired after the resolution, the invoked function must not see an expired context
+		return []reflect.Value{}, fmt.Errorf("%w after %s", ErrResolutionTimeout, timeout)
+	}
+	if err != nil {
+		return []reflect.Value{}, err
+	}
+	return fValue.Call(in), nil
+}
@@ -0,0 +1,44 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvokeWithResolutionTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	injector, err := NewInjector(
+		WarmUp(Type[*AppConfig]()),
+		Provide(func() *AppConfig { return &AppConfig{} }),
+		Provide(func(_ *Child) *Parent { return &Parent{} }),
+		Provide(func() *Child {
+			<-unblock
+			return &Child{}
+		}),
+	)
+	assert.Nil(t, err)
+
+	called := false
+	err = injector.Invoke(context.Background(), func(_ *AppConfig, _ *Parent) { called = true },
+		WithResolutionTimeout(20*time.Millisecond))
+	assert.ErrorIs(t, err, ErrResolutionTimeout)
+	assert.ErrorContains(t, err, "while resolving *goinject.Child")
+	assert.Equal(t, ResolutionPath{KeyOf[*Parent](), KeyOf[*Child]()}, resolutionPathOf(err))
+	assert.False(t, called)
+}
+
+func TestInvokeWithResolutionTimeoutShouldNotBoundInvokedFunction(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func() *AppConfig { return &AppConfig{} }),
+	)
+	assert.Nil(t, err)
+	err = injector.Invoke(context.Background(), func(ctx InvocationContext, _ *AppConfig) error {
+		time.Sleep(40 * time.Millisecond)
+		return ctx.Err()
+	}, WithResolutionTimeout(20*time.Millisecond))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,97 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Lazy[T] create the instance of a binding declaring a LazyProxy on first use
+type Lazy[T any] struct {
+	mu       sync.Mutex
+	injector *Injector
+	ctx      context.Context
+	binding  *binding
+	instance T
+	created  bool
+}
+
+// Get return the instance of the binding, created (within the binding scope, against the context of the injection)
+// on the first call. Creation errors are returned, the next call trying again.
+func (l *Lazy[T]) Get() (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.created {
+		return l.instance, nil
+	}
+	if l.injector == nil {
+		return l.instance, errors.New("Lazy was not created by the injector")
+	}
+	instance, err := l.injector.getScopedInstanceFromBinding(l.ctx, l.binding)
+	if err != nil {
+		return l.instance, err
+	}
+	if instance.IsValid() {
+		l.instance, _ = instance.Convert(l.binding.typeof).Interface().(T)
+	}
+	l.created = true
+	return l.instance, nil
+}
+
+// MustGet is like Get but panics if the instance cannot be created, for proxied methods that do not return errors
+func (l *Lazy[T]) MustGet() T {
+	instance, err := l.Get()
+	if err != nil {
+		panic(fmt.Errorf("failed to create lazy instance of %s: %w", l.binding.key(), err))
+	}
+	return instance
+}
+
+// lazyProxy create the value injected in place of the instance of a binding, see LazyProxy
+type lazyProxy struct {
+	elemType reflect.Type
+	create   func(injector *Injector, ctx context.Context, b *binding) reflect.Value
+}
+
+type lazyProxyAnnotation struct {
+	proxy *lazyProxy
+}
+
+func (a *lazyProxyAnnotation) apply(b *binding) error {
+	b.lazyProxy = a.proxy
+	return nil
+}
+
+// LazyProxy return an annotation declaring the lazy proxy of an interface binding: a function taking a *Lazy[T] and
+// returning the value injected in place of the instance, usually a thin facade implementing T whose methods call
+// Lazy.Get (or Lazy.MustGet) then the method of the instance. The instance is created on the first method call,
+// letting heavy optional integrations cost nothing until used without changing consumers to Provider[T]. Like the
+// proxies of WithProxy, the facade must be written (or generated) by hand.
+// Singletons with a lazy proxy are not created eagerly. Instances of contextual scopes must be used while the scope
+// of the injection is active.
+func LazyProxy[T any](proxy func(lazy *Lazy[T]) T) Annotation {
+	return &lazyProxyAnnotation{proxy: &lazyProxy{
+		elemType: reflect.TypeFor[T](),
+		create: func(injector *Injector, ctx context.Context, b *binding) reflect.Value {
+			lazy := &Lazy[T]{injector: injector, ctx: context.WithoutCancel(withNewResolutionPath(ctx)), binding: b}
+			return reflect.ValueOf(proxy(lazy)).Convert(b.typeof)
+		},
+	}}
+}
+
+// checkLazyProxy verify that the lazy proxy (if any) is declared for an interface binding without proxy
+func (b *binding) checkLazyProxy() error {
+	if b.lazyProxy == nil {
+		return nil
+	}
+	if b.typeof.Kind() != reflect.Interface || b.lazyProxy.elemType != b.typeof {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("LazyProxy must take and return the binding interface type, got %s", b.lazyProxy.elemType), nil)
+	}
+	if b.proxy.IsValid() {
+		return newInjectorConfigurationError("LazyProxy cannot be combined with WithProxy", nil)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Geocoder interface {
+	Geocode(address string) ([]string, error)
+}
+
+type heavyGeocoder struct{}
+
+func (i *heavyGeocoder) Geocode(address string) ([]string, error) {
+	return []string{address}, nil
+}
+
+// lazyGeocoder is the facade of Geocoder creating the geocoder on first call
+type lazyGeocoder struct {
+	lazy *Lazy[Geocoder]
+}
+
+func (i *lazyGeocoder) Geocode(address string) ([]string, error) {
+	geocoder, err := i.lazy.Get()
+	if err != nil {
+		return nil, err
+	}
+	return geocoder.Geocode(address)
+}
+
+func TestLazyProxy(t *testing.T) {
+	created := 0
+	injector, err := NewInjector(
+		Provide(func() *heavyGeocoder {
+			created++
+			return &heavyGeocoder{}
+		}, As(Type[Geocoder]()), LazyProxy(func(lazy *Lazy[Geocoder]) Geocoder {
+			return &lazyGeocoder{lazy: lazy}
+		})),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, created)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(_ Geocoder) {})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, created)
+	for i := 0; i < 2; i++ {
+		err = injector.Invoke(ctx, func(geocoder Geocoder) {
+			results, err := geocoder.Geocode("go")
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"go"}, results)
+		})
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 1, created)
+}
+
+func TestLazyProxyShouldRequireInterfaceBinding(t *testing.T) {
+	_, err := NewInjector(
+		Provide(func() *heavyGeocoder { return &heavyGeocoder{} },
+			LazyProxy(func(lazy *Lazy[Geocoder]) Geocoder { return &lazyGeocoder{lazy: lazy} })),
+	)
+	assert.ErrorContains(t, err, "LazyProxy must take and return the binding interface type")
+}
@@ -0,0 +1,298 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// DefaultPhase is the phase of lifecycle hooks of bindings without Phase annotation, it is started before the
+// phases declared with WithPhases
+const DefaultPhase = ""
+
+// lifecycleHook is a start or stop hook of a binding
+type lifecycleHook func(ctx context.Context, instance reflect.Value) error
+
+type lifecycleHookAnnotation struct {
+	name string // annotation name used in errors
+	hook any
+	set  func(b *binding, hook lifecycleHook)
+}
+
+func (a *lifecycleHookAnnotation) apply(b *binding) error {
+	hookFnVal := reflect.ValueOf(a.hook)
+	if hookFnVal.Kind() != reflect.Func ||
+		hookFnVal.Type().NumIn() != 2 ||
+		hookFnVal.Type().In(0) != contextReflectType ||
+		hookFnVal.Type().In(1) != b.providedType ||
+		hookFnVal.Type().NumOut() != 1 ||
+		hookFnVal.Type().Out(0) != errorReflectType {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("argument of %s must be a function with a context and the provided type as arguments "+
+				"returning an error", a.name),
+			nil,
+		)
+	}
+	a.set(b, func(ctx context.Context, instance reflect.Value) error {
+		err, _ := hookFnVal.Call([]reflect.Value{reflect.ValueOf(ctx), instance})[0].Interface().(error)
+		return err
+	})
+	return nil
+}
+
+// OnStart return an annotation that declare a hook (a function taking a context.Context and the provided type,
+// returning an error) called by Injector.Start. Only singleton bindings can have lifecycle hooks (including
+// readiness checks).
+func OnStart(hook any) Annotation {
+	return &lifecycleHookAnnotation{name: "OnStart", hook: hook, set: func(b *binding, hook lifecycleHook) {
+		b.onStart = hook
+	}}
+}
+
+// OnStop return an annotation that declare a hook (a function taking a context.Context and the provided type,
+// returning an error) called by Injector.Stop if the binding was started.
+func OnStop(hook any) Annotation {
+	return &lifecycleHookAnnotation{name: "OnStop", hook: hook, set: func(b *binding, hook lifecycleHook) {
+		b.onStop = hook
+	}}
+}
+
+type phaseAnnotation struct {
+	phase string
+}
+
+func (a *phaseAnnotation) apply(b *binding) error {
+	b.phase = a.phase
+	return nil
+}
+
+// Phase return an annotation that set the lifecycle phase of the binding hooks, the phase must be declared with
+// WithPhases
+func Phase(phase string) Annotation {
+	return &phaseAnnotation{phase: phase}
+}
+
+type phasesOption struct {
+	phases []string
+}
+
+func (o *phasesOption) apply(mod *configuration) error {
+	mod.phases = append(mod.phases, o.phases...)
+	return nil
+}
+
+// WithPhases declare lifecycle phases, in start order. Injector.Start run start hooks phase by phase (beginning
+// with DefaultPhase), and Injector.Stop run stop hooks in reverse phase order. Within a phase, hooks run in
+// dependency order on start (dependencies first) and in reverse order on stop.
+func WithPhases(phases ...string) InjectorOption {
+	return newInjectorOption("WithPhases", &phasesOption{phases: phases})
+}
+
+// lifecycle hold the state of lifecycle hooks of an injector
+type lifecycle struct {
+	phases []string
+
+	mu           sync.Mutex
+	created      []*binding                 // bindings with hooks, in creation (thus dependency) order
+	started      []*binding                 // bindings whose start hook ran (or without start hook), in start order
+	startSucceed bool                       // true if the last Start call succeeded and Stop was not called since
+	retired      map[*binding]reflect.Value // started bindings removed from the injector, by instance to stop
+}
+
+// newLifecycle validate lifecycle declarations of the configuration, it returns all the problems found
+func newLifecycle(mod *configuration) (*lifecycle, []error) {
+	var errs []error
+	phases := append([]string{DefaultPhase}, mod.phases...)
+	known := make(map[string]bool, len(phases))
+	for _, p := range phases {
+		if known[p] {
+			errs = append(errs, newConfigurationProblemError(InvalidLifecycle, "", nil, "",
+				newInjectorConfigurationError(fmt.Sprintf("lifecycle phase %q is declared twice", p), nil)))
+		}
+		known[p] = true
+	}
+	for _, b := range mod.orderedBindings() {
+		if b.onStart == nil && b.onStop == nil && b.readinessCheck == nil {
+			continue
+		}
+		if b.scope != Singleton {
+			errs = append(errs, newConfigurationProblemError(InvalidLifecycle, b.key().String(), b.modules, b.location,
+				newInjectorConfigurationError(fmt.Sprintf("binding %s has lifecycle hooks but is not a singleton", b.key()), nil)))
+		} else if !known[b.phase] {
+			errs = append(errs, newConfigurationProblemError(InvalidLifecycle, b.key().String(), b.modules, b.location,
+				newInjectorConfigurationError(fmt.Sprintf("binding %s use undeclared lifecycle phase %q", b.key(), b.phase), nil)))
+		}
+	}
+	return &lifecycle{phases: phases}, errs
+}
+
+func (l *lifecycle) recordCreated(b *binding) {
+	if b.onStart == nil && b.onStop == nil && b.readinessCheck == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, c := range l.created {
+		if c == b { // refreshed singleton
+			return
+		}
+	}
+	l.created = append(l.created, b)
+}
+
+// forget remove a binding from created and started bindings, its hooks will not be run anymore
+func (l *lifecycle) forget(b *binding) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.created = slices.DeleteFunc(l.created, func(c *binding) bool { return c == b })
+	l.started = slices.DeleteFunc(l.started, func(s *binding) bool { return s == b })
+}
+
+// retire remove a binding removed from the injector from created bindings. If it was started, its stop hook is run
+// with instance by the next Stop, which then destroys the instance.
+func (l *lifecycle) retire(b *binding, instance reflect.Value) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.created = slices.DeleteFunc(l.created, func(c *binding) bool { return c == b })
+	if b.onStop == nil || !instance.IsValid() || !slices.Contains(l.started, b) {
+		l.started = slices.DeleteFunc(l.started, func(s *binding) bool { return s == b })
+		return
+	}
+	if l.retired == nil {
+		l.retired = make(map[*binding]reflect.Value)
+	}
+	l.retired[b] = instance
+}
+
+// isRetired return true if the stop hook of the retired binding b was not run yet
+func (l *lifecycle) isRetired(b *binding) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, retired := l.retired[b]
+	return retired
+}
+
+// stopBinding run the stop hook of the started binding b. The instance of a retired binding is destroyed once
+// stopped.
+func (injector *Injector) stopBinding(ctx context.Context, b *binding) error {
+	injector.lifecycle.mu.Lock()
+	instance, retired := injector.lifecycle.retired[b]
+	delete(injector.lifecycle.retired, b)
+	injector.lifecycle.mu.Unlock()
+	var err error
+	if retired {
+		err = b.onStop(ctx, instance)
+		b.destroySingleton()
+	} else if instance, err = injector.getScopedInstanceFromBinding(ctx, b); err == nil {
+		err = b.onStop(ctx, instance)
+	}
+	if err != nil {
+		return fmt.Errorf("stop hook of binding %s returned error: %w", b.key(), err)
+	}
+	return nil
+}
+
+// Start run start hooks of singletons phase by phase. It stops at the first failing hook, already started bindings
+// are not stopped. Bindings already started are skipped, so Start can be called again after a failure.
+// Singletons created once the injector is started run their start hook when they are created, a failing hook
+// failing their resolution.
+func (injector *Injector) Start(ctx context.Context) (err error) {
+	endStart, err := injector.beginStart("Start")
+	if err != nil {
+		return err
+	}
+	defer func() { endStart(err) }()
+	for _, phase := range injector.lifecycle.phases {
+		injector.lifecycle.mu.Lock()
+		created := append([]*binding(nil), injector.lifecycle.created...)
+		injector.lifecycle.mu.Unlock()
+
+		for _, b := range created {
+			if b.phase != phase || injector.isStarted(b) {
+				continue
+			}
+			if err := injector.startBinding(ctx, b); err != nil {
+				return decorateError(injector.errorDecorators, err)
+			}
+		}
+	}
+	injector.lifecycle.mu.Lock()
+	defer injector.lifecycle.mu.Unlock()
+	injector.lifecycle.startSucceed = true
+	return nil
+}
+
+func (injector *Injector) isStarted(b *binding) bool {
+	injector.lifecycle.mu.Lock()
+	defer injector.lifecycle.mu.Unlock()
+	for _, started := range injector.lifecycle.started {
+		if started == b {
+			return true
+		}
+	}
+	return false
+}
+
+func (injector *Injector) startBinding(ctx context.Context, b *binding) error {
+	if b.onStart != nil {
+		instance, err := injector.getScopedInstanceFromBinding(ctx, b)
+		if err != nil {
+			return fmt.Errorf("failed to get instance to start: %w", err)
+		}
+		if err = b.onStart(ctx, instance); err != nil {
+			return fmt.Errorf("start hook of binding %s returned error: %w", b.key(), err)
+		}
+	}
+	injector.lifecycle.mu.Lock()
+	defer injector.lifecycle.mu.Unlock()
+	injector.lifecycle.started = append(injector.lifecycle.started, b)
+	return nil
+}
+
+// startCreated run the start hook of a singleton created once the injector is started, so that singletons created
+// lazily are started (and stopped by Stop) like the ones started by Start
+func (injector *Injector) startCreated(ctx context.Context, b *binding, instance reflect.Value) error {
+	if (b.onStart == nil && b.onStop == nil) || injector.State() != InjectorStarted || injector.isStarted(b) {
+		return nil
+	}
+	if b.onStart != nil {
+		if err := b.onStart(ctx, instance); err != nil {
+			return fmt.Errorf("start hook of binding %s returned error: %w", b.key(), err)
+		}
+	}
+	injector.lifecycle.mu.Lock()
+	defer injector.lifecycle.mu.Unlock()
+	injector.lifecycle.started = append(injector.lifecycle.started, b)
+	return nil
+}
+
+// Stop run stop hooks of started bindings, phase by phase in reverse order. All hooks are run even if some of them
+// fail, the returned error joins their errors.
+func (injector *Injector) Stop(ctx context.Context) error {
+	if err := injector.state.transition("Stop", InjectorStopping, InjectorConfiguring, InjectorStarted); err != nil {
+		return err
+	}
+	defer injector.state.set(InjectorStopped)
+	injector.lifecycle.mu.Lock()
+	started := injector.lifecycle.started
+	injector.lifecycle.started = nil
+	injector.lifecycle.startSucceed = false
+	injector.lifecycle.mu.Unlock()
+
+	var errs []error
+	for p := len(injector.lifecycle.phases) - 1; p >= 0; p-- {
+		for i := len(started) - 1; i >= 0; i-- {
+			b := started[i]
+			if b.phase != injector.lifecycle.phases[p] || b.onStop == nil {
+				continue
+			}
+			if err := injector.stopBinding(ctx, b); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return decorateError(injector.errorDecorators, errors.Join(errs...))
+}
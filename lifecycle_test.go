@@ -0,0 +1,148 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Database struct{}
+
+type Cache struct {
+	db *Database
+}
+
+type HTTPServer struct {
+	cache *Cache
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Run("Should run hooks by phase and dependency order", func(t *testing.T) {
+		var events []string
+		injector, err := NewInjector(
+			WithPhases("infrastructure", "servers"),
+			Provide(func(c *Cache) *HTTPServer { return &HTTPServer{cache: c} }, Phase("servers"),
+				OnStart(func(_ context.Context, _ *HTTPServer) error {
+					events = append(events, "start server")
+					return nil
+				}),
+				OnStop(func(_ context.Context, _ *HTTPServer) error {
+					events = append(events, "stop server")
+					return nil
+				})),
+			Provide(func(db *Database) *Cache { return &Cache{db: db} }, Phase("infrastructure"),
+				OnStart(func(_ context.Context, _ *Cache) error {
+					events = append(events, "start cache")
+					return nil
+				}),
+				OnStop(func(_ context.Context, _ *Cache) error {
+					events = append(events, "stop cache")
+					return nil
+				})),
+			Provide(func() *Database { return &Database{} }, Phase("infrastructure"),
+				OnStart(func(_ context.Context, _ *Database) error {
+					events = append(events, "start db")
+					return nil
+				}),
+				OnStop(func(_ context.Context, _ *Database) error {
+					events = append(events, "stop db")
+					return nil
+				})),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		assert.Nil(t, injector.Start(ctx))
+		assert.Nil(t, injector.Stop(ctx))
+		assert.Equal(t, []string{
+			"start db", "start cache", "start server",
+			"stop server", "stop cache", "stop db",
+		}, events)
+	})
+
+	t.Run("Should stop at first failing start hook and only stop started bindings", func(t *testing.T) {
+		startErr := errors.New("port already in use")
+		var events []string
+		injector, err := NewInjector(
+			WithPhases("servers"),
+			Provide(func() *Database { return &Database{} },
+				OnStop(func(_ context.Context, _ *Database) error {
+					events = append(events, "stop db")
+					return nil
+				})),
+			Provide(func() *HTTPServer { return &HTTPServer{} }, Phase("servers"),
+				OnStart(func(_ context.Context, _ *HTTPServer) error {
+					return startErr
+				}),
+				OnStop(func(_ context.Context, _ *HTTPServer) error {
+					events = append(events, "stop server")
+					return nil
+				})),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		err = injector.Start(ctx)
+		assert.ErrorIs(t, err, startErr)
+		assert.ErrorContains(t, err, "start hook of binding *goinject.HTTPServer returned error")
+		assert.Nil(t, injector.Stop(ctx))
+		assert.Equal(t, []string{"stop db"}, events)
+	})
+
+	t.Run("Should start singletons created after Start", func(t *testing.T) {
+		var events []string
+		startErr := errors.New("port already in use")
+		injector, err := NewInjector(
+			WarmUp(Type[*Database]()),
+			Provide(func() *Database { return &Database{} }),
+			Provide(func() *Cache { return &Cache{} },
+				OnStart(func(_ context.Context, _ *Cache) error {
+					events = append(events, "start cache")
+					return nil
+				}),
+				OnStop(func(_ context.Context, _ *Cache) error {
+					events = append(events, "stop cache")
+					return nil
+				})),
+			Provide(func() *HTTPServer { return &HTTPServer{} },
+				OnStart(func(_ context.Context, _ *HTTPServer) error {
+					return startErr
+				})),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		assert.Nil(t, injector.Start(ctx))
+		assert.Empty(t, events)
+
+		assert.Nil(t, injector.Invoke(ctx, func(_ *Cache) {}))
+		assert.Nil(t, injector.Invoke(ctx, func(_ *Cache) {}))
+		assert.Equal(t, []string{"start cache"}, events)
+		err = injector.Invoke(ctx, func(_ *HTTPServer) {})
+		assert.ErrorIs(t, err, startErr)
+
+		assert.Nil(t, injector.Stop(ctx))
+		assert.Equal(t, []string{"start cache", "stop cache"}, events)
+	})
+
+	t.Run("Should reject invalid configuration", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *Database { return &Database{} }, Phase("unknown"),
+				OnStart(func(_ context.Context, _ *Database) error { return nil })),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.Equal(t, "binding *goinject.Database use undeclared lifecycle phase \"unknown\"", err.Error())
+
+		_, err = NewInjector(
+			Provide(func() *Database { return &Database{} }, In(PerLookUp),
+				OnStart(func(_ context.Context, _ *Database) error { return nil })),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.Equal(t, "binding *goinject.Database has lifecycle hooks but is not a singleton", err.Error())
+
+		_, err = NewInjector(
+			Provide(func() *Database { return &Database{} }, OnStart(func(_ *Database) {})),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.Contains(t, err.Error(), "argument of OnStart must be a function with a context and the provided type")
+	})
+}
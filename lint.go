@@ -0,0 +1,122 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// LintViolation is the kind of problems raised by lint rules, see WithLintRules
+const LintViolation ProblemKind = "lint_violation"
+
+// LintBinding describe a configured binding to lint rules
+type LintBinding struct {
+	Key          BindingKey
+	Scope        string
+	ProvidedType reflect.Type
+	Modules      []string // names of the modules that installed the binding, outermost first
+	Location     string   // source location of the Provide call
+}
+
+// LintRule is a wiring convention checked on each binding when the injector is created, see WithLintRules
+type LintRule interface {
+	// Name identify the rule in problem messages
+	Name() string
+	// Check return an error describing the violation of the rule by the binding, nil if it complies
+	Check(b LintBinding) error
+}
+
+type lintRuleFunc struct {
+	name  string
+	check func(b LintBinding) error
+}
+
+func (r *lintRuleFunc) Name() string { return r.name }
+
+func (r *lintRuleFunc) Check(b LintBinding) error { return r.check(b) }
+
+// LintRuleFunc return a LintRule with the given name checking bindings with check
+func LintRuleFunc(name string, check func(b LintBinding) error) LintRule {
+	return &lintRuleFunc{name: name, check: check}
+}
+
+// ForbidScope return a LintRule forbidding bindings of type target in scope, unless they are installed by one of
+// the allowed modules (e.g. no PerLookUp *sql.DB outside of the database module)
+func ForbidScope(scope string, target AsType, allowedModules ...string) LintRule {
+	t := target.getType()
+	return LintRuleFunc("forbid-scope", func(b LintBinding) error {
+		if b.Key.Type != t || b.Scope != scope || slices.ContainsFunc(b.Modules, func(module string) bool {
+			return slices.Contains(allowedModules, module)
+		}) {
+			return nil
+		}
+		return fmt.Errorf("bindings of type %s must not be in scope %q", t, scope)
+	})
+}
+
+// RequireModule return a LintRule requiring bindings to be installed by a module rather than given directly to
+// NewInjector
+func RequireModule() LintRule {
+	return LintRuleFunc("require-module", func(b LintBinding) error {
+		if len(b.Modules) > 0 {
+			return nil
+		}
+		return fmt.Errorf("binding must be installed by a module")
+	})
+}
+
+// RequireAnnotatedInterfaces return a LintRule requiring interface bindings to be annotated (see Named), so that
+// alternative implementations can be added without making injections ambiguous
+func RequireAnnotatedInterfaces() LintRule {
+	return LintRuleFunc("require-annotated-interfaces", func(b LintBinding) error {
+		if b.Key.Type.Kind() != reflect.Interface || b.Key.Annotation != "" {
+			return nil
+		}
+		return fmt.Errorf("interface binding must be annotated")
+	})
+}
+
+type lintRulesOption struct {
+	rules []LintRule
+}
+
+func (o *lintRulesOption) apply(mod *configuration) error {
+	mod.lintRules = append(mod.lintRules, o.rules...)
+	return nil
+}
+
+// WithLintRules return an InjectorOption checking every binding against the given rules when the injector is created:
+// each violation is reported as a Problem of kind LintViolation in the ConfigurationReport returned by
+// NewInjector. It gives platform teams a way to enforce wiring conventions in code.
+func WithLintRules(rules ...LintRule) InjectorOption {
+	return newInjectorOption("WithLintRules", &lintRulesOption{rules: rules})
+}
+
+// lint return the violations of the lint rules, ordered by binding registration
+func (mod *configuration) lint() []error {
+	if len(mod.lintRules) == 0 {
+		return nil
+	}
+	bindings := mod.orderedBindings()
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].order < bindings[j].order
+	})
+	var errs []error
+	for _, b := range bindings {
+		lintBinding := LintBinding{
+			Key:          b.key(),
+			Scope:        b.scope,
+			ProvidedType: b.providedType,
+			Modules:      b.modules,
+			Location:     b.location,
+		}
+		for _, rule := range mod.lintRules {
+			if err := rule.Check(lintBinding); err != nil {
+				errs = append(errs, newConfigurationProblemError(LintViolation, b.key().String(), b.modules,
+					b.location, fmt.Errorf("lint rule %s: %w", rule.Name(), err)))
+			}
+		}
+	}
+	return errs
+}
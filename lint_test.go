@@ -0,0 +1,56 @@
+package goinject
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintRules(t *testing.T) {
+	_, err := NewInjector(
+		WithLintRules(
+			ForbidScope(PerLookUp, Type[*AppConfig](), "config"),
+			RequireModule(),
+			RequireAnnotatedInterfaces(),
+		),
+		Module("config",
+			Provide(func() *AppConfig { return &AppConfig{} }, In(PerLookUp)),
+		),
+		Module("app",
+			Provide(func() *AppConfig { return &AppConfig{} }, In(PerLookUp), Named("app")),
+			Provide(func() *flakyInventory { return &flakyInventory{} }, As(Type[Inventory]())),
+		),
+		Provide(func() *Parent { return &Parent{} }),
+	)
+	var report *ConfigurationReport
+	assert.ErrorAs(t, err, &report)
+	assert.Len(t, report.Problems, 3)
+	assert.Equal(t, LintViolation, report.Problems[0].Kind)
+	assert.Equal(t, `*goinject.AppConfig("app")`, report.Problems[0].Binding)
+	assert.Equal(t, "app", report.Problems[0].Module)
+	assert.Contains(t, report.Problems[0].Location, "lint_test.go")
+	assert.Equal(t, `lint rule forbid-scope: bindings of type *goinject.AppConfig must not be in scope "inject.PerLookUp"`,
+		report.Problems[0].Message)
+	assert.Equal(t, "goinject.Inventory", report.Problems[1].Binding)
+	assert.Equal(t, "lint rule require-annotated-interfaces: interface binding must be annotated",
+		report.Problems[1].Message)
+	assert.Equal(t, "*goinject.Parent", report.Problems[2].Binding)
+	assert.Equal(t, "lint rule require-module: binding must be installed by a module", report.Problems[2].Message)
+
+	t.Run("custom rule", func(t *testing.T) {
+		errNoSingleton := errors.New("singletons are forbidden")
+		_, err := NewInjector(
+			WithLintRules(LintRuleFunc("no-singleton", func(b LintBinding) error {
+				if b.Scope == Singleton {
+					return errNoSingleton
+				}
+				return nil
+			})),
+			Provide(func() *Parent { return &Parent{} }, In(PerLookUp)),
+			Provide(func() *Child { return &Child{} }),
+		)
+		assert.ErrorIs(t, err, errNoSingleton)
+		assert.ErrorContains(t, err, "lint rule no-singleton: singletons are forbidden")
+	})
+}
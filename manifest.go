@@ -0,0 +1,68 @@
+package goinject
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// manifestPrefix prefix manifests with the hash algorithm and the Graph format version, so that a manifest computed
+// by an incompatible version never matches
+var manifestPrefix = fmt.Sprintf("sha256-v%d:", graphFormatVersion)
+
+// ErrManifestMismatch is matched (using errors.Is) by the error returned by NewInjector when the manifest of the
+// bindings differs from the one expected by VerifyManifest
+var ErrManifestMismatch = errors.New("binding manifest mismatch")
+
+type verifyManifestOption struct {
+	expected string
+}
+
+func (o *verifyManifestOption) apply(mod *configuration) error {
+	mod.expectedManifest = o.expected
+	return nil
+}
+
+// VerifyManifest return an InjectorOption failing NewInjector, before any singleton is created, if the manifest of
+// the bindings (see Injector.Manifest) differs from expected. Given within When, it checks the wiring of each
+// environment against its checked-in manifest, guarding against accidental drift in production:
+//
+//	When(OnEnvironmentVariable("ENV", "production", false), VerifyManifest(productionManifest))
+//
+// The manifest is a hash, Injector.Graph and Injector.CheckCompatibility tell which bindings differ.
+func VerifyManifest(expected string) InjectorOption {
+	return newInjectorOption("VerifyManifest", &verifyManifestOption{expected: expected})
+}
+
+// Manifest return a hash of the effective bindings of the injector, once conditionals, variants, fakes and
+// overrides are applied: their keys, scopes, provided types, dependencies and toggles, as exported by Graph.
+// It does not depend on the registration order of the bindings.
+func (injector *Injector) Manifest() string {
+	bindings := injector.Graph().Bindings
+	entries := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		entry, _ := json.Marshal(b) // GraphBinding only holds strings, it cannot fail
+		entries = append(entries, string(entry))
+	}
+	sort.Strings(entries) // bindings of the same key and scope are not sorted by Graph
+	hash := sha256.New()
+	for _, entry := range entries {
+		hash.Write([]byte(entry))
+		hash.Write([]byte{'\n'})
+	}
+	return manifestPrefix + hex.EncodeToString(hash.Sum(nil))
+}
+
+// verifyManifest return an error wrapping ErrManifestMismatch if the manifest of the injector is not expected
+func (injector *Injector) verifyManifest(expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if actual := injector.Manifest(); actual != expected {
+		return fmt.Errorf("%w: manifest of the bindings is %s, expected %s", ErrManifestMismatch, actual, expected)
+	}
+	return nil
+}
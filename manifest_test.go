@@ -0,0 +1,67 @@
+package goinject
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifest(t *testing.T) {
+	options := []Option{
+		Provide(func() *Color { return &Color{} }, IntoGroup("colors")),
+		Provide(func() *Color { return &Color{} }, IntoGroup("colors"), In(PerLookUp)),
+		Provide(func() *Child { return &Child{} }),
+		Provide(func(_ *Child) *Parent { return &Parent{} }),
+	}
+	injector, err := NewInjector(options...)
+	assert.Nil(t, err)
+	manifest := injector.Manifest()
+	assert.True(t, strings.HasPrefix(manifest, "sha256-v1:"))
+
+	t.Run("Should not depend on registration order", func(t *testing.T) {
+		reversed, err := NewInjector(options[3], options[2], options[1], options[0])
+		assert.Nil(t, err)
+		assert.Equal(t, manifest, reversed.Manifest())
+	})
+
+	t.Run("Should change with the bindings", func(t *testing.T) {
+		other, err := NewInjector(append(options, Provide(func() *Square { return &Square{} }))...)
+		assert.Nil(t, err)
+		assert.NotEqual(t, manifest, other.Manifest())
+
+		rescoped, err := NewInjector(options[0], options[1], options[2],
+			Provide(func(_ *Child) *Parent { return &Parent{} }, In(PerLookUp)))
+		assert.Nil(t, err)
+		assert.NotEqual(t, manifest, rescoped.Manifest())
+	})
+
+	t.Run("Should accept the expected manifest", func(t *testing.T) {
+		_, err := NewInjector(append(options, VerifyManifest(manifest))...)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should fail on a different manifest before creating singletons", func(t *testing.T) {
+		created := false
+		_, err := NewInjector(
+			Provide(func() *Child {
+				created = true
+				return &Child{}
+			}),
+			VerifyManifest(manifest),
+		)
+		assert.ErrorIs(t, err, ErrManifestMismatch)
+		assert.ErrorContains(t, err, "expected "+manifest)
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.False(t, created)
+	})
+
+	t.Run("Should verify the manifest of the enabled environment", func(t *testing.T) {
+		t.Setenv("GOINJECT_MANIFEST_ENV", "staging")
+		_, err := NewInjector(append(options,
+			When(OnEnvironmentVariable("GOINJECT_MANIFEST_ENV", "production", false), VerifyManifest("sha256-v1:0")),
+			When(OnEnvironmentVariable("GOINJECT_MANIFEST_ENV", "staging", false), VerifyManifest(manifest)),
+		)...)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,184 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ParamKind tell how the injector resolves a parameter of a function (or a field of a Params parameter)
+type ParamKind string
+
+const (
+	// ParamBinding is resolved from the binding of its key
+	ParamBinding ParamKind = "binding"
+	// ParamGroup is a slice resolved from all the bindings of its key, see IntoGroup
+	ParamGroup ParamKind = "group"
+	// ParamProvider is a Provider function resolving the binding of its key when called
+	ParamProvider ParamKind = "provider"
+	// ParamOptional is an Optional resolved from the binding of its key if any
+	ParamOptional ParamKind = "optional"
+	// ParamBoundProvider is a BoundProvider resolving the binding of its key against the injection context
+	ParamBoundProvider ParamKind = "bound-provider"
+	// ParamRegistry is a Registry enumerating the bindings of its key
+	ParamRegistry ParamKind = "registry"
+	// ParamContextual is resolved from the resolution context rather than from a binding: InvocationContext,
+	// InjectionPoint or BindingName
+	ParamContextual ParamKind = "contextual"
+)
+
+// ParamPlan describe how a parameter of a function, or a tagged field of a Params parameter, is resolved
+type ParamPlan struct {
+	Parameter  int          // index of the function parameter
+	Field      string       // name of the Params field, empty for function parameters
+	FieldIndex int          // index of the Params field, -1 for function parameters
+	Type       reflect.Type // type of the parameter or field
+	Kind       ParamKind
+	Key        BindingKey // key of the requested bindings, zero for ParamContextual
+	Optional   bool       // the field is tagged optional or has a default, see Params
+	Default    string     // literal of the default tag, if HasDefault
+	HasDefault bool
+}
+
+// FunctionMetadata describe how the injector calls a function: a provider or an invoked function
+type FunctionMetadata struct {
+	Name         string // fully qualified name of the function, empty if unknown
+	Type         reflect.Type
+	Params       []ParamPlan // in parameter order, then field order
+	ReturnsError bool        // the last result is an error
+}
+
+// BindingMetadata describe a binding and its provider
+type BindingMetadata struct {
+	Key      BindingKey
+	Scope    string
+	Location string // source location of the Provide call, empty if unknown
+	Provider FunctionMetadata
+}
+
+// functionPlans cache the parameter plans by function type
+var functionPlans sync.Map // []ParamPlan by reflect.Type
+
+// DescribeFunction return the metadata of fn, as analyzed by the injector to resolve its arguments (see Invoke and
+// Provide), so that external tools (debug UIs, code generators, static analyzers) do not re-implement the analysis.
+// The plans are cached by function type, they must not be modified.
+func DescribeFunction(fn any) (*FunctionMetadata, error) {
+	fvalue := reflect.ValueOf(fn)
+	if fn == nil || fvalue.Kind() != reflect.Func {
+		return nil, newInvalidInputError(fmt.Sprintf("can't describe non-function %v", fn))
+	}
+	return describeFunction(fvalue), nil
+}
+
+// Metadata return the metadata of the injector bindings (except the *Injector one), sorted by key and scope
+func (injector *Injector) Metadata() []BindingMetadata {
+	var res []BindingMetadata
+	for _, b := range injector.allBindings() {
+		res = append(res, BindingMetadata{
+			Key:      b.key(),
+			Scope:    b.scope,
+			Location: b.location,
+			Provider: *describeFunction(b.provider),
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Key.String() != res[j].Key.String() {
+			return res[i].Key.String() < res[j].Key.String()
+		}
+		return res[i].Scope < res[j].Scope
+	})
+	return res
+}
+
+func describeFunction(fvalue reflect.Value) *FunctionMetadata {
+	ftype := fvalue.Type()
+	res := &FunctionMetadata{
+		Type:         ftype,
+		Params:       planOf(ftype),
+		ReturnsError: ftype.NumOut() > 0 && ftype.Out(ftype.NumOut()-1) == errorReflectType,
+	}
+	if fn := runtime.FuncForPC(fvalue.Pointer()); fn != nil {
+		res.Name = fn.Name()
+	}
+	return res
+}
+
+// planOf return the (cached) parameter plans of the function type fnType
+func planOf(fnType reflect.Type) []ParamPlan {
+	if plans, ok := functionPlans.Load(fnType); ok {
+		return plans.([]ParamPlan)
+	}
+	var plans []ParamPlan
+	for i := 0; i < fnType.NumIn(); i++ {
+		argType := fnType.In(i)
+		if !EmbedsParams(argType) {
+			plans = append(plans, newParamPlan(ParamPlan{Parameter: i, FieldIndex: -1, Type: argType}, ""))
+			continue
+		}
+		if argType.Kind() == reflect.Ptr {
+			argType = argType.Elem()
+		}
+		for fieldIndex := 0; fieldIndex < argType.NumField(); fieldIndex++ {
+			field := argType.Field(fieldIndex)
+			tag, ok := field.Tag.Lookup("inject")
+			if !ok || field.Type == _paramType {
+				continue
+			}
+			annotation, optional := parseInjectTag(tag)
+			plan := ParamPlan{Parameter: i, Field: field.Name, FieldIndex: fieldIndex, Type: field.Type}
+			plan.Default, plan.HasDefault = field.Tag.Lookup("default")
+			plan.Optional = optional || plan.HasDefault
+			plans = append(plans, newParamPlan(plan, annotation))
+		}
+	}
+	functionPlans.Store(fnType, plans)
+	return plans
+}
+
+// parseInjectTag return the annotation of an inject tag and whether it has the optional option
+func parseInjectTag(tag string) (annotation string, optional bool) {
+	options := strings.Split(tag, ",")
+	for _, option := range options {
+		if strings.TrimSpace(option) == "optional" {
+			optional = true
+		}
+	}
+	return options[0], optional
+}
+
+// argumentPlans return the plans of the parameter of index parameter among the plans of a function
+func argumentPlans(plans []ParamPlan, parameter int) []ParamPlan {
+	start := sort.Search(len(plans), func(i int) bool { return plans[i].Parameter >= parameter })
+	end := sort.Search(len(plans), func(i int) bool { return plans[i].Parameter > parameter })
+	return plans[start:end]
+}
+
+// newParamPlan set the kind and key of plan, requesting its type with annotation
+func newParamPlan(plan ParamPlan, annotation string) ParamPlan {
+	t := plan.Type
+	switch {
+	case isContextualArgument(t):
+		plan.Kind = ParamContextual
+		return plan
+	case isOptionalType(t):
+		plan.Kind, plan.Key = ParamOptional, newParamPlan(ParamPlan{Type: optionalElemType(t)}, annotation).Key
+		return plan
+	case isBoundProviderType(t):
+		plan.Kind, plan.Key = ParamBoundProvider, newParamPlan(ParamPlan{Type: boundProviderElemType(t)}, annotation).Key
+		return plan
+	case isRegistryType(t):
+		plan.Kind, t = ParamRegistry, registryElemType(t)
+	case t.Kind() == reflect.Slice:
+		plan.Kind, t = ParamGroup, t.Elem()
+	case t.Kind() == reflect.Func && t.NumIn() == 1 && t.In(0) == invocationContextReflectType &&
+		t.NumOut() == 2 && t.Out(1) == errorReflectType:
+		plan.Kind, t = ParamProvider, t.Out(0)
+	default:
+		plan.Kind = ParamBinding
+	}
+	plan.Key = BindingKey{Type: t, Annotation: annotation}
+	return plan
+}
@@ -0,0 +1,53 @@
+package goinject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeFunction(t *testing.T) {
+	type DescribedParams struct {
+		Params
+		Colors   []*Color                                `inject:"colors"`
+		Parent   Optional[*Parent]                       `inject:""`
+		Port     int                                     `inject:"port" default:"8080"`
+		Provider func(InvocationContext) (*Child, error) `inject:"child,optional"`
+	}
+	metadata, err := DescribeFunction(func(_ InvocationContext, _ *AppConfig, _ DescribedParams) error { return nil })
+	assert.Nil(t, err)
+	assert.True(t, metadata.ReturnsError)
+	assert.Contains(t, metadata.Name, "TestDescribeFunction")
+	assert.Equal(t, []ParamPlan{
+		{Parameter: 0, FieldIndex: -1, Type: invocationContextReflectType, Kind: ParamContextual},
+		{Parameter: 1, FieldIndex: -1, Type: KeyOf[*AppConfig]().Type, Kind: ParamBinding, Key: KeyOf[*AppConfig]()},
+		{Parameter: 2, Field: "Colors", FieldIndex: 1, Type: KeyOf[[]*Color]().Type, Kind: ParamGroup,
+			Key: KeyOf[*Color]("colors")},
+		{Parameter: 2, Field: "Parent", FieldIndex: 2, Type: KeyOf[Optional[*Parent]]().Type, Kind: ParamOptional,
+			Key: KeyOf[*Parent]()},
+		{Parameter: 2, Field: "Port", FieldIndex: 3, Type: KeyOf[int]().Type, Kind: ParamBinding,
+			Key: KeyOf[int]("port"), Optional: true, Default: "8080", HasDefault: true},
+		{Parameter: 2, Field: "Provider", FieldIndex: 4, Type: KeyOf[func(InvocationContext) (*Child, error)]().Type,
+			Kind: ParamProvider, Key: KeyOf[*Child]("child"), Optional: true},
+	}, metadata.Params)
+
+	_, err = DescribeFunction("not a function")
+	assert.NotNil(t, err)
+}
+
+func TestInjectorMetadata(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func(_ *Child) *Parent { return &Parent{} }, In(PerLookUp)),
+		Provide(func() *Child { return &Child{} }),
+	)
+	assert.Nil(t, err)
+	metadata := injector.Metadata()
+	if assert.Len(t, metadata, 2) {
+		assert.Equal(t, KeyOf[*Child](), metadata[0].Key)
+		assert.Equal(t, KeyOf[*Parent](), metadata[1].Key)
+		assert.Equal(t, PerLookUp, metadata[1].Scope)
+		assert.NotEmpty(t, metadata[1].Location)
+		assert.Equal(t, KeyOf[*Child](), metadata[1].Provider.Params[0].Key)
+		assert.False(t, metadata[1].Provider.ReturnsError)
+	}
+}
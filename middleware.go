@@ -0,0 +1,57 @@
+package goinject
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+)
+
+// InvokeInfo describes the function invoked by Injector.Invoke or Injector.InvokeBestEffort
+type InvokeInfo struct {
+	Function  string       // fully qualified name of the invoked function
+	Arguments []BindingKey // keys of the bindings requested by the function arguments
+}
+
+// InvokeMiddleware wrap an invocation of Injector.Invoke or Injector.InvokeBestEffort: it must call next to resolve the arguments and call
+// the invoked function, and may change the context passed to next or the returned error.
+type InvokeMiddleware func(ctx context.Context, info InvokeInfo, next func(ctx context.Context) error) error
+
+type invokeMiddlewareOption struct {
+	middleware InvokeMiddleware
+}
+
+func (o *invokeMiddlewareOption) apply(mod *configuration) error {
+	if o.middleware == nil {
+		return newInjectorConfigurationError("cannot accept nil invoke middleware", nil)
+	}
+	mod.invokeMiddlewares = append(mod.invokeMiddlewares, o.middleware)
+	return nil
+}
+
+// WithInvokeMiddleware register an InvokeMiddleware applied around every Injector.Invoke and InvokeBestEffort call,
+// e.g. to convert panics to errors, to log or to time invocations. The first registered middleware is the outermost one.
+func WithInvokeMiddleware(middleware InvokeMiddleware) InjectorOption {
+	return newInjectorOption("WithInvokeMiddleware", &invokeMiddlewareOption{middleware: middleware})
+}
+
+func newInvokeInfo(fvalue reflect.Value) InvokeInfo {
+	info := InvokeInfo{Arguments: dependenciesOf(fvalue.Type())}
+	if fn := runtime.FuncForPC(fvalue.Pointer()); fn != nil {
+		info.Function = fn.Name()
+	}
+	return info
+}
+
+// withInvokeMiddlewares return invoke wrapped by the injector middlewares
+func (injector *Injector) withInvokeMiddlewares(
+	info InvokeInfo,
+	invoke func(ctx context.Context) error,
+) func(ctx context.Context) error {
+	for i := len(injector.invokeMiddlewares) - 1; i >= 0; i-- {
+		middleware, next := injector.invokeMiddlewares[i], invoke
+		invoke = func(ctx context.Context) error {
+			return middleware(ctx, info, next)
+		}
+	}
+	return invoke
+}
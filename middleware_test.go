@@ -0,0 +1,55 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type middlewareContextKey struct{}
+
+func TestInvokeMiddleware(t *testing.T) {
+	var calls []string
+	var infos []InvokeInfo
+	injector, err := NewInjector(
+		Provide(func() *Parent { return &Parent{} }),
+		WithInvokeMiddleware(func(ctx context.Context, info InvokeInfo, next func(ctx context.Context) error) (err error) {
+			calls = append(calls, "recover")
+			infos = append(infos, info)
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("invocation panicked: %v", r)
+				}
+			}()
+			return next(ctx)
+		}),
+		WithInvokeMiddleware(func(ctx context.Context, _ InvokeInfo, next func(ctx context.Context) error) error {
+			calls = append(calls, "context")
+			return next(context.WithValue(ctx, middlewareContextKey{}, "enriched"))
+		}),
+	)
+	assert.Nil(t, err)
+
+	t.Run("Should apply middlewares in registration order", func(t *testing.T) {
+		calls, infos = nil, nil
+		err := injector.Invoke(context.Background(), func(ctx InvocationContext, _ *Parent) {
+			assert.Equal(t, "enriched", ctx.Value(middlewareContextKey{}))
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"recover", "context"}, calls)
+		assert.Contains(t, infos[0].Function, "TestInvokeMiddleware")
+		assert.Equal(t, []BindingKey{KeyOf[*Parent]()}, infos[0].Arguments)
+	})
+
+	t.Run("Should let middlewares convert panics to errors", func(t *testing.T) {
+		err := injector.Invoke(context.Background(), func() { panic("boom") })
+		assert.EqualError(t, err, "invocation panicked: boom")
+	})
+
+	t.Run("Should reject nil middleware", func(t *testing.T) {
+		_, err := NewInjector(WithInvokeMiddleware(nil))
+		assert.EqualError(t, err, "cannot accept nil invoke middleware")
+	})
+}
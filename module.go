@@ -1,13 +1,45 @@
 package goinject
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 )
 
 type configuration struct {
-	bindings map[*binding]bool
-	scopes   map[string]Scope
+	bindings          map[*binding]bool
+	registered        int // number of Provide options applied, used as registration order
+	scopes            map[string]Scope
+	errorDecorators   []ErrorDecorator
+	deterministic     bool
+	debugResolutions  bool
+	checkInterfaces   bool                  // see CheckInterfaceBindings
+	strictNil         bool                  // see StrictNil
+	auditContexts     bool                  // see AuditContextPropagation
+	auditResolutions  bool                  // see AuditResolutions
+	detectDuplicates  bool                  // see DetectDuplicateInstances
+	warmUp            map[reflect.Type]bool // types of the eagerly created singletons if set, see WarmUp
+	deferEagerErrors  bool                  // see DeferEagerErrors
+	observers         []func(event Event)
+	modules           []string        // names of the modules being installed, outermost first
+	replacedModules   map[string]bool // modules whose bindings are replaced, see ReplaceModule
+	replacing         int             // greater than 0 while installing replacement options
+	phases            []string        // lifecycle phases, in start order
+	invokeMiddlewares []InvokeMiddleware
+	selectors         map[BindingKey]Selector  // see WithSelector
+	variants          map[string]bool          // enabled variants, see WithVariants
+	variantsEvaluated bool                     // true once an OnVariant conditional was evaluated
+	overrideMode      OverrideMode             // see OverridePolicy
+	lintRules         []LintRule               // see WithLintRules
+	moduleDefaults    []Annotation             // annotations of the module being installed, see DefaultAnnotations
+	shutdownHooks     []shutdownHook           // see OnShutdown
+	fakes             bool                     // see WithFakes
+	unit              string                   // unit being installed, see Unit
+	collectors        []collector              // see CollectImplementations
+	installedModules  map[string]*moduleOption // installed modules, by name
+	defaultScope      string                   // scope of the bindings without explicit scope if set, see DefaultScope
+	requirements      []moduleRequirement      // see Requires
+	expectedManifest  string                   // see VerifyManifest
 }
 
 // Option enable to configure the given injector
@@ -15,15 +47,68 @@ type Option interface {
 	apply(*configuration) error
 }
 
+// InjectorOption is an Option configuring the injector itself (observers, error decorators, strictness, variants,
+// debug tools...) rather than its bindings. It is given to NewInjector, possibly within When, while installing it in
+// a Module (or in ReplaceModule, Unit...) fails: modules only hold bindings and are reusable across injectors.
+// As an InjectorOption is an Option (so that When and NewInjector accept both), this is checked at runtime only:
+// Module("x", WithObserver(...)) compiles, and makes NewInjector return an error.
+type InjectorOption interface {
+	Option
+	injectorOption()
+}
+
+type injectorOption struct {
+	name   string // name of the function creating the option
+	option Option
+}
+
+func newInjectorOption(name string, option Option) InjectorOption {
+	return &injectorOption{name: name, option: option}
+}
+
+func (o *injectorOption) apply(mod *configuration) error {
+	if len(mod.modules) > 0 {
+		return newInjectorConfigurationError(fmt.Sprintf(
+			"injector option %s cannot be installed in module %s, it must be given to NewInjector",
+			o.name, mod.modules[len(mod.modules)-1]), nil)
+	}
+	return o.option.apply(mod)
+}
+
+func (o *injectorOption) injectorOption() {}
+
 type moduleOption struct {
-	name    string
-	options []Option
+	name     string
+	options  []Option
+	location string // source location of the Module call
 }
 
 func (o *moduleOption) apply(mod *configuration) error {
+	if mod.replacing == 0 {
+		if installed, ok := mod.installedModules[o.name]; ok {
+			if installed == o {
+				return nil
+			}
+			return newConfigurationProblemError(InvalidOption, "", mod.modules, o.location,
+				newInjectorConfigurationError(fmt.Sprintf(
+					"module %q installed twice with different definitions (at %s and %s)",
+					o.name, installed.location, o.location), nil))
+		}
+		if mod.installedModules == nil {
+			mod.installedModules = make(map[string]*moduleOption)
+		}
+		mod.installedModules[o.name] = o
+	}
+	mod.modules = append(mod.modules, o.name)
+	defer func() { mod.modules = mod.modules[:len(mod.modules)-1] }()
+	defer mod.withDefaultAnnotations(o.options)()
 	for _, opt := range o.options {
 		err := opt.apply(mod)
 		if err != nil {
+			var problemErr *configurationProblemError
+			if !errors.As(err, &problemErr) {
+				err = newConfigurationProblemError(InvalidOption, "", mod.modules, "", err)
+			}
 			return newInjectorConfigurationError(
 				fmt.Sprintf("error while installing module %s", o.name), err)
 		}
@@ -33,10 +118,14 @@ func (o *moduleOption) apply(mod *configuration) error {
 
 // Module group a list of Option in order to easily reuse them.
 // the Module name is used in error when applying Option to easily find misconfigured options.
+// A module is installed once: installing again the same Option returned by Module (e.g. a shared dependency of two
+// modules) is a no-op, while installing two modules of the same name fails, including two modules returned by
+// calls of a module factory: such factories must give distinct names to their modules.
 func Module(name string, opts ...Option) Option {
 	mo := &moduleOption{
-		name:    name,
-		options: opts,
+		name:     name,
+		options:  opts,
+		location: callerLocation(),
 	}
 	return mo
 }
@@ -44,9 +133,36 @@ func Module(name string, opts ...Option) Option {
 type provideOption struct {
 	constructor any
 	annotations []Annotation
+	location    string // source location of the Provide call
 }
 
 func (o *provideOption) apply(mod *configuration) error {
+	var b *binding
+	if err := o.configure(mod, &b); err != nil {
+		key := ""
+		if b != nil {
+			key = b.key().String()
+		}
+		return newConfigurationProblemError(InvalidProvider, key, mod.modules, o.location, err)
+	}
+	if mod.replacing == 0 && mod.isReplaced(b) {
+		return nil
+	}
+	b.order = mod.registered
+	mod.registered++
+	mod.bindings[b] = true
+	if b.expandFields {
+		for _, fieldBinding := range fieldBindings(b) {
+			fieldBinding.order = mod.registered
+			mod.registered++
+			mod.bindings[fieldBinding] = true
+		}
+	}
+	return nil
+}
+
+// configure create the binding in res, res is set as soon as the provided type is known
+func (o *provideOption) configure(mod *configuration, res **binding) error {
 	if o.constructor == nil {
 		return newInjectorConfigurationError("cannot accept nil provider", nil)
 	}
@@ -66,8 +182,12 @@ func (o *provideOption) apply(mod *configuration) error {
 	b.providedType = fncType.Out(0)
 	b.typeof = b.providedType
 	b.scope = Singleton
+	b.modules = append([]string(nil), mod.modules...)
+	b.location = o.location
+	b.unit = mod.unit
+	*res = b
 
-	for _, a := range o.annotations {
+	for _, a := range append(append([]Annotation{}, mod.moduleDefaults...), o.annotations...) {
 		err := a.apply(b)
 		if err != nil {
 			return newInjectorConfigurationError(
@@ -76,8 +196,13 @@ func (o *provideOption) apply(mod *configuration) error {
 			)
 		}
 	}
+	if err := errors.Join(b.checkProxy(), b.checkLazyProxy(), b.checkFake(), b.checkDestroyOnReturn()); err != nil {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("got error while configuring provider for provided type %s", b.providedType),
+			err,
+		)
+	}
 
-	mod.bindings[b] = true
 	return nil
 }
 
@@ -88,6 +213,7 @@ func Provide(constructor any, annotations ...Annotation) Option {
 	return &provideOption{
 		constructor: constructor,
 		annotations: annotations,
+		location:    callerLocation(),
 	}
 }
 
@@ -96,7 +222,17 @@ type registerScopeOption struct {
 	scope Scope
 }
 
+// validatedScope is implemented by scopes whose options are checked when they are registered
+type validatedScope interface {
+	validate() error
+}
+
 func (o *registerScopeOption) apply(mod *configuration) error {
+	if scope, ok := o.scope.(validatedScope); ok {
+		if err := scope.validate(); err != nil {
+			return err
+		}
+	}
 	mod.scopes[o.name] = o.scope
 	return nil
 }
@@ -115,7 +251,7 @@ type whenOption struct {
 }
 
 func (o *whenOption) apply(mod *configuration) error {
-	if o.condition.evaluate() {
+	if o.condition.evaluate(mod) {
 		for _, opt := range o.options {
 			if err := opt.apply(mod); err != nil {
 				return err
@@ -187,9 +323,15 @@ func (a *nameAnnotation) apply(b *binding) error {
 	return nil
 }
 
+// Name is a typed annotation name. Declaring annotation names as variables or constants
+// (e.g. `var Red = goinject.Name("red")`) make them refactorable instead of scattered string literals.
+// The name is matched by inject tags having the same value (`inject:"red"`).
+type Name string
+
 // Named return an annotation that is used to define the binding annotation name.
-func Named(name string) Annotation {
-	return &nameAnnotation{name: name}
+// It accepts both string and Name.
+func Named[N ~string](name N) Annotation {
+	return &nameAnnotation{name: string(name)}
 }
 
 type inAnnotation struct {
@@ -203,6 +345,7 @@ func In(scope string) Annotation {
 
 func (a *inAnnotation) apply(b *binding) error {
 	b.scope = a.scope
+	b.explicitScope = true
 	return nil
 }
 
@@ -233,3 +376,38 @@ func WithDestroy(destroyMethod any) Annotation {
 		destroyMethod: destroyMethod,
 	}
 }
+
+// IntoGroup return an annotation adding the binding to the named group.
+// A group is a multi-binding: members registered with the same type (see As) are resolved together
+// by requesting a slice of that type tagged with the group name, e.g. `inject:"commands"`.
+// It accepts both string and Name.
+func IntoGroup[N ~string](group N) Annotation {
+	return &groupAnnotation{name: string(group)}
+}
+
+type groupAnnotation struct {
+	name string
+}
+
+func (a *groupAnnotation) apply(b *binding) error {
+	b.annotatedWith = a.name
+	b.grouped = true
+	return nil
+}
+
+// annotationName return the name given by the Named or IntoGroup annotations, for options resolving the binding of
+// the same key elsewhere. As is rejected: the resolved type is fixed by the option.
+func annotationName(annotations []Annotation) (string, error) {
+	name := ""
+	for _, a := range annotations {
+		switch a := a.(type) {
+		case *nameAnnotation:
+			name = a.name
+		case *groupAnnotation:
+			name = a.name
+		case *asAnnotation:
+			return "", fmt.Errorf("As(%s) cannot be used here, the bound type is given by the option", a.target.getType())
+		}
+	}
+	return name, nil
+}
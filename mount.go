@@ -0,0 +1,85 @@
+package goinject
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+// MountOption configure Mount
+type MountOption func(m *mountOption)
+
+// ExportOnly restrict the bindings mounted by Mount to the given types
+func ExportOnly(types ...AsType) MountOption {
+	return func(m *mountOption) {
+		if m.exported == nil {
+			m.exported = make(map[reflect.Type]bool)
+		}
+		for _, t := range types {
+			m.exported[t.getType()] = true
+		}
+	}
+}
+
+type mountOption struct {
+	name     string
+	other    *Injector
+	exported map[reflect.Type]bool // types of the mounted bindings, all types if nil
+	location string                // source location of the Mount call
+}
+
+func (o *mountOption) apply(mod *configuration) error {
+	if o.name == "" {
+		return newInjectorConfigurationError("name of Mount must not be empty", nil)
+	}
+	if o.other == nil {
+		return newInjectorConfigurationError("cannot mount nil injector", nil)
+	}
+	bindings := o.other.allBindings()
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return bindings[i].order < bindings[j].order
+	})
+	for _, b := range bindings {
+		if o.exported != nil && !o.exported[b.typeof] {
+			continue
+		}
+		option := &provideOption{
+			constructor: o.mountedProvider(b),
+			annotations: []Annotation{Named(o.mountedAnnotation(b.annotatedWith)), In(PerLookUp)},
+			location:    o.location,
+		}
+		if err := option.apply(mod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mountedAnnotation return the annotation of a mounted binding: the mount name for unannotated bindings, the
+// annotation within the mount name namespace otherwise
+func (o *mountOption) mountedAnnotation(annotation string) string {
+	if annotation == "" {
+		return o.name
+	}
+	return string(Namespace(o.name).Name(annotation))
+}
+
+// mountedProvider return the provider of the mounted binding b, resolving b in the mounted injector
+func (o *mountOption) mountedProvider(b *binding) any {
+	return delegatingProvider(b.typeof, func(ctx context.Context) (reflect.Value, error) {
+		return o.other.getInjectedInstance(ctx, b)
+	})
+}
+
+// Mount return an Option binding the bindings of the already built injector other, with annotations prefixed by
+// name: an unannotated binding of other is annotated with name, a binding annotated with "x" is annotated with
+// name/x (see Namespace). It lets teams ship pre-built containers that applications embed, ExportOnly restricting
+// the mounted types to the public API of the container.
+// Mounted bindings are resolved by other, which keeps owning their instances: other must be shut down separately.
+func Mount(name string, other *Injector, opts ...MountOption) Option {
+	res := &mountOption{name: name, other: other, location: callerLocation()}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res
+}
@@ -0,0 +1,58 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMount(t *testing.T) {
+	parent := &Parent{}
+	childErr := errors.New("child unavailable")
+	payments, err := NewInjector(
+		Provide(func() *Parent { return parent }),
+		Provide(func() *Child { return &Child{} }, Named("internal")),
+		Provide(func() (*AppConfig, error) { return nil, childErr }, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+	defer payments.Shutdown()
+
+	injector, err := NewInjector(
+		Mount("payments", payments),
+		Mount("api", payments, ExportOnly(Type[*Parent]())),
+	)
+	assert.Nil(t, err)
+
+	type params struct {
+		Params
+		Parent    *Parent `inject:"payments"`
+		Child     *Child  `inject:"payments/internal"`
+		APIParent *Parent `inject:"api"`
+		APIChild  *Child  `inject:"api/internal,optional"`
+	}
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(p params) {
+		assert.Same(t, parent, p.Parent)
+		assert.Same(t, parent, p.APIParent)
+		assert.NotNil(t, p.Child)
+		assert.Nil(t, p.APIChild)
+	})
+	assert.Nil(t, err)
+
+	type configParams struct {
+		Params
+		Config *AppConfig `inject:"payments"`
+	}
+	err = injector.Invoke(ctx, func(_ configParams) {
+		assert.Fail(t, "should not be reached")
+	})
+	assert.ErrorIs(t, err, childErr)
+	assert.ErrorContains(t, err, "*goinject.AppConfig (with annotation \"payments\")")
+
+	_, err = NewInjector(Mount("", payments))
+	assert.ErrorContains(t, err, "name of Mount must not be empty")
+	_, err = NewInjector(Mount("payments", nil))
+	assert.ErrorContains(t, err, "cannot mount nil injector")
+}
@@ -0,0 +1,94 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ProviderErrorsEvent is notified when a provider returned an error aggregating several errors (e.g. with
+// errors.Join, possibly wrapped), Errs hold each cause separately
+type ProviderErrorsEvent struct {
+	Key  BindingKey
+	Errs []error
+}
+
+func (ProviderErrorsEvent) isEvent() {}
+
+// providerError is the error returned by a provider, causes of multi-errors are reported on their own lines
+type providerError struct {
+	providedType reflect.Type
+	err          error   // error returned by the provider
+	causes       []error // see errorCauses
+}
+
+var _ error = &providerError{}
+
+func newProviderError(providedType reflect.Type, err error) *providerError {
+	return &providerError{providedType: providedType, err: err, causes: errorCauses(err)}
+}
+
+func (e *providerError) Error() string {
+	if len(e.causes) == 1 {
+		return fmt.Sprintf("provider for type %q returned error: %s", e.providedType.String(), e.err)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "provider for type %q returned %d errors:", e.providedType.String(), len(e.causes))
+	if context := wrappingContext(e.err); context != "" {
+		fmt.Fprintf(&sb, " %s:", context)
+	}
+	for _, cause := range e.causes {
+		sb.WriteString("\n  - ")
+		sb.WriteString(strings.ReplaceAll(cause.Error(), "\n", "\n    "))
+	}
+	return sb.String()
+}
+
+func (e *providerError) Unwrap() error { return e.err }
+
+// errorCauses return the errors aggregated by err if it is (or wraps) a multi-error, nested multi-errors being
+// flattened, or err itself otherwise
+func errorCauses(err error) []error {
+	for unwrapped := err; unwrapped != nil; {
+		switch e := unwrapped.(type) {
+		case interface{ Unwrap() []error }:
+			var causes []error
+			for _, cause := range e.Unwrap() {
+				if cause != nil {
+					causes = append(causes, errorCauses(cause)...)
+				}
+			}
+			if len(causes) > 0 {
+				return causes
+			}
+			return []error{err}
+		case interface{ Unwrap() error }:
+			unwrapped = e.Unwrap()
+		default:
+			return []error{err}
+		}
+	}
+	return []error{err}
+}
+
+// wrappingContext return the message added by the errors wrapping the multi-error aggregated by err, e.g.
+// "loading config" for fmt.Errorf("loading config: %w", errors.Join(a, b)). It is empty if err is the multi-error
+// or if the wrapping errors do not end their message with the message of the wrapped error.
+func wrappingContext(err error) string {
+	wrapped := false
+	for unwrapped := err; unwrapped != nil; wrapped = true {
+		switch e := unwrapped.(type) {
+		case interface{ Unwrap() []error }:
+			message, multiMessage := err.Error(), unwrapped.Error()
+			if !wrapped || !strings.HasSuffix(message, multiMessage) {
+				return ""
+			}
+			return strings.TrimRight(strings.TrimSuffix(message, multiMessage), ": \n")
+		case interface{ Unwrap() error }:
+			unwrapped = e.Unwrap()
+		default:
+			return ""
+		}
+	}
+	return ""
+}
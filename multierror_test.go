@@ -0,0 +1,78 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderMultiError(t *testing.T) {
+	addrErr := errors.New("listen address is required")
+	portErr := errors.New("port must be positive")
+	var events []Event
+	injector, err := NewInjector(
+		WithObserver(func(event Event) {
+			if _, ok := event.(ProviderErrorsEvent); ok {
+				events = append(events, event)
+			}
+		}),
+		Provide(func() (*AppConfig, error) {
+			return nil, fmt.Errorf("invalid configuration: %w", errors.Join(addrErr, portErr))
+		}, In(PerLookUp)),
+		Provide(func() (*Parent, error) {
+			return nil, addrErr
+		}, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(_ *AppConfig) {
+		assert.Fail(t, "should not be reached")
+	})
+	assert.ErrorIs(t, err, addrErr)
+	assert.ErrorIs(t, err, portErr)
+	assert.ErrorContains(t, err, "provider for type \"*goinject.AppConfig\" returned 2 errors: invalid configuration:\n"+
+		"  - listen address is required\n"+
+		"  - port must be positive")
+	assert.Equal(t, []Event{
+		ProviderErrorsEvent{Key: KeyOf[*AppConfig](), Errs: []error{addrErr, portErr}},
+	}, events)
+
+	err = injector.Invoke(ctx, func(_ *Parent) {
+		assert.Fail(t, "should not be reached")
+	})
+	assert.ErrorIs(t, err, addrErr)
+	assert.ErrorContains(t, err, "provider for type \"*goinject.Parent\" returned error: listen address is required")
+	assert.Len(t, events, 1)
+}
+
+type ConfigLoadingError struct {
+	err error
+}
+
+func (e *ConfigLoadingError) Error() string { return "config loading failed: " + e.err.Error() }
+
+func (e *ConfigLoadingError) Unwrap() error { return e.err }
+
+func TestProviderMultiErrorShouldKeepWrappingErrors(t *testing.T) {
+	addrErr := errors.New("listen address is required")
+	portErr := errors.New("port must be positive")
+	injector, err := NewInjector(
+		Provide(func() (*AppConfig, error) {
+			return nil, fmt.Errorf("loading config: %w", &ConfigLoadingError{errors.Join(addrErr, portErr)})
+		}, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+
+	err = injector.Invoke(context.Background(), func(_ *AppConfig) {})
+	var loadingErr *ConfigLoadingError
+	assert.ErrorAs(t, err, &loadingErr)
+	assert.ErrorIs(t, err, addrErr)
+	assert.ErrorIs(t, err, portErr)
+	assert.ErrorContains(t, err, "returned 2 errors: loading config: config loading failed:\n"+
+		"  - listen address is required\n"+
+		"  - port must be positive")
+}
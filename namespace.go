@@ -0,0 +1,57 @@
+package goinject
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// Namespace prefix annotation names so that independently developed modules using common names (e.g. "primary")
+// do not collide in one injector: Namespace("payments").Named("cache") annotate the binding with "payments/cache".
+// Namespaces can be nested with Sub.
+// Inject tags may use wildcards (e.g. `inject:"*/cache"`), matched with path.Match: a wildcard does not match
+// across namespace separators.
+type Namespace string
+
+// NamespaceSeparator separate the namespace from the name in namespaced annotations
+const NamespaceSeparator = "/"
+
+// Name return the namespaced annotation name, to be used in KeyOf or in inject tags
+func (ns Namespace) Name(name string) Name {
+	return Name(string(ns) + NamespaceSeparator + name)
+}
+
+// Named return an annotation defining the binding annotation name within the namespace
+func (ns Namespace) Named(name string) Annotation {
+	return Named(ns.Name(name))
+}
+
+// IntoGroup return an annotation adding the binding to the group within the namespace
+func (ns Namespace) IntoGroup(group string) Annotation {
+	return IntoGroup(ns.Name(group))
+}
+
+// Sub return the nested namespace with the given name
+func (ns Namespace) Sub(name string) Namespace {
+	return Namespace(ns.Name(name))
+}
+
+// isAnnotationPattern tell if the annotation requested by an inject tag contains wildcards
+func isAnnotationPattern(annotation string) bool {
+	return strings.Contains(annotation, "*")
+}
+
+// findBindingsMatchingAnnotation return the bindings of type t whose annotation match the pattern, in registration
+// order
+func findBindingsMatchingAnnotation(byAnnotation map[string][]*binding, pattern string) []*binding {
+	var res []*binding
+	for annotation, bindings := range byAnnotation {
+		if matched, _ := path.Match(pattern, annotation); matched {
+			res = append(res, bindings...)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].order < res[j].order
+	})
+	return res
+}
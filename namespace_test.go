@@ -0,0 +1,54 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	payments = Namespace("payments")
+	shipping = Namespace("shipping")
+)
+
+type NamespacedParams struct {
+	Params
+	PaymentsCache *Color   `inject:"payments/cache"`
+	Caches        []*Color `inject:"*/cache"`
+	Primary       *Color   `inject:"*/primary"`
+	Regions       []*Color `inject:"shipping/regions/*"`
+}
+
+func TestNamespace(t *testing.T) {
+	injector, err := NewInjector(
+		Deterministic(),
+		Provide(func() *Color { return &Color{name: "payments cache"} }, payments.Named("cache")),
+		Provide(func() *Color { return &Color{name: "shipping cache"} }, shipping.Named("cache")),
+		Provide(func() *Color { return &Color{name: "payments primary"} }, payments.Named("primary")),
+		Provide(func() *Color { return &Color{name: "eu"} }, shipping.Sub("regions").Named("eu")),
+		Provide(func() *Color { return &Color{name: "us"} }, shipping.Sub("regions").IntoGroup("us")),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(p NamespacedParams) {
+		assert.Equal(t, "payments cache", p.PaymentsCache.name)
+		assert.Equal(t, []*Color{{name: "payments cache"}, {name: "shipping cache"}}, p.Caches)
+		assert.Equal(t, "payments primary", p.Primary.name)
+		assert.Equal(t, []*Color{{name: "eu"}, {name: "us"}}, p.Regions)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, Name("payments/cache"), payments.Name("cache"))
+
+	t.Run("Wildcard should fail single-value injection matching several bindings", func(t *testing.T) {
+		type AmbiguousParams struct {
+			Params
+			Cache *Color `inject:"*/cache"`
+		}
+		err := injector.Invoke(ctx, func(_ AmbiguousParams) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorContains(t, err, "found multiple bindings expected one")
+	})
+}
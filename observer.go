@@ -0,0 +1,38 @@
+package goinject
+
+// Event is notified to observers registered with WithObserver, see the concrete event types
+type Event interface {
+	isEvent()
+}
+
+// BindingDegradedEvent is notified when the eager creation of a NonCritical singleton failed
+type BindingDegradedEvent struct {
+	Key BindingKey
+	Err error
+}
+
+func (BindingDegradedEvent) isEvent() {}
+
+type observerOption struct {
+	observer func(event Event)
+}
+
+func (o *observerOption) apply(mod *configuration) error {
+	if o.observer == nil {
+		return newInjectorConfigurationError("cannot accept nil observer", nil)
+	}
+	mod.observers = append(mod.observers, o.observer)
+	return nil
+}
+
+// WithObserver register a function notified of injector events (e.g. BindingDegradedEvent).
+// Observers are called synchronously, from the goroutine that triggered the event, and must not block.
+func WithObserver(observer func(event Event)) InjectorOption {
+	return newInjectorOption("WithObserver", &observerOption{observer: observer})
+}
+
+func (injector *Injector) notify(event Event) {
+	for _, observer := range injector.observers {
+		observer(event)
+	}
+}
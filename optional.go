@@ -0,0 +1,93 @@
+package goinject
+
+import "reflect"
+
+// OptionalState tell why an Optional holds a value or not
+type OptionalState int
+
+const (
+	OptionalMissing OptionalState = iota // there is no binding for the requested type
+	OptionalPresent                      // the instance was resolved
+	OptionalFailed                       // there is a binding but its resolution failed, see Optional.Err
+)
+
+// Optional[T] may be requested instead of T (as a function argument or a Params field) to inject T only if it is
+// bound. Unlike the optional tag option, which only tolerates missing bindings and fails the resolution if a
+// provider returns an error, Optional never fails the resolution: it reports whether the binding is missing or
+// failed, so that callers can tell real failures from absence.
+type Optional[T any] struct {
+	value T
+	state OptionalState
+	err   error
+}
+
+// Get return the instance and true if it was resolved, the zero value and false otherwise
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == OptionalPresent
+}
+
+// State return whether the instance is present, missing or failed
+func (o Optional[T]) State() OptionalState {
+	return o.state
+}
+
+// Err return the resolution error if the state is OptionalFailed, nil otherwise
+func (o Optional[T]) Err() error {
+	return o.err
+}
+
+// optionalValue is implemented by pointers to Optional types
+type optionalValue interface {
+	elemType() reflect.Type
+	set(state OptionalState, value reflect.Value, err error)
+}
+
+var optionalValueReflectType = reflect.TypeFor[optionalValue]()
+
+func (o *Optional[T]) elemType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (o *Optional[T]) set(state OptionalState, value reflect.Value, err error) {
+	o.state = state
+	o.err = err
+	if value.IsValid() {
+		reflect.ValueOf(&o.value).Elem().Set(value)
+	}
+}
+
+// isOptionalType tell if t is an Optional type
+func isOptionalType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(optionalValueReflectType)
+}
+
+// optionalElemType return the type wrapped by the Optional type t
+func optionalElemType(t reflect.Type) reflect.Type {
+	return reflect.New(t).Interface().(optionalValue).elemType()
+}
+
+// createOptionalValue resolve the instance wrapped by the Optional type t, recording resolution errors instead of
+// returning them
+func (injector *Injector) createOptionalValue(
+	ctx InvocationContext,
+	t reflect.Type,
+	annotation string,
+) reflect.Value {
+	res := reflect.New(t)
+	elemType := optionalElemType(t)
+	lookedUpType := elemType
+	if elemType.Kind() == reflect.Slice {
+		lookedUpType = elemType.Elem()
+	}
+	if len(injector.activeBindings(ctx, injector.findBindingsForAnnotatedType(lookedUpType, annotation))) == 0 {
+		res.Interface().(optionalValue).set(OptionalMissing, reflect.Value{}, nil)
+		return res.Elem()
+	}
+	instance, err := injector.getInstanceOfAnnotatedType(ctx, elemType, annotation, true)
+	if err != nil {
+		res.Interface().(optionalValue).set(OptionalFailed, reflect.Value{}, err)
+	} else {
+		res.Interface().(optionalValue).set(OptionalPresent, instance, nil)
+	}
+	return res.Elem()
+}
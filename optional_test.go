@@ -0,0 +1,74 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	providerErr := errors.New("cannot connect")
+	injector, err := NewInjector(
+		Provide(func() *Parent { return &Parent{} }),
+		Provide(func() (*AppConfig, error) { return nil, providerErr }, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(parent Optional[*Parent], config Optional[*AppConfig], child Optional[*Child]) {
+		value, ok := parent.Get()
+		assert.True(t, ok)
+		assert.NotNil(t, value)
+		assert.Equal(t, OptionalPresent, parent.State())
+		assert.Nil(t, parent.Err())
+
+		_, ok = config.Get()
+		assert.False(t, ok)
+		assert.Equal(t, OptionalFailed, config.State())
+		assert.ErrorIs(t, config.Err(), providerErr)
+
+		_, ok = child.Get()
+		assert.False(t, ok)
+		assert.Equal(t, OptionalMissing, child.State())
+		assert.Nil(t, child.Err())
+	})
+	assert.Nil(t, err)
+
+	t.Run("optional tag does not tolerate provider errors", func(t *testing.T) {
+		type params struct {
+			Params
+			Config *AppConfig `inject:",optional"`
+		}
+		err := injector.Invoke(ctx, func(_ params) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorIs(t, err, providerErr)
+	})
+
+	t.Run("Optional field", func(t *testing.T) {
+		type params struct {
+			Params
+			Config Optional[*AppConfig] `inject:""`
+		}
+		err := injector.Invoke(ctx, func(p params) {
+			assert.Equal(t, OptionalFailed, p.Config.State())
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("graph dependency", func(t *testing.T) {
+		type consumer struct{}
+		injector, err := NewInjector(
+			Provide(func() *Parent { return &Parent{} }),
+			Provide(func(_ Optional[*Parent]) *consumer { return &consumer{} }),
+		)
+		assert.Nil(t, err)
+		for _, b := range injector.Graph().Bindings {
+			if b.Key == KeyOf[*consumer]().String() {
+				assert.Equal(t, []string{KeyOf[*Parent]().String()}, b.Dependencies)
+			}
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package goinject
+
+import "fmt"
+
+// OverrideMode tell how bindings registered with the same key are handled, see OverridePolicy
+type OverrideMode string
+
+const (
+	// MultipleBindingsAllowed keep all the bindings registered with the same key: they are injected together in
+	// slices, and injecting a single instance is ambiguous. It is the default mode.
+	MultipleBindingsAllowed OverrideMode = "multiple"
+	// LastRegisteredWins keep only the bindings of a key registered by the module that registered it last, so that
+	// later modules intentionally replace the bindings of earlier ones (e.g. test or environment modules layered over
+	// the application module). Bindings of the same key registered by one module, and group members (see IntoGroup),
+	// are kept together.
+	LastRegisteredWins OverrideMode = "last-wins"
+)
+
+type overridePolicyOption struct {
+	mode OverrideMode
+}
+
+func (o *overridePolicyOption) apply(mod *configuration) error {
+	if o.mode != MultipleBindingsAllowed && o.mode != LastRegisteredWins {
+		return newInjectorConfigurationError(fmt.Sprintf("unknown override mode %q", o.mode), nil)
+	}
+	mod.overrideMode = o.mode
+	return nil
+}
+
+// OverridePolicy return an InjectorOption setting how bindings registered with the same key are handled
+func OverridePolicy(mode OverrideMode) InjectorOption {
+	return newInjectorOption("OverridePolicy", &overridePolicyOption{mode: mode})
+}
+
+// withoutOverriddenBindings remove the bindings overridden by bindings with the same key registered later by another
+// module, unless they are group members
+func withoutOverriddenBindings(bindings []*binding) []*binding {
+	last := make(map[BindingKey]*binding)
+	for _, b := range bindings {
+		if current, ok := last[b.key()]; !b.grouped && (!ok || current.order < b.order) {
+			last[b.key()] = b
+		}
+	}
+	res := make([]*binding, 0, len(bindings))
+	for _, b := range bindings {
+		if b.grouped || installingModule(last[b.key()]) == installingModule(b) {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+// installingModule return the name of the innermost module that installed the binding, "" if it is not in a module
+func installingModule(b *binding) string {
+	if len(b.modules) == 0 {
+		return ""
+	}
+	return b.modules[len(b.modules)-1]
+}
@@ -0,0 +1,65 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverridePolicy(t *testing.T) {
+	appModule := Module("app",
+		Provide(func() *Color { return &Color{name: "red"} }),
+		Provide(func() *Color { return &Color{name: "red"} }, IntoGroup("palette")),
+		Provide(func() *Rectangle { return &Rectangle{} }, As(Type[Shape]())),
+		Provide(func() *Circle { return &Circle{} }, As(Type[Shape]()), Named("handlers")),
+		Provide(func() *Rectangle { return &Rectangle{} }, As(Type[Shape]()), Named("handlers")),
+	)
+	testModule := Module("test",
+		Provide(func() *Color { return &Color{name: "blue"} }),
+		Provide(func() *Color { return &Color{name: "blue"} }, IntoGroup("palette")),
+	)
+	ctx := context.Background()
+
+	t.Run("LastRegisteredWins should keep the last binding of each key", func(t *testing.T) {
+		injector, err := NewInjector(OverridePolicy(LastRegisteredWins), appModule, testModule)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(c *Color, s Shape) {
+			assert.Equal(t, "blue", c.name)
+			assert.Equal(t, "rectangle", s.Name())
+		})
+		assert.Nil(t, err)
+
+		type PaletteParams struct {
+			Params
+			Palette []*Color `inject:"palette"`
+		}
+		err = injector.Invoke(ctx, func(p PaletteParams) {
+			assert.Len(t, p.Palette, 2)
+		})
+		assert.Nil(t, err)
+
+		type HandlersParams struct {
+			Params
+			Handlers []Shape `inject:"handlers"`
+		}
+		err = injector.Invoke(ctx, func(p HandlersParams) {
+			assert.Len(t, p.Handlers, 2, "bindings of a key registered by one module should be kept")
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Unknown override modes should be rejected", func(t *testing.T) {
+		_, err := NewInjector(OverridePolicy("first-wins"))
+		assert.ErrorContains(t, err, `unknown override mode "first-wins"`)
+	})
+
+	t.Run("MultipleBindingsAllowed should keep all bindings", func(t *testing.T) {
+		injector, err := NewInjector(appModule, testModule)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(_ *Color) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorContains(t, err, "found multiple bindings expected one")
+	})
+}
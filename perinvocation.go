@@ -0,0 +1,28 @@
+package goinject
+
+import "context"
+
+// PerInvocation is the scope of bindings created at most once per invocation (Invoke, InvokeBestEffort, or each
+// function of InvokeAllFns): all the injections of an invocation resolution tree, including the nested invocations
+// of providers given the InvocationContext, share the same instance. Instances are destroyed when the invocation
+// returns, and cannot be resolved afterward (e.g. by a Provider function kept by a singleton).
+const PerInvocation = "inject.PerInvocation"
+
+type perInvocationScopeKey struct{}
+
+func newPerInvocationScope() Scope {
+	return NewContextualScope(perInvocationScopeKey{})
+}
+
+// withPerInvocationScope return ctx with the PerInvocation scope enabled and the function shutting it down when
+// the invocation returns. Nested invocations share the scope of the outermost one.
+func withPerInvocationScope(ctx context.Context) (context.Context, func()) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Value(perInvocationScopeKey{}).(*instanceRegistry); ok {
+		return ctx, func() {}
+	}
+	ctx = WithContextualScopeEnabled(ctx, perInvocationScopeKey{})
+	return ctx, func() { ShutdownContextualScope(ctx, perInvocationScopeKey{}) }
+}
@@ -0,0 +1,53 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Tx struct {
+	ID     int
+	closed bool
+}
+
+func TestPerInvocationScope(t *testing.T) {
+	var created []*Tx
+	type params struct {
+		Params
+		Tx *Tx `inject:""`
+	}
+	injector, err := NewInjector(
+		Provide(func() *Tx {
+			tx := &Tx{ID: len(created) + 1}
+			created = append(created, tx)
+			return tx
+		}, In(PerInvocation), WithDestroy(func(tx *Tx) { tx.closed = true })),
+		Provide(func(_ *Tx) *Child { return &Child{} }, In(PerLookUp)),
+		Provide(func(ctx InvocationContext, injector *Injector) (*Parent, error) {
+			return &Parent{}, injector.Invoke(ctx, func(tx *Tx) {
+				assert.Equal(t, len(created), tx.ID)
+			})
+		}, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	var provider Provider[*Tx]
+	for i := 1; i <= 2; i++ {
+		err = injector.Invoke(ctx, func(tx *Tx, p params, _ *Child, _ *Parent, txs Provider[*Tx]) {
+			assert.Equal(t, i, tx.ID)
+			assert.Same(t, tx, p.Tx)
+			assert.False(t, tx.closed)
+			provider = txs
+		})
+		assert.Nil(t, err)
+	}
+	assert.Len(t, created, 2)
+	assert.True(t, created[0].closed)
+	assert.True(t, created[1].closed)
+
+	_, err = provider(ctx)
+	assert.ErrorContains(t, err, "Scope is not active")
+}
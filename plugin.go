@@ -0,0 +1,63 @@
+package goinject
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type pluginModuleOption struct {
+	name string
+	load func() ([]Option, error)
+}
+
+func (o *pluginModuleOption) apply(mod *configuration) error {
+	options, err := o.load()
+	if err != nil {
+		return newInjectorConfigurationError(fmt.Sprintf("failed to load plugin module %s", o.name), err)
+	}
+	return installPlugin(mod, o.name, options)
+}
+
+// PluginModule return an Option installing the options returned by load in a module with the given name. Unlike a
+// Module, the bindings of the plugin must not conflict with the bindings configured before this Option (except
+// fallbacks and group members). It backs the plugin package, which loads the options from Go plugins: it is kept
+// apart so that the binaries that do not load plugins do not link the standard plugin package.
+func PluginModule(name string, load func() ([]Option, error)) Option {
+	return &pluginModuleOption{name: name, load: load}
+}
+
+// installPlugin install the options of a plugin in a module with the given name. It fails if a binding of the
+// plugin has the same key as an already configured binding, unless one of them is a fallback or both are members
+// of a group.
+func installPlugin(mod *configuration, name string, options []Option) error {
+	existing := make(map[BindingKey][]*binding, len(mod.bindings))
+	installed := make(map[*binding]bool, len(mod.bindings))
+	for b := range mod.bindings {
+		existing[b.key()] = append(existing[b.key()], b)
+		installed[b] = true
+	}
+	if err := Module(name, options...).apply(mod); err != nil {
+		return err
+	}
+	var conflicts []string
+	for b := range mod.bindings {
+		if installed[b] || b.fallback {
+			continue
+		}
+		for _, other := range existing[b.key()] {
+			if !other.fallback && !(b.grouped && other.grouped) {
+				conflicts = append(conflicts, fmt.Sprintf("binding %s is already bound at %s", b.key(), other.location))
+				break
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return newInjectorConfigurationError(
+			fmt.Sprintf("plugin module %s conflicts with configured bindings:\n%s", name, strings.Join(conflicts, "\n")),
+			nil,
+		)
+	}
+	return nil
+}
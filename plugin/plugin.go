@@ -0,0 +1,44 @@
+// Package plugin installs the options exported by Go plugins (.so files) in an injector.
+//
+// It is kept out of the goinject package because importing the standard plugin package makes binaries link libc
+// dynamically when cgo is enabled: only the binaries loading plugins pay for it.
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+	goplugin "plugin"
+	"strings"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// Symbol is the name of the function looked up in Go plugins by Load, it must have the type func() []goinject.Option
+const Symbol = "InjectOptions"
+
+// Load return an Option opening the Go plugin at path and installing the options returned by its exported
+// InjectOptions function (see Symbol) in a module named "plugin:<file name>". The bindings of the plugin must not
+// conflict with the bindings configured before this Option (except fallbacks and group members), see
+// goinject.PluginModule. Go plugins are only supported on some platforms, see the standard plugin package.
+func Load(path string) goinject.Option {
+	return goinject.PluginModule(moduleName(path), func() ([]goinject.Option, error) {
+		p, err := goplugin.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open plugin %s: %w", path, err)
+		}
+		symbol, err := p.Lookup(Symbol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load plugin %s: %w", path, err)
+		}
+		injectOptions, ok := symbol.(func() []goinject.Option)
+		if !ok {
+			return nil, fmt.Errorf("symbol %s of plugin %s must be a func() []goinject.Option, got %T", Symbol, path, symbol)
+		}
+		return injectOptions(), nil
+	})
+}
+
+// moduleName return the name of the module installing the options of the plugin at path
+func moduleName(path string) string {
+	return "plugin:" + strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
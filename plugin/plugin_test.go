@@ -0,0 +1,21 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("Should fail to load a missing plugin", func(t *testing.T) {
+		_, err := goinject.NewInjector(Load("testdata/missing.so"))
+		assert.IsType(t, err, &goinject.ConfigurationReport{})
+		assert.ErrorContains(t, err, "failed to open plugin testdata/missing.so")
+	})
+
+	t.Run("Module name should be derived from the file name", func(t *testing.T) {
+		assert.Equal(t, "plugin:payments", moduleName("/opt/app/plugins/payments.so"))
+	})
+}
@@ -0,0 +1,52 @@
+package goinject
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadPlugin(t *testing.T) {
+	t.Run("Should install plugin options in a module", func(t *testing.T) {
+		var modules []string
+		injector, err := NewInjector(
+			Provide(func() *Color { return &Color{name: "red"} }, IntoGroup("colors")),
+			NoOpFallback[Shape](&Square{}),
+			PluginModule("plugin:shapes", func() ([]Option, error) {
+				return []Option{
+					Provide(func() *Color { return &Color{name: "blue"} }, IntoGroup("colors")),
+					Provide(func() *Rectangle { return &Rectangle{} }, As(Type[Shape]())),
+					Provide(func() *Parent { return &Parent{} }),
+				}, nil
+			}),
+		)
+		assert.Nil(t, err)
+		for _, b := range injector.allBindings() {
+			if b.key() == KeyOf[*Parent]() {
+				modules = b.modules
+			}
+		}
+		assert.Equal(t, []string{"plugin:shapes"}, modules)
+	})
+
+	t.Run("Should fail if plugin bindings conflict with configured bindings", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *Parent { return &Parent{} }),
+			PluginModule("plugin:parent", func() ([]Option, error) {
+				return []Option{Provide(func() *Parent { return &Parent{} })}, nil
+			}),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.ErrorContains(t, err, "plugin module plugin:parent conflicts with configured bindings:\n"+
+			"binding *goinject.Parent is already bound at ")
+	})
+
+	t.Run("Should fail if the plugin cannot be loaded", func(t *testing.T) {
+		_, err := NewInjector(PluginModule("plugin:missing", func() ([]Option, error) {
+			return nil, errors.New("no such file")
+		}))
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.ErrorContains(t, err, "failed to load plugin module plugin:missing:\nno such file")
+	})
+}
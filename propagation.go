@@ -0,0 +1,102 @@
+package goinject
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+type enabledScopesContextKey struct{}
+
+// enabledScope is an element of the list of contextual scopes enabled in a context, innermost first
+type enabledScope struct {
+	key      any
+	registry *instanceRegistry
+	parent   *enabledScope
+}
+
+type propagationContextKey struct{}
+
+// propagation hold the registries a propagated context uses, until ReleaseScopes
+type propagation struct {
+	acquired []*instanceRegistry
+	forked   []*instanceRegistry
+	release  sync.Once
+}
+
+// PropagateOption configure PropagateScopes
+type PropagateOption func(forked *[]any)
+
+// Fork make PropagateScopes enable a new activation of the contextual scopes of the given keys instead of sharing
+// the current one: the goroutine gets its own instances, destroyed by ReleaseScopes
+func Fork(keys ...any) PropagateOption {
+	return func(forked *[]any) {
+		*forked = append(*forked, keys...)
+	}
+}
+
+// PropagateScopes return a context sharing the contextual scopes (including PerInvocation) enabled in ctx, for a
+// goroutine outliving the code that enabled them (e.g. a goroutine spawned within a request). Shutting down a
+// shared scope, such as with ShutdownContextualScope, is deferred until every context propagating it is released
+// with ReleaseScopes: destroy callbacks run once, when the last user finishes.
+// The returned context must be released exactly once, ReleaseScopes ignore the following calls.
+func PropagateScopes(ctx context.Context, opts ...PropagateOption) context.Context {
+	var forked []any
+	for _, opt := range opts {
+		opt(&forked)
+	}
+	p := &propagation{}
+	seen := make(map[any]bool)
+	for s, _ := ctx.Value(enabledScopesContextKey{}).(*enabledScope); s != nil; s = s.parent {
+		if seen[s.key] || slices.Contains(forked, s.key) {
+			continue
+		}
+		seen[s.key] = true
+		if registry, ok := ctx.Value(s.key).(*instanceRegistry); ok && registry == s.registry {
+			registry.acquire()
+			p.acquired = append(p.acquired, registry)
+		}
+	}
+	for _, key := range forked {
+		ctx = WithContextualScopeEnabled(ctx, key)
+		p.forked = append(p.forked, ctx.Value(key).(*instanceRegistry))
+	}
+	return context.WithValue(ctx, propagationContextKey{}, p)
+}
+
+// ReleaseScopes release the scopes of a context returned by PropagateScopes: forked scopes are shut down, shared
+// scopes are shut down if their shutdown was requested and this was their last user
+func ReleaseScopes(ctx context.Context) {
+	p, ok := ctx.Value(propagationContextKey{}).(*propagation)
+	if !ok {
+		return
+	}
+	p.release.Do(func() {
+		for _, registry := range p.forked {
+			registry.shutdown()
+		}
+		for _, registry := range p.acquired {
+			registry.release()
+		}
+	})
+}
+
+// GoroutineGroup is a group of goroutines such as errgroup.Group
+type GoroutineGroup interface {
+	Go(fn func() error)
+}
+
+// GoWithScopes run fn in a goroutine of group, with a context propagating the scopes of ctx (see PropagateScopes)
+// that is released when fn returns
+func GoWithScopes(
+	ctx context.Context,
+	group GoroutineGroup,
+	fn func(ctx context.Context) error,
+	opts ...PropagateOption,
+) {
+	propagated := PropagateScopes(ctx, opts...)
+	group.Go(func() error {
+		defer ReleaseScopes(propagated)
+		return fn(propagated)
+	})
+}
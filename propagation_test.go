@@ -0,0 +1,83 @@
+package goinject
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitGroup is a minimal GoroutineGroup, such as errgroup.Group
+type waitGroup struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+func (g *waitGroup) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := fn(); err != nil {
+			g.mu.Lock()
+			g.errs = append(g.errs, err)
+			g.mu.Unlock()
+		}
+	}()
+}
+
+func TestPropagateScopes(t *testing.T) {
+	var destroyed []*Request
+	var mu sync.Mutex
+	injector, err := NewInjector(
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+		Provide(func() *Request { return &Request{} }, In("request"), WithDestroy(func(r *Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			destroyed = append(destroyed, r)
+		})),
+	)
+	assert.Nil(t, err)
+
+	t.Run("Shared scopes should be destroyed when the last user finishes", func(t *testing.T) {
+		destroyed = nil
+		ctx := WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+		var request *Request
+		assert.Nil(t, injector.Invoke(ctx, func(r *Request) { request = r }))
+
+		release := make(chan struct{})
+		group := &waitGroup{}
+		GoWithScopes(ctx, group, func(ctx context.Context) error {
+			<-release
+			return injector.Invoke(ctx, func(r *Request) {
+				assert.Same(t, request, r)
+			})
+		})
+		ShutdownContextualScope(ctx, requestScopeKeyVal)
+		assert.Empty(t, destroyed)
+
+		close(release)
+		group.wg.Wait()
+		assert.Empty(t, group.errs)
+		assert.Equal(t, []*Request{request}, destroyed)
+	})
+
+	t.Run("Forked scopes should have their own instances", func(t *testing.T) {
+		destroyed = nil
+		ctx := WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+		var request *Request
+		assert.Nil(t, injector.Invoke(ctx, func(r *Request) { request = r }))
+
+		forked := PropagateScopes(ctx, Fork(requestScopeKeyVal))
+		var forkedRequest *Request
+		assert.Nil(t, injector.Invoke(forked, func(r *Request) { forkedRequest = r }))
+		assert.NotSame(t, request, forkedRequest)
+		ReleaseScopes(forked)
+		ReleaseScopes(forked)
+		assert.Equal(t, []*Request{forkedRequest}, destroyed)
+
+		ShutdownContextualScope(ctx, requestScopeKeyVal)
+		assert.Equal(t, []*Request{forkedRequest, request}, destroyed)
+	})
+}
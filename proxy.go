@@ -0,0 +1,109 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+var callGuardReflectType = reflect.TypeFor[*CallGuard]()
+
+// callPolicy wrap a method call of a proxied binding, see CallGuard
+type callPolicy func(ctx context.Context, guard *CallGuard, method string, call func(ctx context.Context) error) error
+
+// CallGuard apply the call policies of a binding (e.g. WithCircuitBreaker) to the method calls of its proxy, see
+// WithProxy
+type CallGuard struct {
+	injector *Injector
+	key      BindingKey
+	policies []callPolicy // outermost first
+	binding  *binding     // set if calls are checked, see CheckInterfaceBindings
+}
+
+// Key return the key of the proxied binding
+func (g *CallGuard) Key() BindingKey {
+	return g.key
+}
+
+// Call run call through the policies of the binding, method is the name of the called method used in errors and
+// events. The call must return the error returned by the proxied method, if any.
+func (g *CallGuard) Call(ctx context.Context, method string, call func(ctx context.Context) error) error {
+	for i := len(g.policies) - 1; i >= 0; i-- {
+		policy, next := g.policies[i], call
+		call = func(ctx context.Context) error {
+			return policy(ctx, g, method, next)
+		}
+	}
+	if g.binding != nil {
+		return g.checkedCall(ctx, method, call)
+	}
+	return call(ctx)
+}
+
+type withProxyAnnotation struct {
+	proxy any
+}
+
+func (a *withProxyAnnotation) apply(b *binding) error {
+	proxyFnVal := reflect.ValueOf(a.proxy)
+	if proxyFnVal.Kind() != reflect.Func ||
+		proxyFnVal.Type().NumIn() != 2 ||
+		proxyFnVal.Type().In(0) != callGuardReflectType ||
+		proxyFnVal.Type().NumOut() != 1 ||
+		proxyFnVal.Type().In(1) != proxyFnVal.Type().Out(0) {
+		return newInjectorConfigurationError(
+			"argument of WithProxy must be a function with a *CallGuard and the binding type as arguments "+
+				"returning the binding type",
+			nil,
+		)
+	}
+	b.proxy = proxyFnVal
+	return nil
+}
+
+// WithProxy return an annotation declaring the proxy of the binding: a function taking a *CallGuard and the
+// instance, returning the value injected in place of the instance (usually a thin facade implementing the same
+// interface and running each method through CallGuard.Call). Call policies such as WithCircuitBreaker need a
+// proxy, as Go reflection cannot synthesize methods at runtime.
+// The proxy is applied at each injection, the instance itself is kept in the scope, destroyed and given to
+// lifecycle hooks.
+func WithProxy(proxy any) Annotation {
+	return &withProxyAnnotation{proxy: proxy}
+}
+
+// checkProxy verify that the proxy (if any) returns the binding type, and that call policies have a proxy
+func (b *binding) checkProxy() error {
+	if !b.proxy.IsValid() {
+		if len(b.callPolicies) > 0 {
+			return newInjectorConfigurationError("call policies require a proxy declared with WithProxy", nil)
+		}
+		return nil
+	}
+	if b.proxy.Type().In(1) != b.typeof {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("proxy of WithProxy must take and return the binding type %s", b.typeof), nil)
+	}
+	return nil
+}
+
+// getInjectedInstance return the instance of the binding to inject, that is its proxy if it has one
+func (injector *Injector) getInjectedInstance(ctx context.Context, b *binding) (reflect.Value, error) {
+	if b.deprecation != "" {
+		injector.warnDeprecated(ctx, b)
+	}
+	if b.lazyProxy != nil {
+		return b.lazyProxy.create(injector, ctx, b), nil
+	}
+	instance, err := injector.getScopedInstanceFromBinding(ctx, b)
+	if b.sensitive && injector.auditResolutions {
+		err = injector.recordResolution(ctx, b, err)
+	}
+	if err != nil || !b.proxy.IsValid() || !instance.IsValid() {
+		return instance, err
+	}
+	guard := &CallGuard{injector: injector, key: b.key(), policies: b.callPolicies}
+	if injector.checkInterfaces {
+		guard.binding = b
+	}
+	return b.proxy.Call([]reflect.Value{reflect.ValueOf(guard), instance.Convert(b.typeof)})[0], nil
+}
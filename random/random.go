@@ -0,0 +1,84 @@
+// Package random provides a RandSource binding so that providers relying on randomness (jitter, sampling, ...)
+// can be tested with a deterministic source.
+//
+// Production code installs Module and depends on RandSource, tests override it container-wide with WithSource:
+//
+//	injector, err := goinject.NewInjector(app.Module(), random.WithSource(random.NewSeeded(42)))
+package random
+
+import (
+	"math/rand/v2"
+	"sync"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// ModuleName is the name of the module installed by Module
+const ModuleName = "random"
+
+// RandSource is a source of pseudo-random numbers, safe for concurrent use
+type RandSource interface {
+	Int64() int64
+	IntN(n int) int
+	Float64() float64
+}
+
+// Module return a goinject module providing the RandSource of the math/rand/v2 package
+func Module() goinject.Option {
+	return goinject.Module(ModuleName,
+		goinject.Provide(func() RandSource { return Global() }, goinject.In(goinject.Singleton)),
+	)
+}
+
+// WithSource return an Option replacing the RandSource provided by Module (whether it is installed before or
+// after this option) with the given one
+func WithSource(source RandSource) goinject.Option {
+	return goinject.ReplaceModule(ModuleName,
+		goinject.Provide(func() RandSource { return source }, goinject.In(goinject.Singleton)),
+	)
+}
+
+type globalSource struct{}
+
+// Global return the RandSource using the top-level functions of the math/rand/v2 package
+func Global() RandSource {
+	return globalSource{}
+}
+
+func (globalSource) Int64() int64     { return rand.Int64() }
+func (globalSource) IntN(n int) int   { return rand.IntN(n) }
+func (globalSource) Float64() float64 { return rand.Float64() }
+
+// Seeded is a RandSource returning the same sequence of numbers for the same seed
+type Seeded struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+var _ RandSource = new(Seeded)
+
+// NewSeeded return a Seeded source
+func NewSeeded(seed uint64) *Seeded {
+	return &Seeded{rand: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// Int64 return a non-negative pseudo-random int64
+func (s *Seeded) Int64() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Int64()
+}
+
+// IntN return a pseudo-random number in [0,n), it panics if n <= 0
+func (s *Seeded) IntN(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.IntN(n)
+}
+
+// Float64 return a pseudo-random number in [0.0,1.0)
+func (s *Seeded) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Float64()
+}
@@ -0,0 +1,38 @@
+package random
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+func TestRandSource(t *testing.T) {
+	t.Run("Should provide the global source", func(t *testing.T) {
+		injector, err := goinject.NewInjector(Module())
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func(source RandSource) {
+			assert.Equal(t, Global(), source)
+			assert.Less(t, source.IntN(10), 10)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should override the source with a seeded one", func(t *testing.T) {
+		draw := func() []int {
+			injector, err := goinject.NewInjector(Module(), WithSource(NewSeeded(42)))
+			assert.Nil(t, err)
+			var res []int
+			err = injector.Invoke(context.Background(), func(source RandSource) {
+				for i := 0; i < 5; i++ {
+					res = append(res, source.IntN(1000))
+				}
+			})
+			assert.Nil(t, err)
+			return res
+		}
+		assert.Equal(t, draw(), draw())
+	})
+}
@@ -0,0 +1,75 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotStarted is returned by Injector.Ready while Injector.Start did not complete successfully
+var ErrNotStarted = errors.New("injector is not started")
+
+// ReadinessCheck return an annotation that declare a readiness check (a function taking a context.Context and the
+// provided type, returning an error) evaluated by Injector.Ready. Only singleton bindings can have readiness checks.
+func ReadinessCheck(check any) Annotation {
+	return &lifecycleHookAnnotation{name: "ReadinessCheck", hook: check, set: func(b *binding, hook lifecycleHook) {
+		b.readinessCheck = hook
+	}}
+}
+
+// Ready return nil if the injector is ready to serve: eager singletons are built, Start completed successfully and
+// all readiness checks pass. Otherwise, it returns ErrNotStarted or the joined errors of failing checks.
+func (injector *Injector) Ready(ctx context.Context) error {
+	injector.lifecycle.mu.Lock()
+	startSucceed := injector.lifecycle.startSucceed
+	created := append([]*binding(nil), injector.lifecycle.created...)
+	injector.lifecycle.mu.Unlock()
+
+	if !startSucceed {
+		return ErrNotStarted
+	}
+	var errs []error
+	for _, b := range created {
+		if b.readinessCheck == nil {
+			continue
+		}
+		instance, err := injector.getScopedInstanceFromBinding(ctx, b)
+		if err == nil {
+			err = b.readinessCheck(ctx, instance)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("readiness check of binding %s failed: %w", b.key(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReadyChan evaluate Ready every interval (and immediately) and send each result on the returned channel, which is
+// closed when ctx is done. Results are dropped while the receiver is not ready to receive them.
+// It is intended to back readiness probes. If interval is not positive, the channel only holds an error and is closed.
+func (injector *Injector) ReadyChan(ctx context.Context, interval time.Duration) <-chan error {
+	res := make(chan error, 1)
+	if interval <= 0 {
+		res <- newInvalidInputError(fmt.Sprintf("interval of ReadyChan must be positive, got %s", interval))
+		close(res)
+		return res
+	}
+	go func() {
+		defer close(res)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case res <- injector.Ready(ctx):
+			default:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return res
+}
@@ -0,0 +1,57 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Warmable struct {
+	warm atomic.Bool
+}
+
+func TestReady(t *testing.T) {
+	coldErr := errors.New("cache is cold")
+	injector, err := NewInjector(
+		Provide(func() *Warmable { return &Warmable{} },
+			OnStart(func(_ context.Context, _ *Warmable) error { return nil }),
+			ReadinessCheck(func(_ context.Context, w *Warmable) error {
+				if !w.warm.Load() {
+					return coldErr
+				}
+				return nil
+			})),
+	)
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, injector.Ready(ctx), ErrNotStarted)
+	assert.Nil(t, injector.Start(ctx))
+
+	err = injector.Ready(ctx)
+	assert.ErrorIs(t, err, coldErr)
+	assert.ErrorContains(t, err, "readiness check of binding *goinject.Warmable failed")
+
+	readyCtx, cancel := context.WithCancel(ctx)
+	results := injector.ReadyChan(readyCtx, time.Millisecond)
+	assert.ErrorIs(t, <-results, coldErr)
+
+	err = injector.Invoke(ctx, func(w *Warmable) { w.warm.Store(true) })
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool { return <-results == nil }, time.Second, time.Millisecond)
+	cancel()
+	for range results { //nolint:revive // drain until closed
+	}
+
+	assert.Nil(t, injector.Stop(ctx))
+	assert.ErrorIs(t, injector.Ready(ctx), ErrNotStarted)
+
+	results = injector.ReadyChan(ctx, 0)
+	assert.ErrorContains(t, <-results, "interval of ReadyChan must be positive, got 0s")
+	_, open := <-results
+	assert.False(t, open)
+}
@@ -0,0 +1,140 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type refreshableAnnotation struct{}
+
+func (a *refreshableAnnotation) apply(b *binding) error {
+	b.refreshable = true
+	return nil
+}
+
+// Refreshable return an annotation marking a singleton binding to be re-created when one of its dependencies
+// is refreshed with Injector.Refresh
+func Refreshable() Annotation {
+	return &refreshableAnnotation{}
+}
+
+// BindingRefreshedEvent is notified when the instance of a singleton binding is refreshed
+type BindingRefreshedEvent struct {
+	Key BindingKey
+}
+
+func (BindingRefreshedEvent) isEvent() {}
+
+// RefreshFailedEvent is notified when a refresh triggered by the injector itself (e.g. by a watched configuration
+// file) failed
+type RefreshFailedEvent struct {
+	Key BindingKey
+	Err error
+}
+
+func (RefreshFailedEvent) isEvent() {}
+
+// Refresh re-create the singleton instances of the bindings with the given key, then the Refreshable singletons
+// depending (transitively) on them.
+// New instances are created before the current ones are discarded: if their creation fails, the error is returned
+// and the current instances are kept. Instances already injected are not updated, dependents must be Refreshable
+// or use a Provider to get the new instance. Discarded instances are destroyed (see WithDestroy) once replaced, the
+// current instance of a dependent whose creation fails is kept.
+func (injector *Injector) Refresh(ctx context.Context, key BindingKey) error {
+	if err := injector.checkRunning("Refresh"); err != nil {
+		return err
+	}
+	bindings := injector.findBindingsForAnnotatedType(key.Type, key.Annotation)
+	if len(bindings) == 0 {
+		return newInjectionError(key.Type, key.Annotation, fmt.Errorf("did not found binding, expected at least one"))
+	}
+	for _, b := range bindings {
+		if b.scope != Singleton {
+			return newInjectionError(key.Type, key.Annotation,
+				fmt.Errorf("cannot refresh binding in scope %q, only singletons can be refreshed", b.scope))
+		}
+	}
+	instances := make([]reflect.Value, len(bindings))
+	destroys := make([]func(), len(bindings))
+	for i, b := range bindings {
+		var err error
+		if instances[i], destroys[i], err = injector.createInstance(ctx, withCreationStep(ctx, b), b); err != nil {
+			for _, destroy := range destroys[:i] {
+				if destroy != nil {
+					destroy()
+				}
+			}
+			return decorateError(injector.errorDecorators,
+				withResolutionPath(ctx, b.key(), fmt.Errorf("failed to refresh binding: %w", err)))
+		}
+	}
+
+	for i, b := range bindings {
+		injector.replaceSingleton(b, instances[i], destroys[i])
+		injector.notify(BindingRefreshedEvent{Key: b.key()})
+	}
+
+	var errs []error
+	for _, b := range injector.refreshableDependents(key) { // dependencies first
+		val, destroy, err := injector.createInstance(ctx, withCreationStep(ctx, b), b)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to refresh dependent binding %s: %w", b.key(), err))
+			continue
+		}
+		injector.replaceSingleton(b, val, destroy)
+		injector.notify(BindingRefreshedEvent{Key: b.key()})
+	}
+	if len(errs) > 0 {
+		return decorateError(injector.errorDecorators, errors.Join(errs...))
+	}
+	return nil
+}
+
+// replaceSingleton replace the instance of the singleton binding b with val, destroying the replaced instance.
+// destroy is the function destroying val returned by createInstance.
+func (injector *Injector) replaceSingleton(b *binding, val reflect.Value, destroy func()) {
+	registry := injector.singletonScope.instanceRegistry
+	replaced := b.destroyCurrent.Swap(nil)
+	registry.replace(b, Instance(val))
+	if destroy != nil {
+		destroy = sync.OnceFunc(destroy)
+		b.destroyCurrent.Store(&destroy)
+		registry.replaceDestructionCallback(b, b.shutdownOrder, destroy)
+	}
+	if replaced != nil {
+		(*replaced)()
+	}
+}
+
+// refreshableDependents return the Refreshable singleton bindings depending transitively on the given key
+func (injector *Injector) refreshableDependents(key BindingKey) []*binding {
+	return injector.singletonDependents(key, func(b *binding) bool { return b.refreshable })
+}
+
+// singletonDependents return the singleton bindings accepted by filter depending transitively on the given key
+func (injector *Injector) singletonDependents(key BindingKey, filter func(b *binding) bool) []*binding {
+	refreshed := map[BindingKey]bool{key: true}
+	var res []*binding
+	bindings := injector.allBindings()
+	found := true
+	for found {
+		found = false
+		for _, b := range bindings {
+			if !filter(b) || b.scope != Singleton || refreshed[b.key()] {
+				continue
+			}
+			for _, dependency := range dependenciesOf(b.provider.Type()) {
+				if refreshed[dependency] {
+					refreshed[b.key()] = true
+					res = append(res, b)
+					found = true
+					break
+				}
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshShouldDestroyReplacedInstances(t *testing.T) {
+	var destroyed []string
+	version := 0
+	injector, err := NewInjector(
+		Provide(func() *AppConfig {
+			version++
+			return &AppConfig{ListenAddr: string(rune('0' + version))}
+		}, WithDestroy(func(c *AppConfig) { destroyed = append(destroyed, "config "+c.ListenAddr) })),
+		Provide(func(c *AppConfig) *AppServer { return &AppServer{config: c} }, Refreshable(),
+			WithDestroy(func(s *AppServer) { destroyed = append(destroyed, "server "+s.config.ListenAddr) })),
+	)
+	assert.Nil(t, err)
+	ctx := context.Background()
+	callbacks := injector.singletonScope.instanceRegistry.pendingDestroyCallbacks()
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, injector.Refresh(ctx, KeyOf[*AppConfig]()))
+	}
+	assert.Equal(t, []string{"config 1", "server 1", "config 2", "server 2", "config 3", "server 3"}, destroyed)
+	assert.Equal(t, callbacks+2, injector.singletonScope.instanceRegistry.pendingDestroyCallbacks(),
+		"refreshed instances should not pile up destroy callbacks")
+
+	destroyed = nil
+	injector.Shutdown()
+	assert.Equal(t, []string{"server 4", "config 4"}, destroyed)
+}
@@ -0,0 +1,90 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// Registry[T] may be requested (as a function argument or a Params field, tagged with a group or an annotation
+// like a slice of T) to enumerate the bindings of T without instantiating them: registry-style components (e.g. a
+// command dispatcher) know what exists at construction time and defer the creation of each instance until needed.
+// The bindings are the ones active when the Registry is injected, in registration order.
+type Registry[T any] struct {
+	injector *Injector
+	bindings []*binding
+}
+
+// Bindings return the description of the bindings of the registry
+func (r Registry[T]) Bindings() []BindingInfo {
+	res := make([]BindingInfo, len(r.bindings))
+	for i, b := range r.bindings {
+		res[i] = BindingInfo{Key: b.key(), Scope: b.scope}
+	}
+	return res
+}
+
+// Len return the number of bindings of the registry
+func (r Registry[T]) Len() int {
+	return len(r.bindings)
+}
+
+// Resolve return the instance of the i-th binding of the registry (see Bindings), created within its scope if
+// needed
+func (r Registry[T]) Resolve(ctx context.Context, i int) (T, error) {
+	var res T
+	if r.injector == nil {
+		return res, errors.New("registry was not created by the injector")
+	}
+	if i < 0 || i >= len(r.bindings) {
+		return res, newInvalidInputError(fmt.Sprintf("binding index %d out of registry of %d bindings", i, len(r.bindings)))
+	}
+	instance, err := r.injector.getInjectedInstance(ctx, r.bindings[i])
+	if err != nil || !instance.IsValid() {
+		return res, err
+	}
+	res, _ = instance.Interface().(T)
+	return res, nil
+}
+
+// registryValue is implemented by pointers to Registry types
+type registryValue interface {
+	registeredType() reflect.Type
+	bind(injector *Injector, bindings []*binding)
+}
+
+var registryValueReflectType = reflect.TypeFor[registryValue]()
+
+func (r *Registry[T]) registeredType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (r *Registry[T]) bind(injector *Injector, bindings []*binding) {
+	r.injector = injector
+	r.bindings = bindings
+}
+
+// isRegistryType tell if t is a Registry type
+func isRegistryType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(registryValueReflectType)
+}
+
+// registryElemType return the type of the bindings of the Registry type t
+func registryElemType(t reflect.Type) reflect.Type {
+	return reflect.New(t).Interface().(registryValue).registeredType()
+}
+
+// createRegistryValue create a Registry of type t with the active bindings of its type and annotation
+func (injector *Injector) createRegistryValue(ctx context.Context, t reflect.Type, annotation string) reflect.Value {
+	bindings := injector.activeBindings(ctx, injector.findBindingsForAnnotatedType(registryElemType(t), annotation))
+	bindings = slices.Clone(bindings)
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return bindings[i].order < bindings[j].order
+	})
+	res := reflect.New(t)
+	res.Interface().(registryValue).bind(injector, bindings)
+	return res.Elem()
+}
@@ -0,0 +1,61 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type colorRegistry struct {
+	colors Registry[*Color]
+}
+
+func TestRegistry(t *testing.T) {
+	created := map[string]int{}
+	newColor := func(name string) func() *Color {
+		return func() *Color {
+			created[name]++
+			return &Color{name: name}
+		}
+	}
+	type RegistryParams struct {
+		Params
+		Colors Registry[*Color] `inject:"colors"`
+	}
+	injector, err := NewInjector(
+		Provide(newColor("red"), IntoGroup("colors")),
+		Provide(newColor("blue"), IntoGroup("colors"), In(PerLookUp)),
+		Provide(func(p RegistryParams) *colorRegistry { return &colorRegistry{colors: p.Colors} }),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(r *colorRegistry) {
+		assert.Equal(t, 2, r.colors.Len())
+		assert.Equal(t, []BindingInfo{
+			{Key: BindingKey{Type: KeyOf[*Color]().Type, Annotation: "colors"}, Scope: Singleton},
+			{Key: BindingKey{Type: KeyOf[*Color]().Type, Annotation: "colors"}, Scope: PerLookUp},
+		}, r.colors.Bindings())
+		assert.Equal(t, 0, created["blue"])
+
+		blue, err := r.colors.Resolve(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "blue", blue.name)
+		assert.Equal(t, 1, created["blue"])
+
+		_, err = r.colors.Resolve(ctx, 2)
+		assert.NotNil(t, err)
+	})
+	assert.Nil(t, err)
+}
+
+func TestRegistryShouldBeEmptyWithoutBindings(t *testing.T) {
+	injector, err := NewInjector()
+	assert.Nil(t, err)
+	err = injector.Invoke(context.Background(), func(r Registry[*Color]) {
+		assert.Equal(t, 0, r.Len())
+		assert.Empty(t, r.Bindings())
+	})
+	assert.Nil(t, err)
+}
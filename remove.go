@@ -0,0 +1,90 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// RemoveOption configure Injector.Remove
+type RemoveOption interface {
+	applyRemove(*removeConfiguration)
+}
+
+type removeConfiguration struct {
+	force bool
+}
+
+type forceEvictionOption struct{}
+
+func (o *forceEvictionOption) applyRemove(config *removeConfiguration) {
+	config.force = true
+}
+
+// ForceEviction return a RemoveOption removing bindings even if their singleton instance was created, the
+// instance is evicted and destroyed when the injector is shut down. If it was started, its stop hook is run by the
+// next Injector.Stop, which then destroys it.
+func ForceEviction() RemoveOption {
+	return &forceEvictionOption{}
+}
+
+// Remove remove the bindings with the given key from the injector, so that long-running processes can retire
+// plugin bindings. It fails if a singleton instance of one of the bindings was created, unless the
+// ForceEviction option is given.
+// Instances already injected are not affected, and lifecycle hooks of removed bindings are not run anymore, except
+// the stop hooks of started singletons evicted with ForceEviction.
+func (injector *Injector) Remove(key BindingKey, opts ...RemoveOption) error {
+	if err := injector.checkRunning("Remove"); err != nil {
+		return err
+	}
+	config := &removeConfiguration{}
+	for _, opt := range opts {
+		opt.applyRemove(config)
+	}
+
+	injector.bindingsMu.Lock()
+	defer injector.bindingsMu.Unlock()
+	bindings := injector.bindings[key.Type][key.Annotation]
+	if len(bindings) == 0 || key.Type == reflect.TypeFor[*Injector]() {
+		return newInjectionError(key.Type, key.Annotation, fmt.Errorf("did not found binding, expected at least one"))
+	}
+	registry := injector.singletonScope.instanceRegistry
+	if !config.force {
+		for _, b := range bindings {
+			if b.scope == Singleton && registry.has(b) {
+				return newInjectionError(key.Type, key.Annotation,
+					fmt.Errorf("cannot remove binding with a live singleton instance without ForceEviction"))
+			}
+		}
+	}
+
+	delete(injector.bindings[key.Type], key.Annotation)
+	injector.bindingsChanged()
+	for _, b := range bindings {
+		b.removed.Store(true)
+		delete(injector.degraded, b)
+		injector.eagerBindings = slices.DeleteFunc(injector.eagerBindings, func(e *binding) bool { return e == b })
+		var instance reflect.Value
+		if b.scope == Singleton {
+			instance = injector.takeSingleton(b)
+		}
+		injector.lifecycle.retire(b, instance)
+	}
+	return nil
+}
+
+// takeSingleton evict the singleton instance of b and return it, unless it is being created
+func (injector *Injector) takeSingleton(b *binding) reflect.Value {
+	var instance reflect.Value
+	if entry, ok := injector.singletonScope.instanceRegistry.take(b); ok && entry.lock.TryRLock() {
+		instance = reflect.Value(entry.instance)
+		entry.lock.RUnlock()
+	}
+	if instance.IsValid() && instance.Type() == validatedHolderReflectType {
+		holder := instance.Interface().(*validatedHolder)
+		holder.mu.Lock()
+		defer holder.mu.Unlock()
+		return holder.value
+	}
+	return instance
+}
@@ -0,0 +1,83 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemove(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Remove should retire a binding without live instance", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func() *Color { return &Color{name: "red"} }, Named("plugin"), In(PerLookUp)),
+		)
+		assert.Nil(t, err)
+
+		assert.Nil(t, injector.Remove(KeyOf[*Color]("plugin")))
+		assert.Empty(t, injector.Graph().Bindings)
+	})
+
+	t.Run("Remove should refuse to remove a binding with a live singleton", func(t *testing.T) {
+		injector, err := NewInjector(
+			Provide(func() *Parent { return &Parent{} }),
+		)
+		assert.Nil(t, err)
+
+		err = injector.Remove(KeyOf[*Parent]())
+		assert.ErrorContains(t, err, "cannot remove binding with a live singleton instance without ForceEviction")
+		assert.Nil(t, injector.Invoke(ctx, func(_ *Parent) {}))
+	})
+
+	t.Run("ForceEviction should evict the singleton and destroy it on shutdown", func(t *testing.T) {
+		destroyed := 0
+		started := 0
+		injector, err := NewInjector(
+			Provide(func() *Parent { return &Parent{} },
+				WithDestroy(func(_ *Parent) { destroyed++ }),
+				OnStart(func(_ context.Context, _ *Parent) error {
+					started++
+					return nil
+				})),
+		)
+		assert.Nil(t, err)
+
+		assert.Nil(t, injector.Remove(KeyOf[*Parent](), ForceEviction()))
+		assert.Nil(t, injector.Start(ctx))
+		assert.Equal(t, 0, started)
+		assert.NotNil(t, injector.Invoke(ctx, func(_ *Parent) {}))
+
+		injector.Shutdown()
+		assert.Equal(t, 1, destroyed)
+	})
+
+	t.Run("ForceEviction should stop a started singleton on Stop", func(t *testing.T) {
+		var stopped []*Parent
+		injector, err := NewInjector(
+			Provide(func() *Parent { return &Parent{} },
+				OnStop(func(_ context.Context, p *Parent) error {
+					stopped = append(stopped, p)
+					return nil
+				})),
+		)
+		assert.Nil(t, err)
+		var instance *Parent
+		assert.Nil(t, injector.Invoke(ctx, func(p *Parent) { instance = p }))
+		assert.Nil(t, injector.Start(ctx))
+
+		assert.Nil(t, injector.Remove(KeyOf[*Parent](), ForceEviction()))
+		assert.Empty(t, stopped)
+		assert.Nil(t, injector.Stop(ctx))
+		assert.Equal(t, []*Parent{instance}, stopped)
+	})
+
+	t.Run("Remove should fail for unknown bindings", func(t *testing.T) {
+		injector, err := NewInjector()
+		assert.Nil(t, err)
+
+		assert.NotNil(t, injector.Remove(KeyOf[*Parent]()))
+		assert.NotNil(t, injector.Remove(KeyOf[*Injector]()))
+	})
+}
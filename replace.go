@@ -0,0 +1,53 @@
+package goinject
+
+import "fmt"
+
+type replaceModuleOption struct {
+	name    string
+	options []Option
+}
+
+func (o *replaceModuleOption) apply(mod *configuration) error {
+	if mod.replacedModules == nil {
+		mod.replacedModules = make(map[string]bool)
+	}
+	mod.replacedModules[o.name] = true
+	for b := range mod.bindings {
+		if mod.isReplaced(b) {
+			delete(mod.bindings, b)
+		}
+	}
+
+	mod.replacing++
+	mod.modules = append(mod.modules, o.name)
+	defer func() {
+		mod.replacing--
+		mod.modules = mod.modules[:len(mod.modules)-1]
+	}()
+	for _, opt := range o.options {
+		if err := opt.apply(mod); err != nil {
+			return newInjectorConfigurationError(fmt.Sprintf("error while replacing module %s", o.name), err)
+		}
+	}
+	return nil
+}
+
+// ReplaceModule remove all bindings installed by the Module with the given name (including its sub-modules),
+// whether it is installed before or after this Option, and install the given options in place.
+// It enables whole-subsystem swaps, e.g. a no-op telemetry module in load tests.
+func ReplaceModule(name string, opts ...Option) Option {
+	return &replaceModuleOption{
+		name:    name,
+		options: opts,
+	}
+}
+
+// isReplaced tell if the binding was installed by a replaced module
+func (mod *configuration) isReplaced(b *binding) bool {
+	for _, m := range b.modules {
+		if mod.replacedModules[m] {
+			return true
+		}
+	}
+	return false
+}
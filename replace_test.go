@@ -0,0 +1,60 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Telemetry interface {
+	Name() string
+}
+
+type realTelemetry struct{}
+
+func (t *realTelemetry) Name() string { return "real" }
+
+type noopTelemetry struct{}
+
+func (t *noopTelemetry) Name() string { return "noop" }
+
+func telemetryModule() Option {
+	return Module("telemetry",
+		Provide(func() *realTelemetry { return &realTelemetry{} }, As(Type[Telemetry]())),
+		Module("telemetry.exporters",
+			Provide(func() *Color { return &Color{name: "exporter"} }),
+		),
+	)
+}
+
+func TestReplaceModule(t *testing.T) {
+	replacement := ReplaceModule("telemetry",
+		Provide(func() *noopTelemetry { return &noopTelemetry{} }, As(Type[Telemetry]())),
+	)
+	for name, opts := range map[string][]Option{
+		"replacement after module":  {telemetryModule(), replacement},
+		"replacement before module": {replacement, telemetryModule()},
+		"replacement of nested module": {
+			Module("app", telemetryModule()), replacement,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			injector, err := NewInjector(opts...)
+			assert.Nil(t, err)
+			err = injector.Invoke(context.Background(), func(telemetry Telemetry) {
+				assert.Equal(t, "noop", telemetry.Name())
+			})
+			assert.Nil(t, err)
+			// bindings of sub-modules are removed too
+			err = injector.Invoke(context.Background(), func(_ *Color) {})
+			assert.ErrorContains(t, err, "did not found binding")
+		})
+	}
+
+	t.Run("Should return replacement errors", func(t *testing.T) {
+		_, err := NewInjector(telemetryModule(), ReplaceModule("telemetry", Provide(nil)))
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.Equal(t, "error while replacing module telemetry:\ncannot accept nil provider", err.Error())
+	})
+}
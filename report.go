@@ -0,0 +1,112 @@
+package goinject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// ProblemKind classifies a Problem of a ConfigurationReport
+type ProblemKind string
+
+const (
+	// InvalidOption is the kind of problems raised by options that are not bound to a provider
+	InvalidOption ProblemKind = "invalid_option"
+	// InvalidProvider is the kind of problems raised by a Provide argument or one of its annotations
+	InvalidProvider ProblemKind = "invalid_provider"
+	// InvalidLifecycle is the kind of problems raised by lifecycle hooks and phases declarations
+	InvalidLifecycle ProblemKind = "invalid_lifecycle"
+)
+
+// Problem is a single configuration problem.
+// Binding, Module and Location are empty when they are not known.
+type Problem struct {
+	Kind     ProblemKind `json:"kind"`
+	Binding  string      `json:"binding,omitempty"`  // key of the misconfigured binding
+	Module   string      `json:"module,omitempty"`   // innermost module installing the misconfigured option
+	Location string      `json:"location,omitempty"` // source location (file:line) of the Provide call
+	Message  string      `json:"message"`
+
+	err error
+}
+
+// ConfigurationReport is the error returned by NewInjector when the configuration is invalid.
+// It lists every problem found instead of the first one only, and can be exported as JSON.
+type ConfigurationReport struct {
+	Problems []Problem `json:"problems"`
+}
+
+var _ error = &ConfigurationReport{}
+
+func (r *ConfigurationReport) Error() string {
+	if len(r.Problems) == 1 {
+		return r.Problems[0].err.Error()
+	}
+	messages := make([]string, len(r.Problems))
+	for i, p := range r.Problems {
+		messages[i] = p.err.Error()
+	}
+	return fmt.Sprintf("found %d configuration problems:\n%s", len(r.Problems), strings.Join(messages, "\n"))
+}
+
+// Unwrap return the errors of all problems
+func (r *ConfigurationReport) Unwrap() []error {
+	errs := make([]error, len(r.Problems))
+	for i, p := range r.Problems {
+		errs[i] = p.err
+	}
+	return errs
+}
+
+// JSON return the report as a JSON document
+func (r *ConfigurationReport) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *ConfigurationReport) add(decorators []ErrorDecorator, err error) {
+	problem := Problem{Kind: InvalidOption, Message: err.Error()}
+	var configErr *configurationProblemError
+	if errors.As(err, &configErr) {
+		problem = configErr.problem
+	}
+	problem.err = decorateError(decorators, err)
+	r.Problems = append(r.Problems, problem)
+}
+
+// configurationProblemError attach the Problem description to a configuration error,
+// it does not alter the cause message.
+type configurationProblemError struct {
+	problem Problem
+	cause   error
+}
+
+var _ error = &configurationProblemError{}
+
+func newConfigurationProblemError(
+	kind ProblemKind,
+	key string,
+	modules []string,
+	location string,
+	cause error,
+) *configurationProblemError {
+	problem := Problem{Kind: kind, Binding: key, Location: location, Message: cause.Error()}
+	if len(modules) > 0 {
+		problem.Module = modules[len(modules)-1]
+	}
+	return &configurationProblemError{problem, cause}
+}
+
+func (e *configurationProblemError) Error() string { return e.cause.Error() }
+
+func (e *configurationProblemError) Unwrap() error { return e.cause }
+
+// callerLocation return the file:line of the caller of the function calling callerLocation
+func callerLocation() string {
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
@@ -0,0 +1,58 @@
+package goinject
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ReportedService struct{}
+
+func TestConfigurationReport(t *testing.T) {
+	t.Run("Should list all configuration problems", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(nil),
+			Module("storage",
+				Provide(func() *Parent { return &Parent{} }, As(Type[*Child]())),
+			),
+			Provide(func() *ReportedService { return &ReportedService{} }, In(PerLookUp),
+				OnStart(func(_ context.Context, _ *ReportedService) error { return nil })),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+		report := err.(*ConfigurationReport)
+		assert.Len(t, report.Problems, 3)
+
+		assert.Equal(t, InvalidProvider, report.Problems[0].Kind)
+		assert.Equal(t, "", report.Problems[0].Binding)
+		assert.Equal(t, "cannot accept nil provider", report.Problems[0].Message)
+		assert.Contains(t, report.Problems[0].Location, "report_test.go:")
+
+		assert.Equal(t, InvalidProvider, report.Problems[1].Kind)
+		assert.Equal(t, "*goinject.Parent", report.Problems[1].Binding)
+		assert.Equal(t, "storage", report.Problems[1].Module)
+
+		assert.Equal(t, InvalidLifecycle, report.Problems[2].Kind)
+		assert.Equal(t, "*goinject.ReportedService", report.Problems[2].Binding)
+		assert.Contains(t, report.Problems[2].Location, "report_test.go:")
+
+		assert.Contains(t, err.Error(), "found 3 configuration problems:\n")
+	})
+
+	t.Run("Should export the report as JSON", func(t *testing.T) {
+		_, err := NewInjector(
+			Module("telemetry", WithObserver(nil)),
+		)
+		data, jsonErr := err.(*ConfigurationReport).JSON()
+		assert.Nil(t, jsonErr)
+
+		var decoded map[string][]map[string]string
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, []map[string]string{{
+			"kind":    "invalid_option",
+			"module":  "telemetry",
+			"message": "injector option WithObserver cannot be installed in module telemetry, it must be given to NewInjector",
+		}}, decoded["problems"])
+	})
+}
@@ -0,0 +1,120 @@
+// Package requestinfo provides a goinject module exposing request metadata (request ID, deadline, peer) as
+// injectable values within a contextual request scope.
+package requestinfo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// RequestID is the identifier of the current request, used to correlate logs
+type RequestID string
+
+// Deadline is the deadline of the current request, Set is false if the request has no deadline
+type Deadline struct {
+	Time time.Time
+	Set  bool
+}
+
+// PeerInfo describe the remote peer of the current request
+type PeerInfo struct {
+	Addr     string
+	Metadata map[string]string
+}
+
+// ErrMissingRequestID is returned when resolving a RequestID while none can be extracted from the context
+var ErrMissingRequestID = errors.New("no request ID found in context")
+
+// ErrMissingPeerInfo is returned when resolving a PeerInfo while none can be extracted from the context
+var ErrMissingPeerInfo = errors.New("no peer info found in context")
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	peerInfoKey
+)
+
+// ContextWithRequestID return a context carrying the given request ID, for the default request ID extractor
+func ContextWithRequestID(ctx context.Context, id RequestID) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// ContextWithPeerInfo return a context carrying the given peer info, for the default peer info extractor
+func ContextWithPeerInfo(ctx context.Context, peer PeerInfo) context.Context {
+	return context.WithValue(ctx, peerInfoKey, peer)
+}
+
+type extractors struct {
+	requestID func(ctx context.Context) (RequestID, bool)
+	deadline  func(ctx context.Context) Deadline
+	peerInfo  func(ctx context.Context) (PeerInfo, bool)
+}
+
+// Option configure how the module extract request metadata from context
+type Option func(e *extractors)
+
+// WithRequestIDExtractor override the default request ID extractor (which read the value set by
+// ContextWithRequestID), e.g. to read it from a tracing span or from incoming metadata
+func WithRequestIDExtractor(extractor func(ctx context.Context) (RequestID, bool)) Option {
+	return func(e *extractors) {
+		e.requestID = extractor
+	}
+}
+
+// WithDeadlineExtractor override the default deadline extractor (which use context.Context Deadline method)
+func WithDeadlineExtractor(extractor func(ctx context.Context) Deadline) Option {
+	return func(e *extractors) {
+		e.deadline = extractor
+	}
+}
+
+// WithPeerInfoExtractor override the default peer info extractor (which read the value set by
+// ContextWithPeerInfo)
+func WithPeerInfoExtractor(extractor func(ctx context.Context) (PeerInfo, bool)) Option {
+	return func(e *extractors) {
+		e.peerInfo = extractor
+	}
+}
+
+// Module return a goinject module providing RequestID, Deadline and PeerInfo in the given (contextual) scope
+func Module(scope string, opts ...Option) goinject.Option {
+	e := &extractors{
+		requestID: func(ctx context.Context) (RequestID, bool) {
+			id, ok := ctx.Value(requestIDKey).(RequestID)
+			return id, ok
+		},
+		deadline: func(ctx context.Context) Deadline {
+			t, ok := ctx.Deadline()
+			return Deadline{Time: t, Set: ok}
+		},
+		peerInfo: func(ctx context.Context) (PeerInfo, bool) {
+			peer, ok := ctx.Value(peerInfoKey).(PeerInfo)
+			return peer, ok
+		},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return goinject.Module("requestinfo",
+		goinject.Provide(func(ctx goinject.InvocationContext) (RequestID, error) {
+			if id, ok := e.requestID(ctx); ok {
+				return id, nil
+			}
+			return "", ErrMissingRequestID
+		}, goinject.In(scope)),
+		goinject.Provide(func(ctx goinject.InvocationContext) Deadline {
+			return e.deadline(ctx)
+		}, goinject.In(scope)),
+		goinject.Provide(func(ctx goinject.InvocationContext) (PeerInfo, error) {
+			if peer, ok := e.peerInfo(ctx); ok {
+				return peer, nil
+			}
+			return PeerInfo{}, ErrMissingPeerInfo
+		}, goinject.In(scope)),
+	)
+}
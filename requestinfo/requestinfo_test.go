@@ -0,0 +1,69 @@
+package requestinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+type requestScopeKey int
+
+const requestScopeKeyVal requestScopeKey = 0
+
+func TestModule(t *testing.T) {
+	injector, err := goinject.NewInjector(
+		goinject.RegisterScope("request", goinject.NewContextualScope(requestScopeKeyVal)),
+		Module("request"),
+	)
+	assert.Nil(t, err)
+
+	t.Run("Should provide request metadata from context", func(t *testing.T) {
+		deadline := time.Now().Add(time.Minute)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+		ctx = ContextWithRequestID(ctx, "req-42")
+		ctx = ContextWithPeerInfo(ctx, PeerInfo{Addr: "10.0.0.1:4242"})
+		ctx = goinject.WithContextualScopeEnabled(ctx, requestScopeKeyVal)
+		defer goinject.ShutdownContextualScope(ctx, requestScopeKeyVal)
+
+		err := injector.Invoke(ctx, func(id RequestID, d Deadline, peer PeerInfo) {
+			assert.Equal(t, RequestID("req-42"), id)
+			assert.True(t, d.Set)
+			assert.Equal(t, deadline, d.Time)
+			assert.Equal(t, "10.0.0.1:4242", peer.Addr)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should return error if request ID is missing", func(t *testing.T) {
+		ctx := goinject.WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+		defer goinject.ShutdownContextualScope(ctx, requestScopeKeyVal)
+
+		err := injector.Invoke(ctx, func(_ RequestID) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorIs(t, err, ErrMissingRequestID)
+	})
+
+	t.Run("Should use configured extractors", func(t *testing.T) {
+		custom, err := goinject.NewInjector(
+			goinject.RegisterScope("request", goinject.NewContextualScope(requestScopeKeyVal)),
+			Module("request", WithRequestIDExtractor(func(_ context.Context) (RequestID, bool) {
+				return "from-carrier", true
+			})),
+		)
+		assert.Nil(t, err)
+		ctx := goinject.WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+		defer goinject.ShutdownContextualScope(ctx, requestScopeKeyVal)
+
+		err = custom.Invoke(ctx, func(id RequestID, d Deadline) {
+			assert.Equal(t, RequestID("from-carrier"), id)
+			assert.False(t, d.Set)
+		})
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+package goinject
+
+import (
+	"fmt"
+	"strings"
+)
+
+// moduleRequirement is a Requires option installed in a module
+type moduleRequirement struct {
+	module   string   // name of the requiring module
+	requires []string // names of the required modules
+	location string   // source location of the Requires call
+}
+
+type requiresOption struct {
+	modules  []string
+	location string
+}
+
+func (o *requiresOption) apply(mod *configuration) error {
+	if len(mod.modules) == 0 {
+		return newInjectorConfigurationError("Requires must be given to a Module", nil)
+	}
+	mod.requirements = append(mod.requirements, moduleRequirement{
+		module:   mod.modules[len(mod.modules)-1],
+		requires: o.modules,
+		location: o.location,
+	})
+	return nil
+}
+
+// Requires return an Option, to be given to a Module, declaring that the module depends on the modules with the
+// given names. NewInjector fails if a required module is not installed (by Module or ReplaceModule) or if module
+// requirements are cyclic, telling which module is missing rather than which binding cannot be resolved:
+//
+//	Module("api", Requires("db", "auth"), Provide(NewAPIServer))
+func Requires(modules ...string) Option {
+	return &requiresOption{modules: modules, location: callerLocation()}
+}
+
+// checkModuleRequirements return an error for each required module that is not installed and for each cycle of
+// module requirements
+func (mod *configuration) checkModuleRequirements() []error {
+	var errs []error
+	var modules []string // requiring modules, in declaration order
+	requires := make(map[string][]string)
+	for _, requirement := range mod.requirements {
+		if _, ok := requires[requirement.module]; !ok {
+			modules = append(modules, requirement.module)
+		}
+		requires[requirement.module] = append(requires[requirement.module], requirement.requires...)
+		for _, required := range requirement.requires {
+			if _, ok := mod.installedModules[required]; ok || mod.replacedModules[required] {
+				continue
+			}
+			errs = append(errs, newConfigurationProblemError(InvalidOption, "", []string{requirement.module},
+				requirement.location, newInjectorConfigurationError(fmt.Sprintf(
+					"module %s requires module %s which is not installed", requirement.module, required), nil)))
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := make(map[string]int)
+	var path []string
+	var visit func(module string)
+	visit = func(module string) {
+		switch states[module] {
+		case visiting:
+			start := len(path) - 1
+			for path[start] != module {
+				start--
+			}
+			cycle := append(append([]string{}, path[start:]...), module)
+			errs = append(errs, newInjectorConfigurationError(
+				fmt.Sprintf("cyclic module requirements: %s", strings.Join(cycle, " -> ")), nil))
+			return
+		case visited:
+			return
+		}
+		states[module] = visiting
+		path = append(path, module)
+		for _, required := range requires[module] {
+			visit(required)
+		}
+		path = path[:len(path)-1]
+		states[module] = visited
+	}
+	for _, module := range modules {
+		visit(module)
+	}
+	return errs
+}
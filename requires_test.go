@@ -0,0 +1,47 @@
+package goinject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequires(t *testing.T) {
+	t.Run("Should accept installed modules whatever their order", func(t *testing.T) {
+		db := Module("db", Provide(func() *Database { return &Database{} }))
+		_, err := NewInjector(
+			Module("api", Requires("db", "auth")),
+			db,
+			ReplaceModule("auth"),
+		)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should report missing modules", func(t *testing.T) {
+		_, err := NewInjector(
+			Module("db"),
+			Module("api", Requires("db", "auth")),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+		report := err.(*ConfigurationReport)
+		assert.Len(t, report.Problems, 1)
+		assert.Equal(t, InvalidOption, report.Problems[0].Kind)
+		assert.Equal(t, "api", report.Problems[0].Module)
+		assert.Equal(t, "module api requires module auth which is not installed", report.Problems[0].Message)
+		assert.Contains(t, report.Problems[0].Location, "requires_test.go:")
+	})
+
+	t.Run("Should report cyclic requirements", func(t *testing.T) {
+		_, err := NewInjector(
+			Module("api", Requires("auth")),
+			Module("auth", Requires("users")),
+			Module("users", Requires("auth")),
+		)
+		assert.ErrorContains(t, err, "cyclic module requirements: auth -> users -> auth")
+	})
+
+	t.Run("Should be given to a Module", func(t *testing.T) {
+		_, err := NewInjector(Requires("db"))
+		assert.ErrorContains(t, err, "Requires must be given to a Module")
+	})
+}
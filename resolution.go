@@ -0,0 +1,177 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BindingKey identifies a binding by its registration type and annotation
+type BindingKey struct {
+	Type       reflect.Type
+	Annotation string
+}
+
+func (k BindingKey) String() string {
+	if k.Annotation == "" {
+		return k.Type.String()
+	}
+	return fmt.Sprintf("%s(%q)", k.Type.String(), k.Annotation)
+}
+
+// KeyOf return the BindingKey of type T with the given annotation (at most one, none for unannotated bindings)
+func KeyOf[T any](annotation ...string) BindingKey {
+	key := BindingKey{Type: reflect.TypeFor[T]()}
+	if len(annotation) > 0 {
+		key.Annotation = annotation[0]
+	}
+	return key
+}
+
+func (b *binding) key() BindingKey {
+	return BindingKey{Type: b.typeof, Annotation: b.annotatedWith}
+}
+
+// ResolutionPath is the chain of binding keys being resolved, from the outermost requested
+// type (an argument of the invoked function or an eagerly created singleton) to the innermost one.
+type ResolutionPath []BindingKey
+
+func (p ResolutionPath) String() string {
+	keys := make([]string, len(p))
+	for i, k := range p {
+		keys[i] = k.String()
+	}
+	return strings.Join(keys, " -> ")
+}
+
+func (p ResolutionPath) contains(key BindingKey) bool {
+	for _, k := range p {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+type resolutionPathContextKey struct{}
+
+func resolutionPathFromContext(ctx context.Context) ResolutionPath {
+	if ctx == nil {
+		return nil
+	}
+	path, _ := ctx.Value(resolutionPathContextKey{}).(ResolutionPath)
+	return path
+}
+
+// appendPath return a copy of the path with the given key appended, so that sibling resolutions never share
+// the same backing array
+func (p ResolutionPath) appendPath(key BindingKey) ResolutionPath {
+	res := make(ResolutionPath, len(p), len(p)+1)
+	copy(res, p)
+	return append(res, key)
+}
+
+func withResolutionStep(ctx context.Context, key BindingKey) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, resolutionPathContextKey{}, resolutionPathFromContext(ctx).appendPath(key))
+}
+
+// withNewResolutionPath return a context starting a new resolution path, used when resolving in another injector
+// whose bindings may have the same keys
+func withNewResolutionPath(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, resolutionPathContextKey{}, ResolutionPath(nil))
+}
+
+// BindingInfo describe a binding: its key and the name of its scope
+type BindingInfo struct {
+	Key   BindingKey
+	Scope string
+}
+
+type creationStepContextKey struct{}
+
+// creationStep describe the instance being created with a context
+type creationStep struct {
+	binding        BindingInfo
+	injectionPoint InjectionPoint
+	inScope        bool // the binding is only active in a scope, see WhenInScope
+}
+
+func creationStepFromContext(ctx context.Context) (creationStep, bool) {
+	if ctx == nil {
+		return creationStep{}, false
+	}
+	step, ok := ctx.Value(creationStepContextKey{}).(creationStep)
+	return step, ok
+}
+
+// CurrentBinding return the binding whose instance is being created, given the InvocationContext received by its
+// provider. Generic providers (e.g. a logger factory bound with several annotations) can use it to tailor the
+// instance. The second result is false if ctx is not the context of a provider call.
+func CurrentBinding(ctx context.Context) (BindingInfo, bool) {
+	step, ok := creationStepFromContext(ctx)
+	return step.binding, ok
+}
+
+// BindingName is the annotation under which the instance being created is resolved. Providers bound under several
+// annotations can request a BindingName argument to select, e.g., their configuration section: one Redis provider
+// for Named("cache") and Named("sessions"). It is empty for unannotated bindings and outside of providers.
+type BindingName string
+
+var bindingNameReflectType = reflect.TypeFor[BindingName]()
+
+// withCreationStep return the context used to create an instance of the binding: the binding is appended to the
+// resolution path and is the CurrentBinding, injected at the pending InjectionPoint
+func withCreationStep(ctx context.Context, b *binding) context.Context {
+	ip := pendingInjectionPoint(ctx)
+	ctx = context.WithValue(withResolutionStep(ctx, b.key()), creationStepContextKey{}, creationStep{
+		binding:        BindingInfo{Key: b.key(), Scope: b.scope},
+		injectionPoint: ip,
+		inScope:        b.activeInScope != "",
+	})
+	if ip.target != nil { // the injection point is consumed, it does not apply to lookups made by the provider
+		ctx = withPendingInjectionPoint(ctx, InjectionPoint{})
+	}
+	return ctx
+}
+
+// withResolutionPath attach the resolution path to err unless the error tree already carries one
+// (the deepest path is the most relevant one).
+func withResolutionPath(ctx context.Context, key BindingKey, err error) error {
+	if err == nil {
+		return nil
+	}
+	var pathErr *resolutionPathError
+	if errors.As(err, &pathErr) {
+		return err
+	}
+	return newResolutionPathError(resolutionPathFromContext(ctx).appendPath(key), err)
+}
+
+// resolutionPathOf return the resolution path attached to err if any
+func resolutionPathOf(err error) ResolutionPath {
+	var pathErr *resolutionPathError
+	if errors.As(err, &pathErr) {
+		return pathErr.path
+	}
+	return nil
+}
+
+// checkResolutionContext return a wrapped context.Canceled or context.DeadlineExceeded error if ctx is done,
+// it is checked between each resolution step so that long resolution chains are aborted early.
+func checkResolutionContext(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("resolution aborted: %w", err)
+	}
+	return nil
+}
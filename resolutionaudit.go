@@ -0,0 +1,117 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"time"
+)
+
+var resolutionAuditSinkReflectType = reflect.TypeFor[ResolutionAuditSink]()
+
+// AuditRecord describe a resolution of a Sensitive binding
+type AuditRecord struct {
+	Key          BindingKey
+	Principal    string         // who invoked, see WithAuditPrincipal, empty if unknown
+	InvocationID string         // see InvocationIDOf
+	Function     string         // fully qualified name of the function given to Invoke, empty if unknown
+	Consumer     InjectionPoint // where the instance is injected
+	Time         time.Time
+	Err          error // resolution error, if any
+}
+
+// ResolutionAuditSink record the AuditRecord of the resolutions of Sensitive bindings, see AuditResolutions.
+// When Record fails, the resolution fails with the returned error: access is denied unless it is audited.
+type ResolutionAuditSink interface {
+	Record(ctx context.Context, record AuditRecord) error
+}
+
+type sensitiveAnnotation struct{}
+
+func (a *sensitiveAnnotation) apply(b *binding) error {
+	b.sensitive = true
+	return nil
+}
+
+// Sensitive return an annotation marking the binding as sensitive (e.g. credentials or PII-handling components),
+// its resolutions are recorded if AuditResolutions is enabled
+func Sensitive() Annotation {
+	return &sensitiveAnnotation{}
+}
+
+type auditResolutionsOption struct{}
+
+func (o *auditResolutionsOption) apply(mod *configuration) error {
+	mod.auditResolutions = true
+	return nil
+}
+
+// AuditResolutions return an InjectorOption recording every resolution of Sensitive bindings to the ResolutionAuditSink
+// bound in the injector (which is required), e.g. for compliance audits of access to credentials. Resolutions made
+// while recording (e.g. by the sink provider) are not audited.
+func AuditResolutions() InjectorOption {
+	return newInjectorOption("AuditResolutions", &auditResolutionsOption{})
+}
+
+type auditPrincipalContextKey struct{}
+
+// WithAuditPrincipal return a context making the resolutions of Sensitive bindings audited as made by principal,
+// e.g. the authenticated user of an HTTP request
+func WithAuditPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, auditPrincipalContextKey{}, principal)
+}
+
+type auditedFunctionContextKey struct{}
+
+type recordingAuditContextKey struct{}
+
+// checkResolutionAudit verify that an ResolutionAuditSink is bound if resolutions are audited
+func (mod *configuration) checkResolutionAudit() []error {
+	if !mod.auditResolutions {
+		return nil
+	}
+	for b := range mod.bindings {
+		if b.typeof == resolutionAuditSinkReflectType {
+			return nil
+		}
+	}
+	return []error{newInjectorConfigurationError(
+		fmt.Sprintf("AuditResolutions requires a binding of %s", resolutionAuditSinkReflectType), nil)}
+}
+
+// withAuditedFunction return ctx with the name of the invoked function fvalue, if resolutions are audited
+func (injector *Injector) withAuditedFunction(ctx context.Context, fvalue reflect.Value) context.Context {
+	if !injector.auditResolutions {
+		return ctx
+	}
+	if fn := runtime.FuncForPC(fvalue.Pointer()); fn != nil {
+		return context.WithValue(ctx, auditedFunctionContextKey{}, fn.Name())
+	}
+	return ctx
+}
+
+// recordResolution record the resolution of the sensitive binding b to the ResolutionAuditSink, resolutionErr being the
+// resolution error if any. The returned error is resolutionErr, or the error of the sink.
+func (injector *Injector) recordResolution(ctx context.Context, b *binding, resolutionErr error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if ctx.Value(recordingAuditContextKey{}) != nil {
+		return resolutionErr
+	}
+	record := AuditRecord{Key: b.key(), Consumer: pendingInjectionPoint(ctx), Time: time.Now(), Err: resolutionErr}
+	record.Principal, _ = ctx.Value(auditPrincipalContextKey{}).(string)
+	record.InvocationID, _ = InvocationIDOf(ctx)
+	record.Function, _ = ctx.Value(auditedFunctionContextKey{}).(string)
+
+	recordingCtx := context.WithValue(ctx, recordingAuditContextKey{}, true)
+	sink, err := injector.getInstanceOfAnnotatedType(recordingCtx, resolutionAuditSinkReflectType, "", false)
+	if err == nil {
+		err = sink.Interface().(ResolutionAuditSink).Record(recordingCtx, record)
+	}
+	if err != nil && resolutionErr == nil {
+		return newInjectionError(b.typeof, b.annotatedWith, fmt.Errorf("failed to audit resolution: %w", err))
+	}
+	return resolutionErr
+}
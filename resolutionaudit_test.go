@@ -0,0 +1,77 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Credentials struct {
+	Token string
+}
+
+type memoryResolutionAuditSink struct {
+	records []AuditRecord
+	err     error
+}
+
+func (s *memoryResolutionAuditSink) Record(_ context.Context, record AuditRecord) error {
+	s.records = append(s.records, record)
+	return s.err
+}
+
+func TestAuditResolutions(t *testing.T) {
+	sink := &memoryResolutionAuditSink{}
+	injector, err := NewInjector(
+		AuditResolutions(),
+		Provide(func() ResolutionAuditSink { return sink }),
+		Provide(func() *Credentials { return &Credentials{Token: "secret"} }, Sensitive()),
+		Provide(func(_ *Credentials) *Request { return &Request{ID: 1} }),
+		Provide(func() *Session { return &Session{ID: 2} }),
+	)
+	assert.Nil(t, err)
+	if assert.Len(t, sink.records, 1) { // resolved by the eager creation of *Request
+		assert.Equal(t, KeyOf[*Credentials](), sink.records[0].Key)
+		assert.Equal(t, KeyOf[*Request]().Type, sink.records[0].Consumer.TargetType())
+		assert.Empty(t, sink.records[0].Function)
+	}
+
+	ctx := WithInvocationID(WithAuditPrincipal(context.Background(), "alice"), "invocation-1")
+	assert.Nil(t, injector.Invoke(ctx, func(_ *Request, _ *Session) {}))
+	assert.Len(t, sink.records, 1)
+
+	assert.Nil(t, injector.Invoke(ctx, func(_ *Session, _ *Credentials) {}))
+	if assert.Len(t, sink.records, 2) {
+		record := sink.records[1]
+		assert.Equal(t, KeyOf[*Credentials](), record.Key)
+		assert.Equal(t, "alice", record.Principal)
+		assert.Equal(t, "invocation-1", record.InvocationID)
+		assert.True(t, strings.HasPrefix(record.Function, "github.com/illuin-tech/goinject.TestAuditResolutions"))
+		assert.Equal(t, 1, record.Consumer.Position())
+		assert.False(t, record.Time.IsZero())
+		assert.Nil(t, record.Err)
+	}
+}
+
+func TestAuditResolutionsShouldDenyUnauditedResolutions(t *testing.T) {
+	sink := &memoryResolutionAuditSink{err: errors.New("sink unavailable")}
+	injector, err := NewInjector(
+		AuditResolutions(),
+		Provide(func() ResolutionAuditSink { return sink }),
+		Provide(func() *Credentials { return &Credentials{} }, Sensitive()),
+	)
+	assert.Nil(t, err)
+	err = injector.Invoke(context.Background(), func(_ *Credentials) {})
+	assert.ErrorContains(t, err, "sink unavailable")
+}
+
+func TestAuditResolutionsShouldRequireSink(t *testing.T) {
+	_, err := NewInjector(
+		AuditResolutions(),
+		Provide(func() *Credentials { return &Credentials{} }, Sensitive()),
+	)
+	assert.ErrorContains(t, err, "AuditResolutions requires a binding")
+}
@@ -0,0 +1,100 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type callTimeoutAnnotation struct {
+	timeout time.Duration
+	methods []string
+}
+
+func (a *callTimeoutAnnotation) apply(b *binding) error {
+	if a.timeout <= 0 {
+		return newInjectorConfigurationError("argument of WithCallTimeout must be positive", nil)
+	}
+	b.callPolicies = append(b.callPolicies, func(
+		ctx context.Context,
+		_ *CallGuard,
+		method string,
+		call func(ctx context.Context) error,
+	) error {
+		if len(a.methods) > 0 && !slices.Contains(a.methods, method) {
+			return call(ctx)
+		}
+		ctx, cancel := context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+		return call(ctx)
+	})
+	return nil
+}
+
+// WithCallTimeout return an annotation applying a deadline to the method calls of the binding proxy (see
+// WithProxy), restricted to the given methods if any. The deadline is set on the context given to the call, the
+// proxied methods must honor it.
+func WithCallTimeout(timeout time.Duration, methods ...string) Annotation {
+	return &callTimeoutAnnotation{timeout: timeout, methods: methods}
+}
+
+// RetryPolicy configure WithCallRetry
+type RetryPolicy struct {
+	MaxAttempts int                  // maximum number of calls, including the first one, 3 if not set
+	Backoff     time.Duration        // delay before the first retry, doubled before each next retry
+	IsRetryable func(err error) bool // tell if a failed call is retried, any error by default
+	Methods     []string             // names of the retried methods, all methods if empty
+}
+
+type callRetryAnnotation struct {
+	policy RetryPolicy
+}
+
+func (a *callRetryAnnotation) apply(b *binding) error {
+	policy := a.policy
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 3
+	}
+	if policy.IsRetryable == nil {
+		policy.IsRetryable = func(_ error) bool { return true }
+	}
+	b.callPolicies = append(b.callPolicies, func(
+		ctx context.Context,
+		_ *CallGuard,
+		method string,
+		call func(ctx context.Context) error,
+	) error {
+		if len(policy.Methods) > 0 && !slices.Contains(policy.Methods, method) {
+			return call(ctx)
+		}
+		backoff := policy.Backoff
+		for attempt := 1; ; attempt++ {
+			err := call(ctx)
+			if err == nil || attempt >= policy.MaxAttempts || !isRetryableCallError(policy, err) {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("retry of %s aborted after %d attempts: %w",
+					method, attempt, errors.Join(err, ctx.Err()))
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	})
+	return nil
+}
+
+// isRetryableCallError tell if err is retried, rejections of an open circuit breaker never are
+func isRetryableCallError(policy RetryPolicy, err error) bool {
+	return !errors.Is(err, ErrCircuitOpen) && policy.IsRetryable(err)
+}
+
+// WithCallRetry return an annotation retrying the failed method calls of the binding proxy (see WithProxy).
+// Call policies are applied in annotation order, the first one being the outermost: declare WithCallRetry before
+// WithCallTimeout to apply the deadline to each attempt, after it to apply the deadline to all the attempts.
+func WithCallRetry(policy RetryPolicy) Annotation {
+	return &callRetryAnnotation{policy: policy}
+}
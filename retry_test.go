@@ -0,0 +1,112 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type slowInventory struct {
+	failures int
+	calls    int
+}
+
+func (i *slowInventory) Stock(ctx context.Context, _ string) (int, error) {
+	i.calls++
+	if i.calls <= i.failures {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	return 3, nil
+}
+
+func TestCallRetryAndTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Each attempt should have its own deadline", func(t *testing.T) {
+		inventory := &slowInventory{failures: 2}
+		injector, err := NewInjector(
+			Provide(func() *slowInventory { return inventory }, As(Type[Inventory]()),
+				WithProxy(newInventoryProxy),
+				WithCallRetry(RetryPolicy{Backoff: time.Millisecond}),
+				WithCallTimeout(10*time.Millisecond)),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(i Inventory) {
+			stock, err := i.Stock(ctx, "book")
+			assert.Nil(t, err)
+			assert.Equal(t, 3, stock)
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, inventory.calls)
+	})
+
+	t.Run("Retry should stop after MaxAttempts", func(t *testing.T) {
+		inventory := &slowInventory{failures: 5}
+		injector, err := NewInjector(
+			Provide(func() *slowInventory { return inventory }, As(Type[Inventory]()),
+				WithProxy(newInventoryProxy),
+				WithCallRetry(RetryPolicy{MaxAttempts: 2}),
+				WithCallTimeout(10*time.Millisecond, "Stock")),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(i Inventory) {
+			_, err := i.Stock(ctx, "book")
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, inventory.calls)
+	})
+
+	t.Run("Retry should only retry retryable errors of the configured methods", func(t *testing.T) {
+		unavailable := errors.New("inventory unavailable")
+		for name, policy := range map[string]RetryPolicy{
+			"not retryable": {IsRetryable: func(err error) bool { return !errors.Is(err, unavailable) }},
+			"other method":  {Methods: []string{"Reserve"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				inventory := &flakyInventory{err: unavailable}
+				injector, err := NewInjector(
+					Provide(func() *flakyInventory { return inventory }, As(Type[Inventory]()),
+						WithProxy(newInventoryProxy),
+						WithCallRetry(policy)),
+				)
+				assert.Nil(t, err)
+				err = injector.Invoke(ctx, func(i Inventory) {
+					_, err := i.Stock(ctx, "book")
+					assert.ErrorIs(t, err, unavailable)
+				})
+				assert.Nil(t, err)
+				assert.Equal(t, 1, inventory.calls)
+			})
+		}
+	})
+
+	t.Run("Retry should stop when the circuit breaker opens", func(t *testing.T) {
+		inventory := &flakyInventory{err: errors.New("inventory unavailable")}
+		injector, err := NewInjector(
+			Provide(func() *flakyInventory { return inventory }, As(Type[Inventory]()),
+				WithProxy(newInventoryProxy),
+				WithCallRetry(RetryPolicy{}),
+				WithCircuitBreaker(CircuitBreakerPolicy{FailureThreshold: 1})),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(i Inventory) {
+			_, err := i.Stock(ctx, "book")
+			assert.ErrorIs(t, err, ErrCircuitOpen)
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, inventory.calls)
+	})
+
+	t.Run("WithCallTimeout should be positive", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *slowInventory { return &slowInventory{} }, As(Type[Inventory]()),
+				WithProxy(newInventoryProxy), WithCallTimeout(0)),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+	})
+}
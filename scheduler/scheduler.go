@@ -0,0 +1,172 @@
+// Package scheduler runs jobs registered as goinject bindings on cron schedules.
+//
+// Jobs are either registered with Schedule, whose function arguments are resolved by the injector on each run:
+//
+//	scheduler.Schedule("purge", "*/5 * * * *", func(repo *Repository) error { return repo.Purge() })
+//
+// or are bindings implementing ScheduledJob added to the Group group. Each run happens within a fresh job scope
+// (see JobScope), so bindings declared in that scope are created per run and destroyed after it.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// Group is the group jobs must be added to using goinject.IntoGroup
+const Group = "scheduler.jobs"
+
+// JobScope is the name of the contextual scope active while a job runs
+const JobScope = "scheduler.Job"
+
+type jobScopeKey int
+
+const jobScopeKeyVal jobScopeKey = 0
+
+// ScheduledJob is a job run on a schedule
+type ScheduledJob interface {
+	// Name identify the job in errors
+	Name() string
+	// Spec is a 5 fields cron expression (minute hour day-of-month month day-of-week) or "@every <duration>"
+	Spec() string
+	// Run execute the job, ctx has the job scope enabled
+	Run(ctx context.Context, injector *goinject.Injector) error
+}
+
+type funcJob struct {
+	name string
+	spec string
+	fn   any
+}
+
+func (j *funcJob) Name() string { return j.name }
+
+func (j *funcJob) Spec() string { return j.spec }
+
+func (j *funcJob) Run(ctx context.Context, injector *goinject.Injector) error {
+	return injector.Invoke(ctx, j.fn)
+}
+
+// Schedule return an option registering a job running fn on the given schedule.
+// Arguments of fn are resolved by the injector on each run, fn may return an error.
+// An invalid spec make the injector creation fail.
+func Schedule(name, spec string, fn any) goinject.Option {
+	return goinject.Provide(func() (*funcJob, error) {
+		if _, err := parseSpec(spec); err != nil {
+			return nil, fmt.Errorf("job %q: %w", name, err)
+		}
+		return &funcJob{name: name, spec: spec, fn: fn}, nil
+	}, goinject.As(goinject.Type[ScheduledJob]()), goinject.IntoGroup(Group))
+}
+
+// ErrorHandler is called with errors returned by job runs
+type ErrorHandler func(job ScheduledJob, err error)
+
+// Scheduler run the jobs of the injector
+type Scheduler struct {
+	injector     *goinject.Injector
+	jobs         []ScheduledJob
+	errorHandler ErrorHandler
+	now          func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type schedulerParams struct {
+	goinject.Params
+	Injector *goinject.Injector `inject:""`
+	Jobs     []ScheduledJob     `inject:"scheduler.jobs,optional"`
+}
+
+// ErrAlreadyStarted is returned when starting a Scheduler twice
+var ErrAlreadyStarted = errors.New("scheduler already started")
+
+// Module return a goinject module registering the job scope and providing the *Scheduler.
+// Errors returned by jobs are passed to errorHandler (which may be nil).
+func Module(errorHandler ErrorHandler) goinject.Option {
+	return goinject.Module("scheduler",
+		goinject.RegisterScope(JobScope, goinject.NewContextualScope(jobScopeKeyVal)),
+		goinject.Provide(func(params schedulerParams) *Scheduler {
+			return &Scheduler{
+				injector:     params.Injector,
+				jobs:         params.Jobs,
+				errorHandler: errorHandler,
+				now:          time.Now,
+			}
+		}, goinject.In(goinject.Singleton), goinject.WithDestroy(func(s *Scheduler) {
+			s.Stop()
+		})),
+	)
+}
+
+// Start run one goroutine per job until ctx is canceled or Stop is called
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return ErrAlreadyStarted
+	}
+	schedules := make([]schedule, len(s.jobs))
+	for i, job := range s.jobs {
+		sched, err := parseSpec(job.Spec())
+		if err != nil {
+			return fmt.Errorf("job %q: %w", job.Name(), err)
+		}
+		schedules[i] = sched
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	for i, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job, schedules[i])
+	}
+	return nil
+}
+
+func (s *Scheduler) loop(ctx context.Context, job ScheduledJob, sched schedule) {
+	defer s.wg.Done()
+	for {
+		next := sched.next(s.now())
+		if next.IsZero() {
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if err := s.run(ctx, job); err != nil && s.errorHandler != nil {
+			s.errorHandler(job, err)
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job ScheduledJob) (err error) {
+	ctx = goinject.WithContextualScopeEnabled(ctx, jobScopeKeyVal)
+	defer goinject.ShutdownContextualScope(ctx, jobScopeKeyVal)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job %q panicked: %v", job.Name(), r)
+		}
+	}()
+	return job.Run(ctx, s.injector)
+}
+
+// Stop stop the scheduler and wait for running jobs to return
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	s.wg.Wait()
+}
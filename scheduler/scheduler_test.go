@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+func TestParseSpec(t *testing.T) {
+	base := time.Date(2024, time.January, 31, 23, 58, 30, 0, time.UTC) // a wednesday
+	cases := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.January, 31, 23, 59, 0, 0, time.UTC)},
+		{"*/5 * * * *", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 8 * * *", time.Date(2024, time.February, 1, 8, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 1-5", time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC)},
+		{"0 12 * * 0,6", time.Date(2024, time.February, 3, 12, 0, 0, 0, time.UTC)},
+		{"15,45 */6 1 * *", time.Date(2024, time.February, 1, 0, 15, 0, 0, time.UTC)},
+		{"@every 90s", base.Add(90 * time.Second)},
+	}
+	for _, c := range cases {
+		t.Run(c.spec, func(t *testing.T) {
+			sched, err := parseSpec(c.spec)
+			assert.Nil(t, err)
+			assert.Equal(t, c.expected, sched.next(base))
+		})
+	}
+
+	for _, invalid := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "a * * * *", "5-1 * * * *", "@every -1s"} {
+		t.Run("invalid "+invalid, func(t *testing.T) {
+			_, err := parseSpec(invalid)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+type Counter struct {
+	runs atomic.Int32
+}
+
+type JobResource struct {
+	closed bool
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("Should run jobs within job scope", func(t *testing.T) {
+		counter := &Counter{}
+		var resources []*JobResource
+		injector, err := goinject.NewInjector(
+			Module(nil),
+			goinject.Provide(func() *Counter { return counter }),
+			goinject.Provide(func() *JobResource { return &JobResource{} }, goinject.In(JobScope),
+				goinject.WithDestroy(func(r *JobResource) { r.closed = true })),
+			Schedule("count", "@every 5ms", func(c *Counter, r *JobResource) {
+				resources = append(resources, r)
+				c.runs.Add(1)
+			}),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func(s *Scheduler) error {
+			return s.Start(context.Background())
+		})
+		assert.Nil(t, err)
+		assert.Eventually(t, func() bool { return counter.runs.Load() >= 2 }, time.Second, time.Millisecond)
+		injector.Shutdown()
+
+		assert.GreaterOrEqual(t, len(resources), 2)
+		assert.NotSame(t, resources[0], resources[1])
+		for _, r := range resources {
+			assert.True(t, r.closed)
+		}
+	})
+
+	t.Run("Should report job errors", func(t *testing.T) {
+		errs := make(chan error, 1)
+		injector, err := goinject.NewInjector(
+			Module(func(_ ScheduledJob, err error) {
+				select {
+				case errs <- err:
+				default:
+				}
+			}),
+			Schedule("missing", "@every 5ms", func(_ *Counter) {}),
+		)
+		assert.Nil(t, err)
+		defer injector.Shutdown()
+		err = injector.Invoke(context.Background(), func(s *Scheduler) error {
+			return s.Start(context.Background())
+		})
+		assert.Nil(t, err)
+		assert.ErrorContains(t, <-errs, "did not found binding")
+	})
+
+	t.Run("Should fail injector creation on invalid spec", func(t *testing.T) {
+		_, err := goinject.NewInjector(
+			Module(nil),
+			Schedule("invalid", "* * *", func() {}),
+		)
+		assert.ErrorContains(t, err, "job \"invalid\": invalid schedule \"* * *\"")
+	})
+}
@@ -2,8 +2,12 @@ package goinject
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
+	"slices"
 	"sync"
+	"time"
 )
 
 // Instance is the return type for Scope ResolveBinding method.
@@ -11,64 +15,208 @@ import (
 type Instance reflect.Value
 
 type instanceRegistry struct {
-	mu                 sync.Mutex                 // lock guarding instanceLock
-	instanceLock       map[*binding]*sync.RWMutex // lock guarding instances
-	instances          sync.Map
+	mu                 sync.Mutex             // lock guarding entries
+	entries            map[any]*instanceEntry // created (or being created) instances by binding (or cache key)
 	destroyMethodsLock sync.Mutex
-	destroyMethods     []func()
+	destroyMethods     []destroyCallback
+	createdAt          time.Time
+	shutdownHooks      []func(instances int) // called at shutdown with the number of instances, under destroyMethodsLock
+	users              int                   // goroutines the registry is propagated to, see PropagateScopes
+	shutdownPending    bool                  // shutdown was requested while users were running
+}
+
+// instanceEntry hold an instance of a binding, its lock is held for writing while the instance is created
+type instanceEntry struct {
+	lock     sync.RWMutex
+	instance Instance
+	err      error
 }
 
 func (r *instanceRegistry) resolveBinding(
 	binding *binding,
 	instanceCreator func() (Instance, error),
+) (Instance, error) {
+	return r.resolve(binding, instanceCreator)
+}
+
+func (r *instanceRegistry) resolve(
+	key any,
+	instanceCreator func() (Instance, error),
+) (Instance, error) {
+	return r.resolveWithQuota(key, 0, instanceCreator)
+}
+
+// resolveWithQuota resolve the instance of key, failing with errQuotaExceeded if it must be created while the
+// registry already holds maxInstances instances (0 means unlimited)
+func (r *instanceRegistry) resolveWithQuota(
+	key any,
+	maxInstances int,
+	instanceCreator func() (Instance, error),
 ) (Instance, error) {
 	r.mu.Lock()
 
-	if l, ok := r.instanceLock[binding]; ok {
+	if entry, ok := r.entries[key]; ok {
 		r.mu.Unlock()
-		l.RLock()
-		defer l.RUnlock()
+		entry.lock.RLock()
+		defer entry.lock.RUnlock()
 
-		instance, _ := r.instances.Load(binding)
-		return instance.(Instance), nil
+		return entry.instance, entry.err
+	}
+	if maxInstances > 0 && len(r.entries) >= maxInstances {
+		r.mu.Unlock()
+		return Instance{}, errQuotaExceeded
 	}
 
-	r.instanceLock[binding] = new(sync.RWMutex)
-	l := r.instanceLock[binding]
-	l.Lock()
+	entry := &instanceEntry{}
+	r.entries[key] = entry
+	entry.lock.Lock()
 	r.mu.Unlock()
+	defer entry.lock.Unlock()
+
+	entry.instance, entry.err = instanceCreator()
+	if entry.err != nil {
+		// failed creation are not cached, next resolution will try again
+		r.mu.Lock()
+		delete(r.entries, key)
+		r.mu.Unlock()
+	}
 
-	instance, err := instanceCreator()
-	r.instances.Store(binding, instance)
+	return entry.instance, entry.err
+}
 
-	defer l.Unlock()
+// replace set the instance of key, replacing the current one if any
+func (r *instanceRegistry) replace(key any, instance Instance) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[key] = &instanceEntry{instance: instance}
+}
 
-	return instance, err
+// has tell if an instance of key was created (or is being created)
+func (r *instanceRegistry) has(key any) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.entries[key]
+	return ok
+}
+
+// take remove the entry of key and return it, if any
+func (r *instanceRegistry) take(key any) (*instanceEntry, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[key]
+	delete(r.entries, key)
+	return entry, ok
+}
+
+// put set the entry of key, replacing the current one if any
+func (r *instanceRegistry) put(key any, entry *instanceEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[key] = entry
+}
+
+// evict remove the instance of key, it will be created again on next resolution
+func (r *instanceRegistry) evict(key any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, key)
 }
 
 func (r *instanceRegistry) registerDestructionCallback(
 	destroyCallback func(),
 ) {
+	r.registerOrderedDestructionCallback(0, destroyCallback)
+}
+
+// registerOrderedDestructionCallback register a destruction callback with the given shutdown order, see
+// ShutdownGroup
+func (r *instanceRegistry) registerOrderedDestructionCallback(order int, callback func()) {
+	r.destroyMethodsLock.Lock()
+	defer r.destroyMethodsLock.Unlock()
+	r.destroyMethods = append(r.destroyMethods, destroyCallback{order: order, fn: callback})
+}
+
+// replaceDestructionCallback register the destruction callback of the instance of key, replacing the callback
+// previously registered by this method for key if any, so that instances re-created many times (e.g. refreshed) do
+// not pile up callbacks. The replaced callback must not be needed anymore.
+func (r *instanceRegistry) replaceDestructionCallback(key any, order int, callback func()) {
 	r.destroyMethodsLock.Lock()
 	defer r.destroyMethodsLock.Unlock()
-	r.destroyMethods = append(r.destroyMethods, destroyCallback)
+	for i := range r.destroyMethods {
+		if r.destroyMethods[i].key == key {
+			r.destroyMethods[i].fn = callback
+			return
+		}
+	}
+	r.destroyMethods = append(r.destroyMethods, destroyCallback{order: order, fn: callback, key: key})
 }
 
+// shutdown destroy the instances of the registry, it is deferred until the last user is released if any
 func (r *instanceRegistry) shutdown() {
 	r.destroyMethodsLock.Lock()
 	defer r.destroyMethodsLock.Unlock()
+	if r.users > 0 {
+		r.shutdownPending = true
+		return
+	}
+	r.destroy()
+}
 
-	for i := len(r.destroyMethods) - 1; i >= 0; i-- {
-		r.destroyMethods[i]()
+// acquire register a user of the registry, delaying its shutdown until the user is released
+func (r *instanceRegistry) acquire() {
+	r.destroyMethodsLock.Lock()
+	defer r.destroyMethodsLock.Unlock()
+	r.users++
+}
+
+// release unregister a user of the registry, running the pending shutdown if it was the last one
+func (r *instanceRegistry) release() {
+	r.destroyMethodsLock.Lock()
+	defer r.destroyMethodsLock.Unlock()
+	r.users--
+	if r.users == 0 && r.shutdownPending {
+		r.shutdownPending = false
+		r.destroy()
+	}
+}
+
+// destroy run the destroy callbacks and the shutdown hooks, destroyMethodsLock must be held
+func (r *instanceRegistry) destroy() {
+	// callbacks are removed before being called, so that the callbacks following a panicking one stay pending
+	for len(r.destroyMethods) > 0 {
+		next := nextDestroyCallback(r.destroyMethods)
+		callback := r.destroyMethods[next]
+		r.destroyMethods = slices.Delete(r.destroyMethods, next, next+1)
+		callback.fn()
+	}
+	r.mu.Lock()
+	instances := len(r.entries)
+	r.mu.Unlock()
+	for _, hook := range r.shutdownHooks {
+		hook(instances)
 	}
+	r.shutdownHooks = nil
+}
+
+// onShutdown register a hook called with the number of instances when the registry is shut down
+func (r *instanceRegistry) onShutdown(hook func(instances int)) {
+	r.destroyMethodsLock.Lock()
+	defer r.destroyMethodsLock.Unlock()
+	r.shutdownHooks = append(r.shutdownHooks, hook)
+}
 
-	r.destroyMethods = []func(){}
+// pendingDestroyCallbacks return the number of registered destroy callbacks that did not run yet
+func (r *instanceRegistry) pendingDestroyCallbacks() int {
+	r.destroyMethodsLock.Lock()
+	defer r.destroyMethodsLock.Unlock()
+	return len(r.destroyMethods)
 }
 
 func newInstanceRegistry() *instanceRegistry {
 	return &instanceRegistry{
-		instanceLock:   make(map[*binding]*sync.RWMutex),
-		destroyMethods: []func(){},
+		entries:        make(map[any]*instanceEntry),
+		destroyMethods: []destroyCallback{},
+		createdAt:      time.Now(),
 	}
 }
 
@@ -113,7 +261,7 @@ func (s *perLookUpScope) RegisterDestructionCallback(
 	_ context.Context,
 	_ func(),
 ) {
-	// nothing to do, per lookup provided need to close destroy method themselves
+	// nothing to do, per lookup provided need to close destroy method themselves (see DestroyOnReturn)
 }
 
 const Singleton = "inject.Singleton"
@@ -140,10 +288,10 @@ func (s *singletonScope) ResolveBinding(
 }
 
 func (s *singletonScope) RegisterDestructionCallback(
-	_ context.Context,
+	ctx context.Context,
 	destroyCallback func(),
 ) {
-	s.instanceRegistry.registerDestructionCallback(destroyCallback)
+	s.instanceRegistry.registerOrderedDestructionCallback(shutdownOrderOf(ctx), destroyCallback)
 }
 
 func (s *singletonScope) Shutdown() {
@@ -152,11 +300,18 @@ func (s *singletonScope) Shutdown() {
 
 // contextualScope is an abstract scope to handle context attached scoped (request, session, ...)
 type contextualScope struct {
-	key any
+	key          any
+	maxInstances int // maximum number of instances per scope activation, 0 means unlimited
+	activations  scopeActivations
+	err          error // invalid option, reported when the scope is registered
 }
 
 var _ Scope = new(contextualScope)
 
+func (s *contextualScope) validate() error {
+	return s.err
+}
+
 func (s *contextualScope) ResolveBinding(
 	ctx context.Context,
 	binding *binding,
@@ -169,7 +324,12 @@ func (s *contextualScope) ResolveBinding(
 	if !ok {
 		return Instance{}, newContextScopedNotActiveError()
 	}
-	return scopeHolder.resolveBinding(binding, instanceCreator)
+	s.activations.track(scopeHolder)
+	instance, err := scopeHolder.resolveWithQuota(binding, s.maxInstances, instanceCreator)
+	if errors.Is(err, errQuotaExceeded) {
+		return Instance{}, newInstanceQuotaError(binding, s.maxInstances)
+	}
+	return instance, err
 }
 
 func (s *contextualScope) RegisterDestructionCallback(
@@ -177,18 +337,40 @@ func (s *contextualScope) RegisterDestructionCallback(
 	destroyCallback func(),
 ) {
 	if scopeHolder, ok := ctx.Value(s.key).(*instanceRegistry); ok {
-		scopeHolder.registerDestructionCallback(destroyCallback)
+		scopeHolder.registerOrderedDestructionCallback(shutdownOrderOf(ctx), destroyCallback)
+	}
+}
+
+// ContextualScopeOption configure a Scope created by NewContextualScope
+type ContextualScopeOption func(s *contextualScope)
+
+// WithMaxInstances limit the number of instances a contextual scope hold per activation (e.g. per request).
+// Resolving a binding that would create an instance beyond the limit return an *InstanceQuotaError.
+// The limit must be positive, registering the scope fails otherwise.
+func WithMaxInstances(maxInstances int) ContextualScopeOption {
+	return func(s *contextualScope) {
+		if maxInstances <= 0 {
+			s.err = newInjectorConfigurationError("argument of WithMaxInstances must be positive", nil)
+		}
+		s.maxInstances = maxInstances
 	}
 }
 
-func NewContextualScope(key any) Scope {
-	return &contextualScope{
+func NewContextualScope(key any, opts ...ContextualScopeOption) Scope {
+	s := &contextualScope{
 		key: key,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func WithContextualScopeEnabled(ctx context.Context, key any) context.Context {
-	return context.WithValue(ctx, key, newInstanceRegistry())
+	registry := newInstanceRegistry()
+	parent, _ := ctx.Value(enabledScopesContextKey{}).(*enabledScope)
+	ctx = context.WithValue(ctx, enabledScopesContextKey{}, &enabledScope{key: key, registry: registry, parent: parent})
+	return context.WithValue(ctx, key, registry)
 }
 
 func ShutdownContextualScope(ctx context.Context, key any) {
@@ -197,3 +379,39 @@ func ShutdownContextualScope(ctx context.Context, key any) {
 		holder.shutdown()
 	}
 }
+
+// PendingDestroyCallbacks return the number of destroy callbacks of the contextual scope enabled in ctx that did not
+// run yet, e.g. because the scope was not shut down, a callback panicked, or an instance was created after the
+// shutdown
+func PendingDestroyCallbacks(ctx context.Context, key any) int {
+	holder, ok := ctx.Value(key).(*instanceRegistry)
+	if !ok {
+		return 0
+	}
+	return holder.pendingDestroyCallbacks()
+}
+
+// SeedContextualScope set value as the instance of the bindings of key in the contextual scope enabled in ctx, e.g.
+// to inject the current request or user in tests
+func (injector *Injector) SeedContextualScope(ctx context.Context, scopeKey any, key BindingKey, value any) error {
+	holder, ok := ctx.Value(scopeKey).(*instanceRegistry)
+	if !ok {
+		return newContextScopedNotActiveError()
+	}
+	bindings := injector.findBindingsForAnnotatedType(key.Type, key.Annotation)
+	if len(bindings) == 0 {
+		return newInjectionError(key.Type, key.Annotation, fmt.Errorf("did not found binding, expected at least one"))
+	}
+	instance := reflect.Zero(key.Type)
+	if value != nil {
+		if instance = reflect.ValueOf(value); !instance.Type().AssignableTo(key.Type) {
+			return newInjectionError(key.Type, key.Annotation,
+				fmt.Errorf("cannot seed with a value of type %s", instance.Type()))
+		}
+		instance = instance.Convert(key.Type)
+	}
+	for _, b := range bindings {
+		holder.put(b, &instanceEntry{instance: Instance(instance)})
+	}
+	return nil
+}
@@ -293,3 +293,61 @@ func TestPerLookUpScope(t *testing.T) {
 		})
 	})
 }
+
+func TestContextualScopeMaxInstances(t *testing.T) {
+	injector, err := NewInjector(
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal, WithMaxInstances(1))),
+		Provide(func() *Request { return &Request{ID: 1} }, In("request")),
+		Provide(func() *Session { return &Session{ID: 2} }, In("request")),
+	)
+	assert.Nil(t, err)
+
+	requestCtx := WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+	defer ShutdownContextualScope(requestCtx, requestScopeKeyVal)
+
+	assert.Nil(t, injector.Invoke(requestCtx, func(_ *Request) {}))
+	assert.Nil(t, injector.Invoke(requestCtx, func(_ *Request) {}))
+	err = injector.Invoke(requestCtx, func(_ *Session) {
+		assert.Fail(t, "should not be reached")
+	})
+	var quotaErr *InstanceQuotaError
+	assert.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, "request", quotaErr.Scope)
+	assert.Equal(t, KeyOf[*Session](), quotaErr.Key)
+	assert.ErrorContains(t, err, `scope "request" reached its quota of 1 instances`)
+
+	otherRequestCtx := WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+	defer ShutdownContextualScope(otherRequestCtx, requestScopeKeyVal)
+	assert.Nil(t, injector.Invoke(otherRequestCtx, func(_ *Session) {}))
+
+	_, err = NewInjector(RegisterScope("request", NewContextualScope(requestScopeKeyVal, WithMaxInstances(0))))
+	assert.ErrorContains(t, err, "argument of WithMaxInstances must be positive")
+}
+
+func TestSeedContextualScope(t *testing.T) {
+	injector, err := NewInjector(
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+		Provide(func() *Request { return &Request{ID: 1} }, In("request"), WithDestroy(func(_ *Request) {})),
+	)
+	assert.Nil(t, err)
+
+	requestCtx := WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+	assert.Nil(t, injector.SeedContextualScope(requestCtx, requestScopeKeyVal, KeyOf[*Request](), &Request{ID: 42}))
+	assert.Nil(t, injector.Invoke(requestCtx, func(r *Request) {
+		assert.Equal(t, 42, r.ID)
+	}))
+	assert.Equal(t, 0, PendingDestroyCallbacks(requestCtx, requestScopeKeyVal))
+
+	assert.ErrorContains(t, injector.SeedContextualScope(requestCtx, requestScopeKeyVal, KeyOf[*Request](), 42),
+		"cannot seed with a value of type int")
+	assert.ErrorContains(t, injector.SeedContextualScope(requestCtx, requestScopeKeyVal, KeyOf[*Session](), nil),
+		"did not found binding")
+	assert.ErrorContains(t, injector.SeedContextualScope(context.Background(), requestScopeKeyVal,
+		KeyOf[*Request](), nil), "Scope is not active")
+
+	otherRequestCtx := WithContextualScopeEnabled(context.Background(), requestScopeKeyVal)
+	assert.Nil(t, injector.Invoke(otherRequestCtx, func(_ *Request) {}))
+	assert.Equal(t, 1, PendingDestroyCallbacks(otherRequestCtx, requestScopeKeyVal))
+	ShutdownContextualScope(otherRequestCtx, requestScopeKeyVal)
+	assert.Equal(t, 0, PendingDestroyCallbacks(otherRequestCtx, requestScopeKeyVal))
+}
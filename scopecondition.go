@@ -0,0 +1,111 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// activatableScope is implemented by scopes that are only active in some contexts
+type activatableScope interface {
+	isActive(ctx context.Context) bool
+}
+
+func (s *contextualScope) isActive(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	_, ok := ctx.Value(s.key).(*instanceRegistry)
+	return ok
+}
+
+func (s *tenantScope) isActive(ctx context.Context) bool {
+	return ctx != nil && s.extractor(ctx) != ""
+}
+
+type whenInScopeOption struct {
+	scope   string
+	options []Option
+}
+
+func (o *whenInScopeOption) apply(mod *configuration) error {
+	installed := make(map[*binding]bool, len(mod.bindings))
+	for b := range mod.bindings {
+		installed[b] = true
+	}
+	for _, option := range o.options {
+		if err := option.apply(mod); err != nil {
+			return err
+		}
+	}
+	for b := range mod.bindings {
+		if !installed[b] {
+			b.activeInScope = o.scope
+		}
+	}
+	return nil
+}
+
+// WhenInScope return an Option whose bindings only resolve while the given scope (a contextual or tenant scope) is
+// active. While it is active, they take precedence over the other bindings with the same key, e.g. to decorate a
+// global service for requests: their own dependencies on the same key resolve to the other bindings. Otherwise,
+// resolution falls back to the other bindings, or to absence for optional injections.
+//
+//	WhenInScope("request", Provide(func(base *Service) *Service { return base.ForRequest() }, In("request")))
+func WhenInScope(scope string, options ...Option) Option {
+	return &whenInScopeOption{scope: scope, options: options}
+}
+
+// checkScopeConditions return an error for each scope of WhenInScope that cannot be active or inactive
+func (mod *configuration) checkScopeConditions() []error {
+	var errs []error
+	checked := make(map[string]bool)
+	for b := range mod.bindings {
+		if b.activeInScope == "" || checked[b.activeInScope] {
+			continue
+		}
+		checked[b.activeInScope] = true
+		if _, ok := mod.scopes[b.activeInScope].(activatableScope); !ok {
+			errs = append(errs, newInjectorConfigurationError(
+				fmt.Sprintf("scope %q of WhenInScope must be a registered contextual or tenant scope", b.activeInScope),
+				nil,
+			))
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+	return errs
+}
+
+// activeBindings return the bindings of WhenInScope whose scope is active in ctx if any, the other bindings
+// otherwise. Bindings disabled by their toggle or belonging to a stopped unit are ignored.
+func (injector *Injector) activeBindings(ctx context.Context, bindings []*binding) []*binding {
+	decorating := len(bindings) > 0 && isDecorating(ctx, bindings[0].key())
+	var active, unconditional []*binding
+	for _, b := range bindings {
+		if !injector.isEnabled(b) || !injector.isUnitRunning(b) {
+			continue
+		} else if b.activeInScope == "" {
+			unconditional = append(unconditional, b)
+		} else if decorating {
+			continue
+		} else if scope, ok := injector.scopes[b.activeInScope].(activatableScope); ok && scope.isActive(ctx) {
+			active = append(active, b)
+		}
+	}
+	if len(active) > 0 {
+		return active
+	}
+	if len(unconditional) == len(bindings) {
+		return bindings
+	}
+	return unconditional
+}
+
+// isDecorating return true if ctx is the context of the creation of a WhenInScope binding of key, whose dependencies
+// on key resolve to the bindings it decorates
+func isDecorating(ctx context.Context, key BindingKey) bool {
+	step, ok := creationStepFromContext(ctx)
+	return ok && step.inScope && step.binding.Key == key
+}
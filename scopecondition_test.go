@@ -0,0 +1,86 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Greeting struct {
+	Text string
+}
+
+func TestWhenInScope(t *testing.T) {
+	injector, err := NewInjector(
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+		Provide(func() *Greeting { return &Greeting{Text: "hello"} }),
+		WhenInScope("request",
+			Provide(func(_ *Request) *Greeting { return &Greeting{Text: "hello request"} }, In("request")),
+			Provide(func() *Request { return &Request{ID: 42} }, In("request")),
+		),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(g *Greeting, greetings []*Greeting, r Optional[*Request]) {
+		assert.Equal(t, "hello", g.Text)
+		assert.Len(t, greetings, 1)
+		assert.Equal(t, OptionalMissing, r.State())
+	})
+	assert.Nil(t, err)
+
+	requestCtx := WithContextualScopeEnabled(ctx, requestScopeKeyVal)
+	defer ShutdownContextualScope(requestCtx, requestScopeKeyVal)
+	err = injector.Invoke(requestCtx, func(g *Greeting, greetings []*Greeting, r *Request) {
+		assert.Equal(t, "hello request", g.Text)
+		assert.Equal(t, []*Greeting{g}, greetings)
+		assert.Equal(t, 42, r.ID)
+	})
+	assert.Nil(t, err)
+
+	_, err = NewInjector(
+		WhenInScope(PerLookUp, Provide(func() *Greeting { return &Greeting{} })),
+	)
+	assert.ErrorContains(t, err,
+		`scope "inject.PerLookUp" of WhenInScope must be a registered contextual or tenant scope`)
+}
+
+func TestWhenInScopeShouldDecorateGlobalBindings(t *testing.T) {
+	injector, err := NewInjector(
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+		Provide(func() *Greeting { return &Greeting{Text: "hello"} }),
+		WhenInScope("request",
+			Provide(func(base *Greeting) *Greeting {
+				return &Greeting{Text: base.Text + " request"}
+			}, In("request")),
+		),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	requestCtx := WithContextualScopeEnabled(ctx, requestScopeKeyVal)
+	defer ShutdownContextualScope(requestCtx, requestScopeKeyVal)
+	err = injector.Invoke(requestCtx, func(g *Greeting) {
+		assert.Equal(t, "hello request", g.Text)
+	})
+	assert.Nil(t, err)
+	err = injector.Invoke(ctx, func(g *Greeting) {
+		assert.Equal(t, "hello", g.Text)
+	})
+	assert.Nil(t, err)
+
+	t.Run("Cycles through the decorated binding should be detected", func(t *testing.T) {
+		injector, err := NewInjector(
+			RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+			Provide(func(_ *Request) *Greeting { return &Greeting{} }, In(PerLookUp)),
+			Provide(func(_ *Greeting) *Request { return &Request{} }, In(PerLookUp)),
+			WhenInScope("request",
+				Provide(func(base *Greeting) *Greeting { return base }, In("request")),
+			),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(requestCtx, func(_ *Greeting) {})
+		assert.ErrorContains(t, err, "dependency cycle detected")
+	})
+}
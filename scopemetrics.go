@@ -0,0 +1,113 @@
+package goinject
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ScopeClosedEvent is notified when an activation of a contextual scope (see NewContextualScope) is shut down, if
+// at least one instance was resolved in it. It lets observers record scope lifetime histograms and per-scope
+// instance counts, see also Injector.ActiveScopes.
+type ScopeClosedEvent struct {
+	Scope     string        // name the scope is registered with
+	Lifetime  time.Duration // time elapsed since the scope was enabled
+	Instances int           // number of instances resolved in the activation
+}
+
+func (ScopeClosedEvent) isEvent() {}
+
+// scopeActivations track the activations of a contextual scope in which instances were resolved
+type scopeActivations struct {
+	mu        sync.Mutex
+	active    map[*instanceRegistry]bool
+	listeners []func(event ScopeClosedEvent) // notify the observers of the injectors the scope is registered in
+}
+
+// track record the activation of registry, until it is shut down
+func (a *scopeActivations) track(registry *instanceRegistry) {
+	a.mu.Lock()
+	if a.active[registry] {
+		a.mu.Unlock()
+		return
+	}
+	if a.active == nil {
+		a.active = make(map[*instanceRegistry]bool)
+	}
+	a.active[registry] = true
+	a.mu.Unlock()
+	// the hook is registered without holding the lock, as the registry calls it with its own lock held
+	registry.onShutdown(func(instances int) {
+		a.mu.Lock()
+		delete(a.active, registry)
+		listeners := a.listeners
+		a.mu.Unlock()
+		event := ScopeClosedEvent{Lifetime: time.Since(registry.createdAt), Instances: instances}
+		for _, listener := range listeners {
+			listener(event)
+		}
+	})
+}
+
+// registries return the tracked activations
+func (a *scopeActivations) registries() []*instanceRegistry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	res := make([]*instanceRegistry, 0, len(a.active))
+	for registry := range a.active {
+		res = append(res, registry)
+	}
+	return res
+}
+
+func (a *scopeActivations) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.active)
+}
+
+func (a *scopeActivations) listen(listener func(event ScopeClosedEvent)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.listeners = append(a.listeners, listener)
+}
+
+// observeScopes notify the injector observers of the ScopeClosedEvent of its contextual scopes
+func (injector *Injector) observeScopes() {
+	if len(injector.observers) == 0 {
+		return
+	}
+	for name, scope := range injector.scopes {
+		if s, ok := scope.(*contextualScope); ok {
+			s.activations.listen(func(event ScopeClosedEvent) {
+				event.Scope = name
+				injector.notify(event)
+			})
+		}
+	}
+}
+
+// LiveSingletons return the keys of the singleton bindings whose instance is created (or being created), sorted
+func (injector *Injector) LiveSingletons() []BindingKey {
+	var res []BindingKey
+	for _, b := range injector.allBindings() {
+		if b.scope == Singleton && injector.singletonScope.instanceRegistry.has(b) {
+			res = append(res, b.key())
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].String() < res[j].String() })
+	return res
+}
+
+// ActiveScopes return the number of activations of each contextual scope (by registration name) in which instances
+// were resolved and that are not shut down yet, e.g. to detect request scope leaks. Activations in which nothing
+// was resolved are not counted.
+func (injector *Injector) ActiveScopes() map[string]int {
+	res := make(map[string]int)
+	for name, scope := range injector.scopes {
+		if s, ok := scope.(*contextualScope); ok {
+			res[name] = s.activations.count()
+		}
+	}
+	return res
+}
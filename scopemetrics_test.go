@@ -0,0 +1,55 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeMetrics(t *testing.T) {
+	var events []ScopeClosedEvent
+	injector, err := NewInjector(
+		WithObserver(func(event Event) {
+			if closed, ok := event.(ScopeClosedEvent); ok {
+				events = append(events, closed)
+			}
+		}),
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+		Provide(func() *Request { return &Request{ID: 1} }, In("request")),
+		Provide(func() *Session { return &Session{ID: 2} }, In("request")),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, injector.ActiveScopes()["request"])
+
+	ctx := context.Background()
+	firstCtx := WithContextualScopeEnabled(ctx, requestScopeKeyVal)
+	secondCtx := WithContextualScopeEnabled(ctx, requestScopeKeyVal)
+	unusedCtx := WithContextualScopeEnabled(ctx, requestScopeKeyVal)
+	assert.Nil(t, injector.Invoke(firstCtx, func(_ *Request, _ *Session) {}))
+	assert.Nil(t, injector.Invoke(firstCtx, func(_ *Request) {}))
+	assert.Nil(t, injector.Invoke(secondCtx, func(_ *Request) {}))
+	assert.Equal(t, 2, injector.ActiveScopes()["request"])
+
+	ShutdownContextualScope(firstCtx, requestScopeKeyVal)
+	ShutdownContextualScope(unusedCtx, requestScopeKeyVal)
+	assert.Equal(t, 1, injector.ActiveScopes()["request"])
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "request", events[0].Scope)
+		assert.Equal(t, 2, events[0].Instances)
+		assert.Positive(t, events[0].Lifetime)
+	}
+
+	ShutdownContextualScope(secondCtx, requestScopeKeyVal)
+	assert.Equal(t, 0, injector.ActiveScopes()["request"])
+	assert.Len(t, events, 2)
+}
+
+func TestLiveSingletons(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func() *Request { return &Request{ID: 1} }),
+		Provide(func() *Session { return &Session{ID: 2} }, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []BindingKey{KeyOf[*Request]()}, injector.LiveSingletons())
+}
@@ -0,0 +1,220 @@
+// Package scopetest provides a conformance test suite for goinject Scope implementations, exercising them through
+// an injector the way applications do:
+//
+//	func TestRequestScope(t *testing.T) {
+//		scopetest.Run(t, func() scopetest.Harness {
+//			return scopetest.Harness{
+//				Scope: NewRequestScope(),
+//				Activate: func(ctx context.Context) context.Context { return WithRequest(ctx) },
+//				Shutdown: func(ctx context.Context, _ *goinject.Injector) { EndRequest(ctx) },
+//			}
+//		})
+//	}
+package scopetest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// ScopeName is the name the scope under test is registered with
+const ScopeName = "scopetest"
+
+// Harness describe the scope under test
+type Harness struct {
+	Scope goinject.Scope
+	// Activate return a context in which the scope is active, the scope is expected to be active in the returned
+	// context as is if nil
+	Activate func(ctx context.Context) context.Context
+	// Shutdown destroy the instances of the activation of ctx, the injector is shut down if nil
+	Shutdown func(ctx context.Context, injector *goinject.Injector)
+}
+
+// Concurrency is the number of concurrent resolutions of the concurrency test
+const Concurrency = 50
+
+// Timeout is the maximum duration of a resolution, longer resolutions are reported as deadlocks
+var Timeout = 5 * time.Second
+
+type leaf struct {
+	id int
+}
+
+type root struct {
+	leaf *leaf
+}
+
+type failing struct{}
+
+type reentrant struct {
+	leaf *leaf
+}
+
+// Run run the conformance test suite against the scopes created by newScope, each test using a new scope
+func Run(t *testing.T, newScope func() Harness) {
+	t.Run("instances are shared within an activation", func(t *testing.T) {
+		h := newScope()
+		var created atomic.Int32
+		injector := newInjector(t, h, goinject.Provide(func() *leaf {
+			return &leaf{id: int(created.Add(1))}
+		}, goinject.In(ScopeName)))
+		ctx := activate(h)
+		defer shutdown(h, ctx, injector)
+
+		first := resolve[*leaf](t, injector, ctx)
+		second := resolve[*leaf](t, injector, ctx)
+		if first != second || created.Load() != 1 {
+			t.Errorf("expected a single instance, got %d creations", created.Load())
+		}
+	})
+
+	t.Run("concurrent resolutions create a single instance", func(t *testing.T) {
+		h := newScope()
+		var created atomic.Int32
+		injector := newInjector(t, h, goinject.Provide(func() *leaf {
+			time.Sleep(time.Millisecond)
+			return &leaf{id: int(created.Add(1))}
+		}, goinject.In(ScopeName)))
+		ctx := activate(h)
+		defer shutdown(h, ctx, injector)
+
+		var wg sync.WaitGroup
+		instances := make([]*leaf, Concurrency)
+		for i := range instances {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				instances[i] = resolve[*leaf](t, injector, ctx)
+			}()
+		}
+		wg.Wait()
+		if created.Load() != 1 {
+			t.Errorf("expected a single creation, got %d", created.Load())
+		}
+		for _, instance := range instances {
+			if instance != instances[0] {
+				t.Errorf("concurrent resolutions returned different instances")
+				break
+			}
+		}
+	})
+
+	t.Run("instances are destroyed in reverse creation order", func(t *testing.T) {
+		h := newScope()
+		var mu sync.Mutex
+		var destroyed []string
+		destroy := func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			destroyed = append(destroyed, name)
+		}
+		injector := newInjector(t, h,
+			goinject.Provide(func() *leaf { return &leaf{} }, goinject.In(ScopeName),
+				goinject.WithDestroy(func(_ *leaf) { destroy("leaf") })),
+			goinject.Provide(func(l *leaf) *root { return &root{leaf: l} }, goinject.In(ScopeName),
+				goinject.WithDestroy(func(_ *root) { destroy("root") })),
+		)
+		ctx := activate(h)
+		resolve[*root](t, injector, ctx)
+		shutdown(h, ctx, injector)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(destroyed) != 2 || destroyed[0] != "root" || destroyed[1] != "leaf" {
+			t.Errorf("expected root then leaf to be destroyed, got %v", destroyed)
+		}
+	})
+
+	t.Run("creation errors are propagated and not cached", func(t *testing.T) {
+		h := newScope()
+		providerErr := errors.New("creation failed")
+		var created atomic.Int32
+		injector := newInjector(t, h, goinject.Provide(func() (*failing, error) {
+			created.Add(1)
+			return nil, providerErr
+		}, goinject.In(ScopeName)))
+		ctx := activate(h)
+		defer shutdown(h, ctx, injector)
+
+		for i := 0; i < 2; i++ {
+			err := invoke(injector, ctx, func(_ *failing) {})
+			if !errors.Is(err, providerErr) {
+				t.Errorf("expected the provider error, got %v", err)
+			}
+		}
+		if created.Load() != 2 {
+			t.Errorf("expected failed creations to be retried, got %d creations", created.Load())
+		}
+	})
+
+	t.Run("providers can resolve instances of the same scope", func(t *testing.T) {
+		h := newScope()
+		injector := newInjector(t, h,
+			goinject.Provide(func() *leaf { return &leaf{} }, goinject.In(ScopeName)),
+			goinject.Provide(func(ctx goinject.InvocationContext, injector *goinject.Injector) (*reentrant, error) {
+				res := &reentrant{}
+				err := injector.Invoke(ctx, func(l *leaf) { res.leaf = l })
+				return res, err
+			}, goinject.In(ScopeName)),
+		)
+		ctx := activate(h)
+		defer shutdown(h, ctx, injector)
+
+		r := resolve[*reentrant](t, injector, ctx)
+		if r != nil && r.leaf != resolve[*leaf](t, injector, ctx) {
+			t.Errorf("expected the re-entrant resolution to share the instance of the scope")
+		}
+	})
+}
+
+func newInjector(t *testing.T, h Harness, options ...goinject.Option) *goinject.Injector {
+	t.Helper()
+	injector, err := goinject.NewInjector(append(options, goinject.RegisterScope(ScopeName, h.Scope))...)
+	if err != nil {
+		t.Fatalf("failed to create injector: %v", err)
+	}
+	return injector
+}
+
+func activate(h Harness) context.Context {
+	if h.Activate == nil {
+		return context.Background()
+	}
+	return h.Activate(context.Background())
+}
+
+func shutdown(h Harness, ctx context.Context, injector *goinject.Injector) {
+	if h.Shutdown == nil {
+		injector.Shutdown()
+		return
+	}
+	h.Shutdown(ctx, injector)
+}
+
+// invoke call injector.Invoke, failing with a deadlock error after Timeout
+func invoke(injector *goinject.Injector, ctx context.Context, function any) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- injector.Invoke(ctx, function)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(Timeout):
+		return errors.New("resolution deadlocked")
+	}
+}
+
+func resolve[T any](t *testing.T, injector *goinject.Injector, ctx context.Context) T {
+	var res T
+	if err := invoke(injector, ctx, func(instance T) { res = instance }); err != nil {
+		t.Errorf("failed to resolve %T: %v", res, err)
+	}
+	return res
+}
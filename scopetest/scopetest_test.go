@@ -0,0 +1,43 @@
+package scopetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/illuin-tech/goinject"
+)
+
+type requestScopeKey struct{}
+
+type tenantKey struct{}
+
+func TestContextualScope(t *testing.T) {
+	Run(t, func() Harness {
+		return Harness{
+			Scope: goinject.NewContextualScope(requestScopeKey{}),
+			Activate: func(ctx context.Context) context.Context {
+				return goinject.WithContextualScopeEnabled(ctx, requestScopeKey{})
+			},
+			Shutdown: func(ctx context.Context, _ *goinject.Injector) {
+				goinject.ShutdownContextualScope(ctx, requestScopeKey{})
+			},
+		}
+	})
+}
+
+func TestTenantScope(t *testing.T) {
+	Run(t, func() Harness {
+		return Harness{
+			Scope: goinject.NewTenantScope(func(ctx context.Context) string {
+				tenant, _ := ctx.Value(tenantKey{}).(string)
+				return tenant
+			}),
+			Activate: func(ctx context.Context) context.Context {
+				return context.WithValue(ctx, tenantKey{}, "acme")
+			},
+			Shutdown: func(_ context.Context, injector *goinject.Injector) {
+				goinject.ShutdownTenant(injector, "acme")
+			},
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"sync/atomic"
+)
+
+// SelectionCandidate describes a binding matching a single-value injection, see Selector
+type SelectionCandidate struct {
+	Key          BindingKey
+	Scope        string
+	ProvidedType reflect.Type
+	Weight       int // see Weight
+}
+
+// Selector choose the binding satisfying a single-value injection when several bindings match, it returns the
+// index of the chosen candidate. Candidates are in registration order. The resolution context is given so that
+// selectors can route on request attributes.
+type Selector func(ctx context.Context, candidates []SelectionCandidate) (int, error)
+
+type selectorOption struct {
+	key      BindingKey
+	selector Selector
+}
+
+func (o *selectorOption) apply(mod *configuration) error {
+	if o.selector == nil {
+		return newInjectorConfigurationError(fmt.Sprintf("cannot accept nil selector for %s", o.key), nil)
+	}
+	if mod.selectors == nil {
+		mod.selectors = make(map[BindingKey]Selector)
+	}
+	mod.selectors[o.key] = o.selector
+	return nil
+}
+
+// WithSelector register the Selector choosing among the bindings with the given key when a single instance is
+// requested (e.g. to A/B test implementations bound with As). Slices of the key type still receive all instances.
+func WithSelector(key BindingKey, selector Selector) Option {
+	return &selectorOption{key: key, selector: selector}
+}
+
+type weightAnnotation struct {
+	weight int
+}
+
+func (a *weightAnnotation) apply(b *binding) error {
+	if a.weight <= 0 {
+		return newInjectorConfigurationError("argument of Weight must be positive", nil)
+	}
+	b.weight = a.weight
+	return nil
+}
+
+// Weight return an annotation setting the weight of the binding used by the Weighted selector, bindings without
+// Weight annotation have a weight of 1
+func Weight(weight int) Annotation {
+	return &weightAnnotation{weight: weight}
+}
+
+// RoundRobin return a Selector choosing the candidates in turn
+func RoundRobin() Selector {
+	var next atomic.Uint64
+	return func(_ context.Context, candidates []SelectionCandidate) (int, error) {
+		return int((next.Add(1) - 1) % uint64(len(candidates))), nil
+	}
+}
+
+// Weighted return a Selector choosing a candidate at random, proportionally to its Weight
+func Weighted() Selector {
+	return func(_ context.Context, candidates []SelectionCandidate) (int, error) {
+		total := 0
+		for _, c := range candidates {
+			total += c.Weight
+		}
+		n := rand.IntN(total)
+		for i, c := range candidates {
+			if n < c.Weight {
+				return i, nil
+			}
+			n -= c.Weight
+		}
+		return len(candidates) - 1, nil
+	}
+}
+
+// selectBinding return the binding chosen by the selector among bindings
+func selectBinding(ctx context.Context, selector Selector, bindings []*binding) (*binding, error) {
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return bindings[i].order < bindings[j].order
+	})
+	candidates := make([]SelectionCandidate, len(bindings))
+	for i, b := range bindings {
+		candidates[i] = SelectionCandidate{Key: b.key(), Scope: b.scope, ProvidedType: b.providedType, Weight: 1}
+		if b.weight > 0 {
+			candidates[i].Weight = b.weight
+		}
+	}
+	i, err := selector(ctx, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("selector returned error: %w", err)
+	}
+	if i < 0 || i >= len(bindings) {
+		return nil, fmt.Errorf("selector returned index %d out of the %d candidates", i, len(bindings))
+	}
+	return bindings[i], nil
+}
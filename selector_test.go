@@ -0,0 +1,90 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type shapeKey struct{}
+
+func TestSelector(t *testing.T) {
+	shapes := func(opts ...Option) []Option {
+		return append([]Option{
+			Deterministic(),
+			Provide(func() *Rectangle { return &Rectangle{} }, As(Type[Shape]()), Weight(3)),
+			Provide(func() *Square { return &Square{} }, As(Type[Shape]())),
+		}, opts...)
+	}
+	ctx := context.Background()
+
+	t.Run("RoundRobin should choose the candidates in turn", func(t *testing.T) {
+		injector, err := NewInjector(shapes(WithSelector(KeyOf[Shape](), RoundRobin()))...)
+		assert.Nil(t, err)
+		var names []string
+		for i := 0; i < 3; i++ {
+			err = injector.Invoke(ctx, func(s Shape) { names = append(names, s.Name()) })
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, []string{"rectangle", "square", "rectangle"}, names)
+
+		err = injector.Invoke(ctx, func(s []Shape) { assert.Len(t, s, 2) })
+		assert.Nil(t, err)
+	})
+
+	t.Run("Selector should receive the candidates and the resolution context", func(t *testing.T) {
+		var candidates []SelectionCandidate
+		injector, err := NewInjector(shapes(WithSelector(KeyOf[Shape](),
+			func(ctx context.Context, c []SelectionCandidate) (int, error) {
+				candidates = c
+				if ctx.Value(shapeKey{}) == "square" {
+					return 1, nil
+				}
+				return 0, nil
+			}))...)
+		assert.Nil(t, err)
+		err = injector.Invoke(context.WithValue(ctx, shapeKey{}, "square"), func(s Shape) {
+			assert.Equal(t, "square", s.Name())
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []SelectionCandidate{
+			{Key: KeyOf[Shape](), Scope: Singleton, ProvidedType: reflect.TypeFor[*Rectangle](), Weight: 3},
+			{Key: KeyOf[Shape](), Scope: Singleton, ProvidedType: reflect.TypeFor[*Square](), Weight: 1},
+		}, candidates)
+	})
+
+	t.Run("Weighted should choose candidates proportionally to their weight", func(t *testing.T) {
+		injector, err := NewInjector(shapes(WithSelector(KeyOf[Shape](), Weighted()))...)
+		assert.Nil(t, err)
+		counts := map[string]int{}
+		for i := 0; i < 400; i++ {
+			err = injector.Invoke(ctx, func(s Shape) { counts[s.Name()]++ })
+			assert.Nil(t, err)
+		}
+		assert.Greater(t, counts["rectangle"], counts["square"])
+		assert.Positive(t, counts["square"])
+	})
+
+	t.Run("Selector errors should fail the resolution", func(t *testing.T) {
+		selectorErr := errors.New("no route")
+		injector, err := NewInjector(shapes(WithSelector(KeyOf[Shape](),
+			func(_ context.Context, _ []SelectionCandidate) (int, error) { return 0, selectorErr }))...)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(_ Shape) { assert.Fail(t, "should not be reached") })
+		assert.ErrorIs(t, err, selectorErr)
+
+		injector, err = NewInjector(shapes(WithSelector(KeyOf[Shape](),
+			func(_ context.Context, _ []SelectionCandidate) (int, error) { return 2, nil }))...)
+		assert.Nil(t, err)
+		err = injector.Invoke(ctx, func(_ Shape) { assert.Fail(t, "should not be reached") })
+		assert.ErrorContains(t, err, "selector returned index 2 out of the 2 candidates")
+	})
+
+	t.Run("WithSelector should not accept nil", func(t *testing.T) {
+		_, err := NewInjector(WithSelector(KeyOf[Shape](), nil))
+		assert.IsType(t, err, &ConfigurationReport{})
+	})
+}
@@ -0,0 +1,170 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ShadowDivergenceEvent is notified when a call mirrored to a shadow binding (see Shadow) returned results
+// different from the primary binding ones, or panicked
+type ShadowDivergenceEvent struct {
+	Primary BindingKey
+	Shadow  BindingKey
+	Method  string
+	Want    []any // results of the primary
+	Got     []any // results of the shadow, nil if it panicked
+	Err     error // set if the shadow call panicked
+}
+
+func (ShadowDivergenceEvent) isEvent() {}
+
+// ShadowOption configure Shadow
+type ShadowOption func(s *shadowConfiguration)
+
+type shadowConfiguration struct {
+	equal func(want, got []any) bool
+}
+
+// WithShadowComparator replace the comparison of primary and shadow results. By default, errors are compared by
+// message and other results with reflect.DeepEqual.
+func WithShadowComparator(equal func(want, got []any) bool) ShadowOption {
+	return func(s *shadowConfiguration) {
+		s.equal = equal
+	}
+}
+
+// Shadowed call the primary implementation of S and mirror each call to the shadow implementation in a new
+// goroutine, see Shadow
+type Shadowed[S any] struct {
+	injector      *Injector
+	primaryKey    BindingKey
+	shadowKey     BindingKey
+	primary       S
+	shadow        S
+	configuration *shadowConfiguration
+}
+
+// Primary return the primary implementation
+func (s *Shadowed[S]) Primary() S {
+	return s.primary
+}
+
+// Call call the method of the primary implementation with args and return its results, the same call is
+// mirrored to the shadow implementation asynchronously. Arguments are passed as is to both implementations, so
+// they must be safe to use concurrently (e.g. a context.Context must outlive the shadow call).
+// An error is returned, and nothing is called, if S has no such method or if args do not match its parameters.
+func (s *Shadowed[S]) Call(method string, args ...any) ([]any, error) {
+	in, err := shadowCallArguments(reflect.TypeFor[S](), method, args)
+	if err != nil {
+		return nil, err
+	}
+	want := valuesToAny(reflect.ValueOf(s.primary).MethodByName(method).Call(in))
+	go s.mirror(method, in, want)
+	return want, nil
+}
+
+// shadowCallArguments return args as the arguments of the method of t, checking their number and types
+func shadowCallArguments(t reflect.Type, method string, args []any) ([]reflect.Value, error) {
+	m, ok := t.MethodByName(method)
+	if !ok {
+		return nil, newInvalidInputError(fmt.Sprintf("cannot call %s: %s has no such method", method, t))
+	}
+	methodType := m.Type
+	offset := 1 // method of concrete type have the receiver as first argument
+	if t.Kind() == reflect.Interface {
+		offset = 0
+	}
+	params := methodType.NumIn() - offset
+	if len(args) != params && (!methodType.IsVariadic() || len(args) < params-1) {
+		return nil, newInvalidInputError(
+			fmt.Sprintf("cannot call %s.%s: got %d arguments, want %d", t, method, len(args), params))
+	}
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		paramType := methodType.In(min(i, params-1) + offset)
+		if methodType.IsVariadic() && i >= params-1 {
+			paramType = paramType.Elem()
+		}
+		if in[i] = reflect.ValueOf(arg); arg == nil {
+			in[i] = reflect.Zero(paramType)
+		} else if !in[i].Type().AssignableTo(paramType) {
+			return nil, newInvalidInputError(
+				fmt.Sprintf("cannot call %s.%s: argument #%d of type %s is not assignable to %s",
+					t, method, i, in[i].Type(), paramType))
+		}
+	}
+	return in, nil
+}
+
+func (s *Shadowed[S]) mirror(method string, in []reflect.Value, want []any) {
+	event := ShadowDivergenceEvent{Primary: s.primaryKey, Shadow: s.shadowKey, Method: method, Want: want}
+	defer func() {
+		if r := recover(); r != nil {
+			event.Err = fmt.Errorf("shadow call panicked: %v", r)
+			s.injector.notify(event)
+		}
+	}()
+	got := valuesToAny(reflect.ValueOf(s.shadow).MethodByName(method).Call(in))
+	if !s.configuration.equal(want, got) {
+		event.Got = got
+		s.injector.notify(event)
+	}
+}
+
+func valuesToAny(values []reflect.Value) []any {
+	res := make([]any, len(values))
+	for i, v := range values {
+		res[i] = v.Interface()
+	}
+	return res
+}
+
+func equalShadowResults(want, got []any) bool {
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		wantErr, wantIsErr := want[i].(error)
+		gotErr, gotIsErr := got[i].(error)
+		if wantIsErr || gotIsErr {
+			if !wantIsErr || !gotIsErr || wantErr.Error() != gotErr.Error() {
+				return false
+			}
+		} else if !reflect.DeepEqual(want[i], got[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Shadow bind a *Shadowed[S] calling the binding of S annotated with primary and mirroring calls to the binding
+// of S annotated with shadow, which is the common pattern to migrate to a new implementation. Divergent results
+// are notified to observers (see WithObserver) as ShadowDivergenceEvent, from the mirroring goroutine.
+// S must be implemented by a thin facade delegating its methods to Shadowed.Call, see WithProxy.
+func Shadow[S any, N ~string](primary, shadow N, opts ...ShadowOption) Option {
+	configuration := &shadowConfiguration{equal: equalShadowResults}
+	for _, opt := range opts {
+		opt(configuration)
+	}
+	primaryKey := KeyOf[S](string(primary))
+	shadowKey := KeyOf[S](string(shadow))
+	return &provideOption{constructor: func(ctx InvocationContext, injector *Injector) (*Shadowed[S], error) {
+		res := &Shadowed[S]{
+			injector:      injector,
+			primaryKey:    primaryKey,
+			shadowKey:     shadowKey,
+			configuration: configuration,
+		}
+		primaryValue, err := injector.getInstanceOfAnnotatedType(ctx, primaryKey.Type, primaryKey.Annotation, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get primary: %w", err)
+		}
+		shadowValue, err := injector.getInstanceOfAnnotatedType(ctx, shadowKey.Type, shadowKey.Annotation, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get shadow: %w", err)
+		}
+		res.primary, _ = primaryValue.Interface().(S)
+		res.shadow, _ = shadowValue.Interface().(S)
+		return res, nil
+	}, location: callerLocation()}
+}
@@ -0,0 +1,125 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type PriceService interface {
+	Price(ctx context.Context, item string) (int, error)
+}
+
+type legacyPriceService struct{}
+
+func (s *legacyPriceService) Price(_ context.Context, item string) (int, error) {
+	if item == "" {
+		return 0, errors.New("unknown item")
+	}
+	return 10, nil
+}
+
+type newPriceService struct{}
+
+func (s *newPriceService) Price(_ context.Context, item string) (int, error) {
+	if item == "" {
+		return 0, errors.New("unknown item")
+	}
+	if item == "panic" {
+		panic("not implemented")
+	}
+	return len(item), nil
+}
+
+// shadowedPriceService is the facade implementing PriceService with a Shadowed[PriceService]
+type shadowedPriceService struct {
+	shadowed *Shadowed[PriceService]
+}
+
+func (s *shadowedPriceService) Price(ctx context.Context, item string) (int, error) {
+	res, err := s.shadowed.Call("Price", ctx, item)
+	if err != nil {
+		return 0, err
+	}
+	err, _ = res[1].(error)
+	return res[0].(int), err
+}
+
+func TestShadow(t *testing.T) {
+	events := make(chan ShadowDivergenceEvent, 10)
+	injector, err := NewInjector(
+		WithObserver(func(event Event) {
+			if e, ok := event.(ShadowDivergenceEvent); ok {
+				events <- e
+			}
+		}),
+		Provide(func() *legacyPriceService { return &legacyPriceService{} }, As(Type[PriceService]()), Named("legacy")),
+		Provide(func() *newPriceService { return &newPriceService{} }, As(Type[PriceService]()), Named("new")),
+		Shadow[PriceService]("legacy", "new"),
+		Provide(func(s *Shadowed[PriceService]) PriceService { return &shadowedPriceService{shadowed: s} }),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(s PriceService) {
+		price, err := s.Price(ctx, "")
+		assert.Equal(t, 0, price)
+		assert.EqualError(t, err, "unknown item")
+
+		price, err = s.Price(ctx, "book")
+		assert.Equal(t, 10, price)
+		assert.Nil(t, err)
+
+		_, _ = s.Price(ctx, "panic")
+	})
+	assert.Nil(t, err)
+
+	divergences := []ShadowDivergenceEvent{<-events, <-events}
+	for _, e := range divergences {
+		assert.Equal(t, KeyOf[PriceService]("legacy"), e.Primary)
+		assert.Equal(t, KeyOf[PriceService]("new"), e.Shadow)
+		assert.Equal(t, "Price", e.Method)
+		if e.Err != nil {
+			assert.ErrorContains(t, e.Err, "shadow call panicked: not implemented")
+			assert.Nil(t, e.Got)
+		} else {
+			assert.Equal(t, []any{10, nil}, e.Want)
+			assert.Equal(t, []any{4, nil}, e.Got)
+		}
+	}
+	assert.Len(t, events, 0)
+
+	t.Run("WithShadowComparator should replace the comparison", func(t *testing.T) {
+		shadowInjector, err := NewInjector(
+			WithObserver(func(event Event) {
+				assert.Fail(t, "should not be notified")
+			}),
+			Provide(func() *legacyPriceService { return &legacyPriceService{} }, As(Type[PriceService]()), Named("legacy")),
+			Provide(func() *newPriceService { return &newPriceService{} }, As(Type[PriceService]()), Named("new")),
+			Shadow[PriceService]("legacy", "new", WithShadowComparator(func(_, _ []any) bool { return true })),
+		)
+		assert.Nil(t, err)
+		err = shadowInjector.Invoke(ctx, func(s *Shadowed[PriceService]) {
+			res, err := s.Call("Price", ctx, "book")
+			assert.Nil(t, err)
+			assert.Equal(t, []any{10, nil}, res)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Call should reject unknown methods and mismatching arguments", func(t *testing.T) {
+		err = injector.Invoke(ctx, func(s *Shadowed[PriceService]) {
+			_, err := s.Call("Cost", ctx, "book")
+			assert.ErrorContains(t, err, "cannot call Cost: goinject.PriceService has no such method")
+			_, err = s.Call("Price", ctx)
+			assert.ErrorContains(t, err, "cannot call goinject.PriceService.Price: got 1 arguments, want 2")
+			_, err = s.Call("Price", ctx, 42)
+			assert.ErrorContains(t, err, "argument #1 of type int is not assignable to string")
+			_, err = s.Call("Price", nil, "book")
+			assert.Nil(t, err)
+		})
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,57 @@
+package goinject
+
+import (
+	"context"
+	"sync"
+)
+
+// ShutdownHookFailedEvent is notified when a hook registered with OnShutdown returned an error
+type ShutdownHookFailedEvent struct {
+	Location string // source location of the OnShutdown call
+	Err      error
+}
+
+func (ShutdownHookFailedEvent) isEvent() {}
+
+type shutdownHook struct {
+	hook     func(ctx context.Context) error
+	location string
+}
+
+type onShutdownOption struct {
+	hook shutdownHook
+}
+
+func (o *onShutdownOption) apply(mod *configuration) error {
+	if o.hook.hook == nil {
+		return newInjectorConfigurationError("cannot accept nil shutdown hook", nil)
+	}
+	mod.shutdownHooks = append(mod.shutdownHooks, o.hook)
+	return nil
+}
+
+// OnShutdown return an Option registering a hook called by Injector.Shutdown, for teardown that is not attached to
+// an instance (e.g. flushing a global metrics buffer). Hooks are called once, in reverse registration order, after
+// the instances are destroyed. Their errors are notified to observers as ShutdownHookFailedEvent.
+func OnShutdown(hook func(ctx context.Context) error) Option {
+	return &onShutdownOption{hook: shutdownHook{hook: hook, location: callerLocation()}}
+}
+
+// shutdownHooks hold the hooks registered with OnShutdown that were not called yet
+type shutdownHooks struct {
+	mu    sync.Mutex
+	hooks []shutdownHook
+}
+
+// runShutdownHooks call the OnShutdown hooks in reverse registration order
+func (injector *Injector) runShutdownHooks() {
+	injector.shutdownHooks.mu.Lock()
+	hooks := injector.shutdownHooks.hooks
+	injector.shutdownHooks.hooks = nil
+	injector.shutdownHooks.mu.Unlock()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].hook(context.Background()); err != nil {
+			injector.notify(ShutdownHookFailedEvent{Location: hooks[i].location, Err: err})
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnShutdown(t *testing.T) {
+	flushErr := errors.New("flush failed")
+	var calls []string
+	var events []Event
+	injector, err := NewInjector(
+		WithObserver(func(event Event) {
+			events = append(events, event)
+		}),
+		Provide(func() *Parent { return &Parent{} }, WithDestroy(func(_ *Parent) {
+			calls = append(calls, "destroy parent")
+		})),
+		OnShutdown(func(_ context.Context) error {
+			calls = append(calls, "close exporter")
+			return nil
+		}),
+		Module("metrics",
+			OnShutdown(func(_ context.Context) error {
+				calls = append(calls, "flush metrics")
+				return flushErr
+			}),
+		),
+	)
+	assert.Nil(t, err)
+
+	injector.Shutdown()
+	injector.Shutdown()
+	assert.Equal(t, []string{"destroy parent", "flush metrics", "close exporter"}, calls)
+	if assert.Len(t, events, 1) {
+		failed := events[0].(ShutdownHookFailedEvent)
+		assert.ErrorIs(t, failed.Err, flushErr)
+		assert.Contains(t, failed.Location, "shutdown_test.go")
+	}
+
+	_, err = NewInjector(OnShutdown(nil))
+	assert.ErrorContains(t, err, "cannot accept nil shutdown hook")
+}
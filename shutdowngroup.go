@@ -0,0 +1,96 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+type shutdownGroupAnnotation struct {
+	name  string
+	order int
+}
+
+func (a *shutdownGroupAnnotation) apply(b *binding) error {
+	if a.name == "" {
+		return newInjectorConfigurationError("name of ShutdownGroup must not be empty", nil)
+	}
+	b.shutdownGroup = a.name
+	b.shutdownOrder = a.order
+	return nil
+}
+
+// ShutdownGroup return an annotation adding the binding to a named shutdown group, so that operational constraints
+// that dependencies cannot express order the destroy callbacks of unrelated bindings, e.g. closing listeners
+// (ShutdownGroup("network", 10)) before flushing queues (ShutdownGroup("queues", 20)) before closing databases
+// (ShutdownGroup("storage", 30)).
+// When a scope is shut down, the destroy callbacks of groups with lower orders run first, bindings without group
+// having the order 0. Within an order, callbacks run in reverse creation order as usual. Bindings of a group must
+// all have the same order.
+func ShutdownGroup(name string, order int) Annotation {
+	return &shutdownGroupAnnotation{name: name, order: order}
+}
+
+// destroyCallback is a destruction callback of an instanceRegistry
+type destroyCallback struct {
+	order int
+	fn    func()
+	key   any // binding of the destroyed instance if set, see instanceRegistry.replaceDestructionCallback
+}
+
+// nextDestroyCallback return the index of the callback to run first: the last registered one of the lowest order
+func nextDestroyCallback(callbacks []destroyCallback) int {
+	next := len(callbacks) - 1
+	for i := next - 1; i >= 0; i-- {
+		if callbacks[i].order < callbacks[next].order {
+			next = i
+		}
+	}
+	return next
+}
+
+type shutdownOrderContextKey struct{}
+
+// withShutdownOrder return ctx with the shutdown order used by the scopes to register destruction callbacks
+func withShutdownOrder(ctx context.Context, order int) context.Context {
+	if order == 0 && shutdownOrderOf(ctx) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, shutdownOrderContextKey{}, order)
+}
+
+// shutdownOrderOf return the shutdown order set in ctx, 0 if there is none
+func shutdownOrderOf(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	order, _ := ctx.Value(shutdownOrderContextKey{}).(int)
+	return order
+}
+
+// checkShutdownGroups return an error for each shutdown group whose bindings have different orders
+func (mod *configuration) checkShutdownGroups() []error {
+	orders := make(map[string]map[int]bool)
+	for b := range mod.bindings {
+		if b.shutdownGroup == "" {
+			continue
+		}
+		if orders[b.shutdownGroup] == nil {
+			orders[b.shutdownGroup] = make(map[int]bool)
+		}
+		orders[b.shutdownGroup][b.shutdownOrder] = true
+	}
+	var names []string
+	for name, groupOrders := range orders {
+		if len(groupOrders) > 1 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	errs := make([]error, 0, len(names))
+	for _, name := range names {
+		errs = append(errs, newInjectorConfigurationError(
+			fmt.Sprintf("bindings of shutdown group %q have different orders", name), nil))
+	}
+	return errs
+}
@@ -0,0 +1,38 @@
+package goinject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Listener struct{}
+
+type QueueFlusher struct{}
+
+type StorageClient struct{}
+
+func TestShutdownGroup(t *testing.T) {
+	var closed []string
+	injector, err := NewInjector(
+		Provide(func() *StorageClient { return &StorageClient{} }, ShutdownGroup("storage", 30),
+			WithDestroy(func(_ *StorageClient) { closed = append(closed, "storage") })),
+		Provide(func() *Listener { return &Listener{} }, ShutdownGroup("network", 10),
+			WithDestroy(func(_ *Listener) { closed = append(closed, "network") })),
+		Provide(func() *QueueFlusher { return &QueueFlusher{} }, ShutdownGroup("queues", 20),
+			WithDestroy(func(_ *QueueFlusher) { closed = append(closed, "queues") })),
+		Provide(func() *AppConfig { return &AppConfig{} },
+			WithDestroy(func(_ *AppConfig) { closed = append(closed, "config") })),
+	)
+	assert.Nil(t, err)
+	injector.Shutdown()
+	assert.Equal(t, []string{"config", "network", "queues", "storage"}, closed)
+
+	t.Run("Bindings of a group should have the same order", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *Listener { return &Listener{} }, ShutdownGroup("network", 10)),
+			Provide(func() *QueueFlusher { return &QueueFlusher{} }, ShutdownGroup("network", 20)),
+		)
+		assert.ErrorContains(t, err, "bindings of shutdown group \"network\" have different orders")
+	})
+}
@@ -0,0 +1,88 @@
+package goinject
+
+import (
+	"reflect"
+)
+
+type sizerAnnotation struct {
+	sizer any
+}
+
+func (a *sizerAnnotation) apply(b *binding) error {
+	sizerFnVal := reflect.ValueOf(a.sizer)
+	if sizerFnVal.Kind() != reflect.Func ||
+		sizerFnVal.Type().NumIn() != 1 ||
+		sizerFnVal.Type().In(0) != b.providedType ||
+		sizerFnVal.Type().NumOut() != 1 ||
+		sizerFnVal.Type().Out(0) != reflect.TypeFor[int64]() {
+		return newInjectorConfigurationError(
+			"argument of Sizer must be a function with the provided type as argument returning an int64", nil)
+	}
+	providedType := b.providedType
+	b.sizer = func(val reflect.Value) int64 {
+		if !val.IsValid() || !val.Type().AssignableTo(providedType) {
+			return 0
+		}
+		return sizerFnVal.Call([]reflect.Value{val})[0].Int()
+	}
+	return nil
+}
+
+// Sizer return an annotation declaring a function estimating the memory size (in bytes) of the instances of the
+// binding, aggregated by scope in Injector.MemoryEstimates to capacity-plan caches and tenant scopes.
+func Sizer(sizer any) Annotation {
+	return &sizerAnnotation{sizer: sizer}
+}
+
+// estimateSize return the sum of the sizes of the instances of bindings with a Sizer, instances being created are
+// ignored
+func (r *instanceRegistry) estimateSize() int64 {
+	r.mu.Lock()
+	sized := make(map[*binding]*instanceEntry)
+	for key, entry := range r.entries {
+		if b, ok := key.(*binding); ok && b.sizer != nil {
+			sized[b] = entry
+		}
+	}
+	r.mu.Unlock()
+
+	var size int64
+	for b, entry := range sized {
+		if !entry.lock.TryRLock() {
+			continue
+		}
+		instance, err := reflect.Value(entry.instance), entry.err
+		entry.lock.RUnlock()
+		if err == nil {
+			size += b.sizer(instance)
+		}
+	}
+	return size
+}
+
+// MemoryEstimates return the estimated memory size (in bytes) of the live instances of the bindings with a Sizer,
+// by scope name: singletons, all the active activations of contextual scopes (see NewContextualScope) and all the
+// tenants of tenant scopes (see NewTenantScope). Scopes without binding with a Sizer are not listed.
+func (injector *Injector) MemoryEstimates() map[string]int64 {
+	res := make(map[string]int64)
+	for _, b := range injector.allBindings() {
+		if b.sizer != nil {
+			res[b.scope] = 0
+		}
+	}
+	for name := range res {
+		var registries []*instanceRegistry
+		switch scope := injector.scopes[name].(type) {
+		case *singletonScope:
+			registries = []*instanceRegistry{scope.instanceRegistry}
+		case *contextualScope:
+			registries = scope.activations.registries()
+		case *tenantScope:
+			registries = scope.tenantRegistries()
+		}
+		for _, registry := range registries {
+			res[name] += registry.estimateSize()
+		}
+	}
+	return res
+}
@@ -0,0 +1,48 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryEstimates(t *testing.T) {
+	sizeOf := func(r *Request) int64 { return int64(r.ID) * 100 }
+	injector, err := NewInjector(
+		RegisterScope("request", NewContextualScope(requestScopeKeyVal)),
+		RegisterScope("tenant", NewTenantScope(tenantFromContext)),
+		Provide(func() *Request { return &Request{ID: 1} }, Sizer(sizeOf)),
+		Provide(func() *Request { return &Request{ID: 2} },
+			In("request"), Named("request"), Sizer(sizeOf)),
+		Provide(func() *Request { return &Request{ID: 3} },
+			In("tenant"), Named("tenant"), Sizer(sizeOf)),
+		Provide(func() *Session { return &Session{ID: 4} }, In("request")),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{Singleton: 100, "request": 0, "tenant": 0}, injector.MemoryEstimates())
+
+	type sizedParams struct {
+		Params
+		Singleton *Request
+		Request   *Request `inject:"request"`
+		Tenant    *Request `inject:"tenant"`
+		Session   *Session
+	}
+	ctx := context.Background()
+	firstCtx := WithContextualScopeEnabled(context.WithValue(ctx, tenantKey{}, "globex"), requestScopeKeyVal)
+	secondCtx := WithContextualScopeEnabled(context.WithValue(ctx, tenantKey{}, "acme"), requestScopeKeyVal)
+	assert.Nil(t, injector.Invoke(firstCtx, func(_ sizedParams) {}))
+	assert.Nil(t, injector.Invoke(secondCtx, func(_ sizedParams) {}))
+	assert.Equal(t, map[string]int64{Singleton: 100, "request": 400, "tenant": 600}, injector.MemoryEstimates())
+
+	ShutdownContextualScope(firstCtx, requestScopeKeyVal)
+	assert.Equal(t, int64(200), injector.MemoryEstimates()["request"])
+}
+
+func TestSizerShouldMatchProvidedType(t *testing.T) {
+	_, err := NewInjector(
+		Provide(func() *Request { return &Request{} }, Sizer(func(_ *Session) int64 { return 0 })),
+	)
+	assert.NotNil(t, err)
+}
@@ -16,7 +16,13 @@ import (
 //	annotation    Requests a value with the same name and type from the
 //	              container. See Named Values for more information.
 //	optional      If set to true, indicates that the dependency is optional and
-//	              the constructor gracefully handles its absence.
+//	              the constructor gracefully handles its absence. Errors of the
+//	              provider of an existing binding still fail the resolution,
+//	              use an Optional field to tolerate them.
+//
+// The default tag declares the literal used when there is no binding for the field
+// (e.g. `inject:"http.port" default:"8080"`). It is converted to the field type, which must be a string,
+// a boolean, a number, a time.Duration or a slice of those (comma separated).
 type Params struct{}
 
 var _paramType = reflect.TypeOf(Params{})
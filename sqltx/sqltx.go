@@ -0,0 +1,87 @@
+// Package sqltx binds database transactions to a goinject contextual scope.
+//
+// Within WithTx, the *sql.Tx binding resolves to a transaction begun lazily from the injected *sql.DB, so that
+// repositories provided in TxScope (or resolved with a Provider) run against that transaction:
+//
+//	goinject.Provide(func(tx *sql.Tx) *UserRepository { return &UserRepository{tx: tx} }, goinject.In(sqltx.TxScope))
+//
+//	err := sqltx.WithTx(ctx, injector, func(users *UserRepository) error {
+//		return users.Create(ctx, user)
+//	})
+package sqltx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// TxScope is the name of the contextual scope active within WithTx
+const TxScope = "sqltx.Transaction"
+
+type txScopeKey int
+
+const txScopeKeyVal txScopeKey = 0
+
+// ErrNoTransaction is returned when resolving *sql.Tx outside WithTx
+var ErrNoTransaction = errors.New("no transaction scope active")
+
+type txHolderKey struct{}
+
+// txHolder hold the transaction of a WithTx call, if one was begun
+type txHolder struct {
+	tx *sql.Tx
+}
+
+// Module return a goinject module registering TxScope and providing the *sql.Tx of the current WithTx call.
+// Transactions are begun with the given options (which may be nil), using the *sql.DB binding.
+func Module(opts *sql.TxOptions) goinject.Option {
+	return goinject.Module("sqltx",
+		goinject.RegisterScope(TxScope, goinject.NewContextualScope(txScopeKeyVal)),
+		goinject.Provide(func(ctx goinject.InvocationContext, db *sql.DB) (*sql.Tx, error) {
+			holder, ok := ctx.Value(txHolderKey{}).(*txHolder)
+			if !ok {
+				return nil, ErrNoTransaction
+			}
+			tx, err := db.BeginTx(ctx, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to begin transaction: %w", err)
+			}
+			holder.tx = tx
+			return tx, nil
+		}, goinject.In(TxScope)),
+	)
+}
+
+// WithTx invoke fn within a fresh transaction scope. The transaction is committed if fn returns nil and rolled
+// back if it returns an error or panics. No transaction is begun if fn never resolves *sql.Tx.
+func WithTx(ctx context.Context, injector *goinject.Injector, fn any) (err error) {
+	holder := &txHolder{}
+	ctx = context.WithValue(ctx, txHolderKey{}, holder)
+	ctx = goinject.WithContextualScopeEnabled(ctx, txScopeKeyVal)
+	defer goinject.ShutdownContextualScope(ctx, txScopeKeyVal)
+
+	defer func() {
+		if holder.tx == nil {
+			return
+		}
+		if r := recover(); r != nil {
+			_ = holder.tx.Rollback()
+			panic(r)
+		}
+		if err != nil {
+			if rollbackErr := holder.tx.Rollback(); rollbackErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to rollback transaction: %w", rollbackErr))
+			}
+			return
+		}
+		if commitErr := holder.tx.Commit(); commitErr != nil {
+			err = fmt.Errorf("failed to commit transaction: %w", commitErr)
+		}
+	}()
+
+	return injector.Invoke(ctx, fn)
+}
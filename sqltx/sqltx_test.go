@@ -0,0 +1,122 @@
+package sqltx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// fakeDriver record transaction outcomes
+type fakeDriver struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (d *fakeDriver) record(event string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, event)
+}
+
+func (d *fakeDriver) Open(_ string) (driver.Conn, error) { return &fakeConn{driver: d}, nil }
+
+func (d *fakeDriver) Connect(_ context.Context) (driver.Conn, error) { return d.Open("") }
+
+func (d *fakeDriver) Driver() driver.Driver { return d }
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(_ string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                          { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.driver.record("begin")
+	return &fakeTx{driver: c.driver}, nil
+}
+
+type fakeTx struct {
+	driver *fakeDriver
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.driver.record("commit")
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.driver.record("rollback")
+	return nil
+}
+
+type UserRepository struct {
+	tx *sql.Tx
+}
+
+func TestWithTx(t *testing.T) {
+	fake := &fakeDriver{}
+	injector, err := goinject.NewInjector(
+		Module(nil),
+		goinject.Provide(func() *sql.DB {
+			return sql.OpenDB(fake)
+		}, goinject.WithDestroy(func(db *sql.DB) { _ = db.Close() })),
+		goinject.Provide(func(tx *sql.Tx) *UserRepository {
+			return &UserRepository{tx: tx}
+		}, goinject.In(TxScope)),
+	)
+	assert.Nil(t, err)
+	defer injector.Shutdown()
+	ctx := context.Background()
+
+	t.Run("Should commit if function succeed", func(t *testing.T) {
+		fake.events = nil
+		err := WithTx(ctx, injector, func(repo *UserRepository, tx *sql.Tx) {
+			assert.Same(t, tx, repo.tx)
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"begin", "commit"}, fake.events)
+	})
+
+	t.Run("Should rollback if function fail", func(t *testing.T) {
+		fake.events = nil
+		fnErr := errors.New("constraint violated")
+		err := WithTx(ctx, injector, func(_ *UserRepository) error {
+			return fnErr
+		})
+		assert.ErrorIs(t, err, fnErr)
+		assert.Equal(t, []string{"begin", "rollback"}, fake.events)
+	})
+
+	t.Run("Should rollback if function panic", func(t *testing.T) {
+		fake.events = nil
+		assert.Panics(t, func() {
+			_ = WithTx(ctx, injector, func(_ *UserRepository) {
+				panic("boom")
+			})
+		})
+		assert.Equal(t, []string{"begin", "rollback"}, fake.events)
+	})
+
+	t.Run("Should not begin transaction if not requested", func(t *testing.T) {
+		fake.events = nil
+		err := WithTx(ctx, injector, func() {})
+		assert.Nil(t, err)
+		assert.Empty(t, fake.events)
+	})
+
+	t.Run("Should not provide transaction outside WithTx", func(t *testing.T) {
+		scoped := goinject.WithContextualScopeEnabled(ctx, txScopeKeyVal)
+		defer goinject.ShutdownContextualScope(scoped, txScopeKeyVal)
+		err := injector.Invoke(scoped, func(_ *sql.Tx) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.ErrorIs(t, err, ErrNoTransaction)
+	})
+}
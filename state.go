@@ -0,0 +1,120 @@
+package goinject
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrInvalidState is matched (using errors.Is) by errors returned by operations that are not allowed in the current
+// state of the injector, e.g. an invocation after Stop or a second Start
+var ErrInvalidState = errors.New("operation not allowed in the injector state")
+
+// InjectorState is the state of an injector, see Injector.State
+type InjectorState string
+
+const (
+	InjectorConfiguring InjectorState = "configuring" // built, Start did not succeed yet
+	InjectorStarting    InjectorState = "starting"    // Start (or StartConcurrently) is running the start hooks
+	InjectorStarted     InjectorState = "started"     // Start (or StartConcurrently) succeeded
+	InjectorStopping    InjectorState = "stopping"    // Stop is running the stop hooks
+	InjectorStopped     InjectorState = "stopped"     // Stop returned or Shutdown was called
+)
+
+// InvalidStateError is returned by an operation called in a state of the injector that does not allow it
+type InvalidStateError struct {
+	Operation string        // name of the Injector method, e.g. "Start"
+	State     InjectorState // state of the injector when the operation was called
+}
+
+var _ error = &InvalidStateError{}
+
+func (e *InvalidStateError) Error() string {
+	return fmt.Sprintf("cannot call %s on an injector in state %q", e.Operation, e.State)
+}
+
+func (e *InvalidStateError) Unwrap() error { return ErrInvalidState }
+
+// stateMachine hold the state of an injector, the zero value is InjectorConfiguring
+type stateMachine struct {
+	mu    sync.Mutex
+	state InjectorState
+}
+
+func (m *stateMachine) get() InjectorState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == "" {
+		return InjectorConfiguring
+	}
+	return m.state
+}
+
+// check return an *InvalidStateError if the current state is not one of allowed
+func (m *stateMachine) check(operation string, allowed ...InjectorState) error {
+	if state := m.get(); !slices.Contains(allowed, state) {
+		return &InvalidStateError{Operation: operation, State: state}
+	}
+	return nil
+}
+
+// transition move to state to if the current state is one of from, it returns an *InvalidStateError otherwise
+func (m *stateMachine) transition(operation string, to InjectorState, from ...InjectorState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := m.state
+	if state == "" {
+		state = InjectorConfiguring
+	}
+	if !slices.Contains(from, state) {
+		return &InvalidStateError{Operation: operation, State: state}
+	}
+	m.state = to
+	return nil
+}
+
+func (m *stateMachine) set(state InjectorState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = state
+}
+
+// State return the state of the injector. An injector is InjectorConfiguring until Start is called, then
+// InjectorStarting while the start hooks run, and InjectorStarted once they succeeded (it is InjectorConfiguring
+// again if one of them failed). Stop moves it to InjectorStopping while its stop hooks run, then to
+// InjectorStopped, as does Shutdown. Operations that are not allowed in the current state fail with an
+// *InvalidStateError:
+//   - Start and StartConcurrently are only allowed while configuring (a failed Start can be retried, concurrent
+//     calls fail),
+//   - Stop is not allowed while the injector is starting, stopping or stopped,
+//   - invocations and resolutions are not allowed once the injector is stopped,
+//   - Swap, Remove, Stub, Refresh and the unit operations are not allowed once the injector is stopping or stopped.
+func (injector *Injector) State() InjectorState {
+	return injector.state.get()
+}
+
+// checkRunning return an *InvalidStateError if the injector is stopping or stopped
+func (injector *Injector) checkRunning(operation string) error {
+	return injector.state.check(operation, InjectorConfiguring, InjectorStarting, InjectorStarted)
+}
+
+// checkNotStopped return an *InvalidStateError if the injector is stopped
+func (injector *Injector) checkNotStopped(operation string) error {
+	return injector.state.check(operation, InjectorConfiguring, InjectorStarting, InjectorStarted, InjectorStopping)
+}
+
+// beginStart move the injector to InjectorStarting, it returns the function to call with the result of the start
+// hooks, moving the injector to InjectorStarted on success and back to InjectorConfiguring otherwise
+func (injector *Injector) beginStart(operation string) (func(err error), error) {
+	if err := injector.state.transition(operation, InjectorStarting, InjectorConfiguring); err != nil {
+		return nil, err
+	}
+	return func(err error) {
+		if err != nil {
+			injector.state.set(InjectorConfiguring)
+		} else {
+			injector.state.set(InjectorStarted)
+		}
+	}, nil
+}
@@ -0,0 +1,107 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectorState(t *testing.T) {
+	t.Run("Should follow the lifecycle", func(t *testing.T) {
+		var states []InjectorState
+		var injector *Injector
+		injector, err := NewInjector(
+			Provide(func() *Database { return &Database{} },
+				OnStop(func(_ context.Context, _ *Database) error {
+					states = append(states, injector.State())
+					return nil
+				})),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		assert.Equal(t, InjectorConfiguring, injector.State())
+		assert.Nil(t, injector.Start(ctx))
+		assert.Equal(t, InjectorStarted, injector.State())
+		assert.Nil(t, injector.Stop(ctx))
+		assert.Equal(t, []InjectorState{InjectorStopping}, states)
+		assert.Equal(t, InjectorStopped, injector.State())
+	})
+
+	t.Run("Should retry a failed Start but not start twice", func(t *testing.T) {
+		fail := true
+		injector, err := NewInjector(
+			Provide(func() *Database { return &Database{} },
+				OnStart(func(_ context.Context, _ *Database) error {
+					if fail {
+						return errors.New("not reachable")
+					}
+					return nil
+				})),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		assert.ErrorContains(t, injector.Start(ctx), "not reachable")
+		assert.Equal(t, InjectorConfiguring, injector.State())
+		fail = false
+		assert.Nil(t, injector.Start(ctx))
+
+		err = injector.Start(ctx)
+		assert.ErrorIs(t, err, ErrInvalidState)
+		var stateErr *InvalidStateError
+		assert.True(t, errors.As(err, &stateErr))
+		assert.Equal(t, &InvalidStateError{Operation: "Start", State: InjectorStarted}, stateErr)
+		_, err = injector.StartConcurrently(ctx, StartPolicy{})
+		assert.ErrorIs(t, err, ErrInvalidState)
+	})
+
+	t.Run("Should reject a Start while starting", func(t *testing.T) {
+		release := make(chan struct{})
+		starting := make(chan struct{})
+		injector, err := NewInjector(
+			Provide(func() *Database { return &Database{} },
+				OnStart(func(_ context.Context, _ *Database) error {
+					close(starting)
+					<-release
+					return nil
+				})),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		done := make(chan error)
+		go func() { done <- injector.Start(ctx) }()
+		<-starting
+		assert.Equal(t, InjectorStarting, injector.State())
+		assert.Equal(t, &InvalidStateError{Operation: "Start", State: InjectorStarting}, injector.Start(ctx))
+		assert.ErrorIs(t, injector.Stop(ctx), ErrInvalidState)
+		close(release)
+		assert.Nil(t, <-done)
+		assert.Equal(t, InjectorStarted, injector.State())
+	})
+
+	t.Run("Should reject invocations and changes after Stop", func(t *testing.T) {
+		injector, err := NewInjector(Provide(func() *Database { return &Database{} }))
+		assert.Nil(t, err)
+		ctx := context.Background()
+		assert.Nil(t, injector.Stop(ctx))
+
+		assert.EqualError(t, injector.Invoke(ctx, func(_ *Database) {}),
+			"cannot call Invoke on an injector in state \"stopped\"")
+		_, err = injector.View(KeyOf[*Database]()).Resolve(ctx, KeyOf[*Database]())
+		assert.ErrorIs(t, err, ErrInvalidState)
+		_, err = injector.Stub(KeyOf[*Database](), &Database{})
+		assert.ErrorIs(t, err, ErrInvalidState)
+		assert.ErrorIs(t, injector.Remove(KeyOf[*Database]()), ErrInvalidState)
+		assert.ErrorIs(t, injector.Stop(ctx), ErrInvalidState)
+		assert.ErrorIs(t, injector.Start(ctx), ErrInvalidState)
+	})
+
+	t.Run("Shutdown should stop the injector", func(t *testing.T) {
+		injector, err := NewInjector()
+		assert.Nil(t, err)
+		injector.Shutdown()
+		assert.Equal(t, InjectorStopped, injector.State())
+		assert.ErrorIs(t, injector.Invoke(context.Background(), func() {}), ErrInvalidState)
+	})
+}
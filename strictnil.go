@@ -0,0 +1,44 @@
+package goinject
+
+import (
+	"reflect"
+)
+
+type strictNilOption struct{}
+
+func (o *strictNilOption) apply(mod *configuration) error {
+	mod.strictNil = true
+	return nil
+}
+
+// StrictNil return an InjectorOption making the providers returning a nil pointer, interface, map, channel or
+// function without error fail the resolution, instead of injecting a nil instance that fails far away. Bindings
+// annotated with AllowNil are not checked.
+func StrictNil() InjectorOption {
+	return newInjectorOption("StrictNil", &strictNilOption{})
+}
+
+type allowNilAnnotation struct{}
+
+func (a *allowNilAnnotation) apply(b *binding) error {
+	b.allowNil = true
+	return nil
+}
+
+// AllowNil return an annotation allowing the provider to return a nil instance despite StrictNil
+func AllowNil() Annotation {
+	return &allowNilAnnotation{}
+}
+
+// isNilInstance tell if instance is a nil pointer, interface, map, channel or function
+func isNilInstance(instance reflect.Value) bool {
+	if !instance.IsValid() {
+		return true
+	}
+	switch instance.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Chan, reflect.Func:
+		return instance.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,37 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictNil(t *testing.T) {
+	injector, err := NewInjector(
+		StrictNil(),
+		Provide(func() *Request { return nil }, In(PerLookUp)),
+		Provide(func() *Session { return nil }, In(PerLookUp), AllowNil()),
+		Provide(func() Shape { return nil }, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(_ *Request) {})
+	assert.ErrorContains(t, err, "provider for *goinject.Request at ")
+	assert.ErrorContains(t, err, "returned nil without error")
+	err = injector.Invoke(ctx, func(_ Shape) {})
+	assert.ErrorContains(t, err, "returned nil without error")
+	err = injector.Invoke(ctx, func(s *Session) {
+		assert.Nil(t, s)
+	})
+	assert.Nil(t, err)
+}
+
+func TestStrictNilShouldFailEagerCreation(t *testing.T) {
+	_, err := NewInjector(StrictNil(), Provide(func() *Request { return nil }))
+	assert.ErrorContains(t, err, "returned nil without error")
+
+	_, err = NewInjector(Provide(func() *Request { return nil }))
+	assert.Nil(t, err)
+}
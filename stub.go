@@ -0,0 +1,76 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Stub replace the bindings of key with a binding injecting value, until the returned restore function is called.
+// It is intended for tests, see the injecttest package.
+// Singletons depending (transitively) on key that were already created are set aside and re-created with value on
+// their next resolution. Restoring brings back the original bindings and the set aside instances, the instances
+// created with value are destroyed when the injector is shut down.
+func (injector *Injector) Stub(key BindingKey, value any) (restore func(), err error) {
+	if err = injector.checkRunning("Stub"); err != nil {
+		return nil, err
+	}
+	stubValue := reflect.Zero(key.Type)
+	if value != nil {
+		stubValue = reflect.ValueOf(value)
+		if !stubValue.Type().AssignableTo(key.Type) {
+			return nil, newInjectionError(key.Type, key.Annotation,
+				fmt.Errorf("cannot stub with a value of type %s", stubValue.Type()))
+		}
+		stubValue = stubValue.Convert(key.Type)
+	}
+	stubType := reflect.FuncOf(nil, []reflect.Type{key.Type}, false)
+	stub := &binding{
+		typeof: key.Type,
+		provider: reflect.MakeFunc(stubType, func(_ []reflect.Value) []reflect.Value {
+			return []reflect.Value{stubValue}
+		}),
+		providedType:  key.Type,
+		annotatedWith: key.Annotation,
+		scope:         PerLookUp,
+		location:      callerLocation(),
+	}
+
+	dependents := injector.singletonDependents(key, func(_ *binding) bool { return true })
+	registry := injector.singletonScope.instanceRegistry
+	injector.bindingsMu.Lock()
+	if injector.bindings[key.Type] == nil {
+		injector.bindings[key.Type] = make(map[string][]*binding)
+	}
+	original, bound := injector.bindings[key.Type][key.Annotation]
+	injector.bindings[key.Type][key.Annotation] = []*binding{stub}
+	injector.bindingsChanged()
+	setAside := make(map[*binding]*instanceEntry)
+	for _, b := range dependents {
+		if entry, ok := registry.take(b); ok {
+			setAside[b] = entry
+		}
+	}
+	injector.bindingsMu.Unlock()
+
+	return func() {
+		injector.bindingsMu.Lock()
+		defer injector.bindingsMu.Unlock()
+		byAnnotation := injector.bindings[key.Type]
+		if byAnnotation == nil { // the injector was shut down
+			return
+		}
+		if bound {
+			byAnnotation[key.Annotation] = original
+		} else {
+			delete(byAnnotation, key.Annotation)
+		}
+		injector.bindingsChanged()
+		for _, b := range dependents {
+			if entry, ok := setAside[b]; ok {
+				registry.put(b, entry)
+			} else {
+				registry.evict(b)
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,175 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// BindingSwappedEvent is notified when the binding of a key is replaced by Injector.Swap, Generation being the
+// generation of the invocations resolving the new instance
+type BindingSwappedEvent struct {
+	Key        BindingKey
+	Generation uint64
+}
+
+func (BindingSwappedEvent) isEvent() {}
+
+// generations count the in-flight invocations by generation, the generation being advanced by each Swap
+type generations struct {
+	mu       sync.Mutex
+	current  uint64
+	inFlight map[uint64]int
+	drains   []generationDrain
+}
+
+// generationDrain is a function run once no invocation of a generation older than generation is in flight
+type generationDrain struct {
+	generation uint64
+	fn         func()
+}
+
+// enter register an invocation of the current generation and return the function unregistering it
+func (g *generations) enter() func() {
+	g.mu.Lock()
+	if g.inFlight == nil {
+		g.inFlight = make(map[uint64]int)
+	}
+	generation := g.current
+	g.inFlight[generation]++
+	g.mu.Unlock()
+	return func() {
+		g.mu.Lock()
+		if g.inFlight[generation]--; g.inFlight[generation] == 0 {
+			delete(g.inFlight, generation)
+		}
+		ready := g.drained()
+		g.mu.Unlock()
+		for _, fn := range ready {
+			fn()
+		}
+	}
+}
+
+// advance start a new generation and run fn once the invocations of the previous generations returned, it return
+// the new generation
+func (g *generations) advance(fn func()) uint64 {
+	g.mu.Lock()
+	g.current++
+	generation := g.current
+	g.drains = append(g.drains, generationDrain{generation: generation, fn: fn})
+	ready := g.drained()
+	g.mu.Unlock()
+	for _, fn := range ready {
+		fn()
+	}
+	return generation
+}
+
+// drained remove and return the drain functions that are ready to run, mu must be held
+func (g *generations) drained() []func() {
+	var ready []func()
+	g.drains = slices.DeleteFunc(g.drains, func(d generationDrain) bool {
+		for generation := range g.inFlight {
+			if generation < d.generation {
+				return false
+			}
+		}
+		ready = append(ready, d.fn)
+		return true
+	})
+	return ready
+}
+
+// Swap replace the singleton binding of key with the binding of option, which must be a Provide option (the
+// annotation of key is used if the option has none). The new instance is created first, and started if the
+// injector is started (see OnStart): if it fails, the error is returned and the current binding is kept. Then
+// resolutions are atomically switched to the new instance, and the current instance is destroyed once the
+// invocations started before the swap returned. If the current instance was started, it is instead stopped then
+// destroyed by the next Stop.
+// Instances already injected are not updated, dependents must use a Provider to get the new instance, e.g. to
+// reload rule engines or templates without downtime.
+func (injector *Injector) Swap(key BindingKey, option Option) error {
+	if err := injector.checkRunning("Swap"); err != nil {
+		return err
+	}
+	provide, ok := option.(*provideOption)
+	if !ok {
+		return newInjectionError(key.Type, key.Annotation,
+			fmt.Errorf("cannot swap binding with an option not created by Provide"))
+	}
+	var b *binding
+	if err := provide.configure(&configuration{}, &b); err != nil {
+		return newInjectionError(key.Type, key.Annotation, err)
+	}
+	if b.annotatedWith == "" {
+		b.annotatedWith = key.Annotation
+	}
+	if b.key() != key || b.scope != Singleton {
+		return newInjectionError(key.Type, key.Annotation,
+			fmt.Errorf("cannot swap binding with a binding of %s in scope %q, expected a singleton", b.key(), b.scope))
+	}
+	if err := injector.checkSwappable(key); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	val, destroy, err := injector.createInstance(ctx, withCreationStep(ctx, b), b)
+	if err != nil {
+		return decorateError(injector.errorDecorators,
+			withResolutionPath(ctx, key, fmt.Errorf("failed to swap binding: %w", err)))
+	}
+	registry := injector.singletonScope.instanceRegistry
+	registry.replace(b, Instance(val))
+	if destroy != nil {
+		injector.registerDestroy(ctx, b, injector.singletonScope, destroy)
+	}
+
+	injector.bindingsMu.Lock()
+	if err = injector.checkSwappableLocked(key); err != nil { // the binding changed while creating the instance
+		injector.bindingsMu.Unlock()
+		registry.evict(b)
+		injector.lifecycle.retire(b, val)
+		if !injector.lifecycle.isRetired(b) {
+			b.destroySingleton()
+		}
+		return err
+	}
+	old := injector.bindings[key.Type][key.Annotation][0]
+	injector.bindings[key.Type][key.Annotation] = []*binding{b}
+	injector.bindingsChanged()
+	if i := slices.Index(injector.eagerBindings, old); i >= 0 {
+		injector.eagerBindings[i] = b
+	}
+	delete(injector.degraded, old)
+	old.removed.Store(true)
+	injector.bindingsMu.Unlock()
+
+	injector.lifecycle.retire(old, injector.takeSingleton(old))
+	generation := injector.generations.advance(func() {
+		if !injector.lifecycle.isRetired(old) { // otherwise destroyed by Stop once stopped
+			old.destroySingleton()
+		}
+	})
+	injector.notify(BindingSwappedEvent{Key: key, Generation: generation})
+	return nil
+}
+
+// checkSwappable return an error if the binding of key is not a single singleton binding that can be swapped
+func (injector *Injector) checkSwappable(key BindingKey) error {
+	injector.bindingsMu.RLock()
+	defer injector.bindingsMu.RUnlock()
+	return injector.checkSwappableLocked(key)
+}
+
+// checkSwappableLocked is checkSwappable, bindingsMu must be held
+func (injector *Injector) checkSwappableLocked(key BindingKey) error {
+	bindings := injector.bindings[key.Type][key.Annotation]
+	if len(bindings) != 1 || bindings[0].scope != Singleton || key.Type == reflect.TypeFor[*Injector]() {
+		return newInjectionError(key.Type, key.Annotation,
+			fmt.Errorf("cannot swap binding, expected a single singleton binding, got %d", len(bindings)))
+	}
+	return nil
+}
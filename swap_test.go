@@ -0,0 +1,126 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type RuleEngine struct {
+	Version   int
+	destroyed bool
+}
+
+func TestSwap(t *testing.T) {
+	var events []Event
+	v1 := &RuleEngine{Version: 1}
+	injector, err := NewInjector(
+		WithObserver(func(event Event) {
+			if swapped, ok := event.(BindingSwappedEvent); ok {
+				events = append(events, swapped)
+			}
+		}),
+		Provide(func() *RuleEngine { return v1 }, WithDestroy(func(e *RuleEngine) { e.destroyed = true })),
+	)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	v2 := &RuleEngine{Version: 2}
+	err = injector.Invoke(ctx, func(engine Provider[*RuleEngine]) {
+		current, err := engine(ctx)
+		assert.Nil(t, err)
+		assert.Same(t, v1, current)
+
+		err = injector.Swap(KeyOf[*RuleEngine](), Provide(func() *RuleEngine { return v2 },
+			WithDestroy(func(e *RuleEngine) { e.destroyed = true })))
+		assert.Nil(t, err)
+		current, err = engine(ctx)
+		assert.Nil(t, err)
+		assert.Same(t, v2, current)
+		assert.False(t, v1.destroyed, "in-flight invocation should drain before destroying the old instance")
+	})
+	assert.Nil(t, err)
+	assert.True(t, v1.destroyed)
+	assert.Equal(t, []Event{BindingSwappedEvent{Key: KeyOf[*RuleEngine](), Generation: 1}}, events)
+
+	t.Run("Failed creation should keep the current binding", func(t *testing.T) {
+		creationErr := errors.New("invalid rules")
+		err := injector.Swap(KeyOf[*RuleEngine](), Provide(func() (*RuleEngine, error) { return nil, creationErr }))
+		assert.ErrorIs(t, err, creationErr)
+		assert.Nil(t, injector.Invoke(ctx, func(e *RuleEngine) { assert.Same(t, v2, e) }))
+	})
+
+	t.Run("Swap should require a singleton Provide option", func(t *testing.T) {
+		err := injector.Swap(KeyOf[*RuleEngine](), Provide(func() *RuleEngine { return v2 }, In(PerLookUp)))
+		assert.ErrorContains(t, err, "expected a singleton")
+		err = injector.Swap(KeyOf[*RuleEngine](), Module("rules"))
+		assert.ErrorContains(t, err, "not created by Provide")
+	})
+
+	injector.Shutdown()
+	assert.True(t, v2.destroyed)
+}
+
+func TestSwapShouldDestroyEachSwappedOutInstance(t *testing.T) {
+	var destroyed []int
+	engine := func(version int) Option {
+		return Provide(func() *RuleEngine { return &RuleEngine{Version: version} },
+			WithDestroy(func(e *RuleEngine) { destroyed = append(destroyed, e.Version) }))
+	}
+	injector, err := NewInjector(engine(1))
+	assert.Nil(t, err)
+
+	assert.Nil(t, injector.Swap(KeyOf[*RuleEngine](), engine(2)))
+	assert.Nil(t, injector.Swap(KeyOf[*RuleEngine](), engine(3)))
+	assert.Equal(t, []int{1, 2}, destroyed)
+	injector.Shutdown()
+	assert.Equal(t, []int{1, 2, 3}, destroyed)
+}
+
+func TestSwapShouldRunLifecycleHooks(t *testing.T) {
+	var events []string
+	engine := func(version int) Option {
+		return Provide(func() *RuleEngine { return &RuleEngine{Version: version} },
+			OnStart(func(_ context.Context, e *RuleEngine) error {
+				events = append(events, fmt.Sprintf("start %d", e.Version))
+				return nil
+			}),
+			OnStop(func(_ context.Context, e *RuleEngine) error {
+				events = append(events, fmt.Sprintf("stop %d", e.Version))
+				return nil
+			}),
+			WithDestroy(func(e *RuleEngine) { events = append(events, fmt.Sprintf("destroy %d", e.Version)) }))
+	}
+	injector, err := NewInjector(engine(1))
+	assert.Nil(t, err)
+	ctx := context.Background()
+	assert.Nil(t, injector.Start(ctx))
+
+	assert.Nil(t, injector.Swap(KeyOf[*RuleEngine](), engine(2)))
+	assert.Equal(t, []string{"start 1", "start 2"}, events)
+	assert.Nil(t, injector.Stop(ctx))
+	assert.Equal(t, []string{"start 1", "start 2", "stop 2", "stop 1", "destroy 1"}, events)
+	injector.Shutdown()
+	assert.Equal(t, []string{"start 1", "start 2", "stop 2", "stop 1", "destroy 1", "destroy 2"}, events)
+}
+
+func TestSwapShouldUpdateEventSubscribers(t *testing.T) {
+	old, swapped := &WelcomeMailer{}, &WelcomeMailer{}
+	injector, err := NewInjector(
+		EventBus(),
+		Provide(func() *WelcomeMailer { return old }),
+	)
+	assert.Nil(t, err)
+	ctx := context.Background()
+	publish := func(name string) {
+		assert.Nil(t, injector.Invoke(ctx, func(p Publisher) error { return p.Publish(ctx, UserCreated{Name: name}) }))
+	}
+	publish("alice")
+	assert.Nil(t, injector.Swap(KeyOf[*WelcomeMailer](), Provide(func() *WelcomeMailer { return swapped })))
+	publish("bob")
+	assert.Equal(t, []string{"alice"}, old.sent)
+	assert.Equal(t, []string{"bob"}, swapped.sent)
+}
@@ -0,0 +1,59 @@
+package goinject
+
+type forNamesAnnotation struct {
+	names []string
+}
+
+func (a *forNamesAnnotation) apply(_ *binding) error {
+	return newInjectorConfigurationError("ForNames can only be used with ProvideTemplate", nil)
+}
+
+// ForNames return the annotation listing the names of the bindings registered by ProvideTemplate
+func ForNames(names ...string) Annotation {
+	return &forNamesAnnotation{names: names}
+}
+
+type templateOption struct {
+	constructor any
+	annotations []Annotation
+	location    string // source location of the ProvideTemplate call
+}
+
+func (o *templateOption) apply(mod *configuration) error {
+	var names []string
+	var annotations []Annotation
+	for _, a := range o.annotations {
+		if forNames, ok := a.(*forNamesAnnotation); ok {
+			names = append(names, forNames.names...)
+		} else {
+			annotations = append(annotations, a)
+		}
+	}
+	if len(names) == 0 {
+		return newConfigurationProblemError(InvalidProvider, "", mod.modules, o.location,
+			newInjectorConfigurationError("ProvideTemplate requires names given with ForNames", nil))
+	}
+	for _, name := range names {
+		option := &provideOption{
+			constructor: o.constructor,
+			annotations: append(append([]Annotation{}, annotations...), Named(name)),
+			location:    o.location,
+		}
+		if err := option.apply(mod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProvideTemplate register one binding per name given with ForNames, each annotated with its name (see Named),
+// from a single constructor that usually requests a BindingName argument to select its configuration:
+//
+//	ProvideTemplate(func(name BindingName, cfg *Config) (*redis.Client, error) {
+//		return redis.NewClient(cfg.Redis[string(name)]), nil
+//	}, ForNames("cache", "sessions", "locks"))
+//
+// The other annotations apply to all the bindings.
+func ProvideTemplate(constructor any, annotations ...Annotation) Option {
+	return &templateOption{constructor: constructor, annotations: annotations, location: callerLocation()}
+}
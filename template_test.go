@@ -0,0 +1,37 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvideTemplate(t *testing.T) {
+	injector, err := NewInjector(
+		ProvideTemplate(func(name BindingName) *Color {
+			return &Color{name: "redis://" + string(name)}
+		}, ForNames("cache", "sessions"), ForNames("locks"), In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+
+	err = injector.Invoke(context.Background(), func(p struct {
+		Params
+		Cache    *Color `inject:"cache"`
+		Sessions *Color `inject:"sessions"`
+		Locks    *Color `inject:"locks"`
+	}) {
+		assert.Equal(t, "redis://cache", p.Cache.name)
+		assert.Equal(t, "redis://sessions", p.Sessions.name)
+		assert.Equal(t, "redis://locks", p.Locks.name)
+	})
+	assert.Nil(t, err)
+	graph := injector.Graph()
+	assert.Len(t, graph.Bindings, 3)
+	assert.Equal(t, PerLookUp, graph.Bindings[0].Scope)
+
+	_, err = NewInjector(ProvideTemplate(func() *Color { return &Color{} }))
+	assert.ErrorContains(t, err, "ProvideTemplate requires names given with ForNames")
+	_, err = NewInjector(Provide(func() *Color { return &Color{} }, ForNames("cache")))
+	assert.ErrorContains(t, err, "ForNames can only be used with ProvideTemplate")
+}
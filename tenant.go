@@ -0,0 +1,162 @@
+package goinject
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// tenantScope is a Scope holding one instance registry per tenant, the tenant being extracted from the context
+type tenantScope struct {
+	extractor  func(ctx context.Context) string
+	maxTenants int
+
+	mu         sync.Mutex               // lock guarding registries and lru
+	registries map[string]*list.Element // element of lru by tenant ID
+	lru        *list.List               // *tenantRegistry, most recently used first
+}
+
+type tenantRegistry struct {
+	tenant   string
+	registry *instanceRegistry
+}
+
+var _ Scope = new(tenantScope)
+
+// TenantScopeOption configure a Scope created by NewTenantScope
+type TenantScopeOption func(s *tenantScope)
+
+// WithMaxTenants limit the number of tenants a tenant scope keep instances for.
+// When the limit is reached, the least recently used tenant is shut down to make room for the new one.
+func WithMaxTenants(maxTenants int) TenantScopeOption {
+	return func(s *tenantScope) {
+		s.maxTenants = maxTenants
+	}
+}
+
+// NewTenantScope return a Scope that keep one instance per tenant, the tenant ID being extracted from the
+// resolution context by the extractor function. An empty tenant ID means that the scope is not active.
+// Instances of a tenant are destroyed by ShutdownTenant, on eviction, or when the injector is shut down.
+func NewTenantScope(extractor func(ctx context.Context) string, opts ...TenantScopeOption) Scope {
+	s := &tenantScope{
+		extractor:  extractor,
+		registries: make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *tenantScope) ResolveBinding(
+	ctx context.Context,
+	binding *binding,
+	instanceCreator func() (Instance, error),
+) (Instance, error) {
+	registry := s.tenantRegistry(ctx, true)
+	if registry == nil {
+		return Instance{}, newContextScopedNotActiveError()
+	}
+	return registry.resolveBinding(binding, instanceCreator)
+}
+
+func (s *tenantScope) RegisterDestructionCallback(
+	ctx context.Context,
+	destroyCallback func(),
+) {
+	if registry := s.tenantRegistry(ctx, false); registry != nil {
+		registry.registerOrderedDestructionCallback(shutdownOrderOf(ctx), destroyCallback)
+	} else {
+		// the tenant was shut down (or evicted) while the instance was created
+		destroyCallback()
+	}
+}
+
+// tenantRegistry return the registry of the tenant of ctx (creating it if requested), or nil if there is no tenant
+func (s *tenantScope) tenantRegistry(ctx context.Context, create bool) *instanceRegistry {
+	if ctx == nil {
+		return nil
+	}
+	tenant := s.extractor(ctx)
+	if tenant == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	if element, ok := s.registries[tenant]; ok {
+		s.lru.MoveToFront(element)
+		s.mu.Unlock()
+		return element.Value.(*tenantRegistry).registry
+	}
+	if !create {
+		s.mu.Unlock()
+		return nil
+	}
+	registry := newInstanceRegistry()
+	s.registries[tenant] = s.lru.PushFront(&tenantRegistry{tenant: tenant, registry: registry})
+	var evicted []*instanceRegistry
+	for s.maxTenants > 0 && s.lru.Len() > s.maxTenants {
+		evicted = append(evicted, s.remove(s.lru.Back()))
+	}
+	s.mu.Unlock()
+
+	for _, r := range evicted {
+		r.shutdown()
+	}
+	return registry
+}
+
+// remove must be called with the lock held
+func (s *tenantScope) remove(element *list.Element) *instanceRegistry {
+	tr := s.lru.Remove(element).(*tenantRegistry)
+	delete(s.registries, tr.tenant)
+	return tr.registry
+}
+
+func (s *tenantScope) shutdownTenant(tenant string) {
+	s.mu.Lock()
+	element, ok := s.registries[tenant]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	registry := s.remove(element)
+	s.mu.Unlock()
+
+	registry.shutdown()
+}
+
+// tenantRegistries return the registries of the tenants
+func (s *tenantScope) tenantRegistries() []*instanceRegistry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]*instanceRegistry, 0, s.lru.Len())
+	for element := s.lru.Front(); element != nil; element = element.Next() {
+		res = append(res, element.Value.(*tenantRegistry).registry)
+	}
+	return res
+}
+
+// Shutdown destroy instances of all tenants
+func (s *tenantScope) Shutdown() {
+	s.mu.Lock()
+	var registries []*instanceRegistry
+	for s.lru.Len() > 0 {
+		registries = append(registries, s.remove(s.lru.Front()))
+	}
+	s.mu.Unlock()
+
+	for _, r := range registries {
+		r.shutdown()
+	}
+}
+
+// ShutdownTenant destroy instances of the given tenant in all tenant scopes registered in the injector
+func ShutdownTenant(injector *Injector, tenant string) {
+	for _, scope := range injector.scopes {
+		if ts, ok := scope.(*tenantScope); ok {
+			ts.shutdownTenant(tenant)
+		}
+	}
+}
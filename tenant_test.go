@@ -0,0 +1,91 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TenantRepository struct {
+	tenant string
+}
+
+func tenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+func TestTenantScope(t *testing.T) {
+	newInjector := func(t *testing.T, destroyed *[]string, opts ...TenantScopeOption) *Injector {
+		injector, err := NewInjector(
+			RegisterScope("tenant", NewTenantScope(tenantFromContext, opts...)),
+			Provide(func(ctx InvocationContext) *TenantRepository {
+				return &TenantRepository{tenant: tenantFromContext(ctx)}
+			}, In("tenant"), WithDestroy(func(r *TenantRepository) {
+				*destroyed = append(*destroyed, r.tenant)
+			})),
+		)
+		assert.Nil(t, err)
+		return injector
+	}
+	resolve := func(t *testing.T, injector *Injector, tenant string) *TenantRepository {
+		var res *TenantRepository
+		err := injector.Invoke(context.WithValue(context.Background(), tenantKey{}, tenant),
+			func(r *TenantRepository) { res = r })
+		assert.Nil(t, err)
+		return res
+	}
+
+	t.Run("Should keep one instance per tenant", func(t *testing.T) {
+		var destroyed []string
+		injector := newInjector(t, &destroyed)
+		acme := resolve(t, injector, "acme")
+		globex := resolve(t, injector, "globex")
+		assert.Same(t, acme, resolve(t, injector, "acme"))
+		assert.NotSame(t, acme, globex)
+		assert.Equal(t, "globex", globex.tenant)
+
+		ShutdownTenant(injector, "acme")
+		assert.Equal(t, []string{"acme"}, destroyed)
+		assert.NotSame(t, acme, resolve(t, injector, "acme"))
+
+		injector.Shutdown()
+		assert.ElementsMatch(t, []string{"acme", "acme", "globex"}, destroyed)
+	})
+
+	t.Run("Should return error if there is no tenant", func(t *testing.T) {
+		var destroyed []string
+		injector := newInjector(t, &destroyed)
+		err := injector.Invoke(context.Background(), func(_ *TenantRepository) {
+			assert.Fail(t, "should not be reached")
+		})
+		assert.True(t, errors.Is(err, &contextScopedNotActiveError{}))
+	})
+
+	t.Run("Should evict least recently used tenant", func(t *testing.T) {
+		var destroyed []string
+		injector := newInjector(t, &destroyed, WithMaxTenants(2))
+		resolve(t, injector, "acme")
+		resolve(t, injector, "globex")
+		resolve(t, injector, "acme")
+		resolve(t, injector, "initech")
+		assert.Equal(t, []string{"globex"}, destroyed)
+	})
+	t.Run("Should destroy instances created while their tenant is shut down", func(t *testing.T) {
+		var destroyed []string
+		injector, err := NewInjector(
+			RegisterScope("tenant", NewTenantScope(tenantFromContext)),
+			Provide(func(ctx InvocationContext, injector *Injector) *TenantRepository {
+				ShutdownTenant(injector, tenantFromContext(ctx))
+				return &TenantRepository{tenant: tenantFromContext(ctx)}
+			}, In("tenant"), WithDestroy(func(r *TenantRepository) {
+				destroyed = append(destroyed, r.tenant)
+			})),
+		)
+		assert.Nil(t, err)
+		resolve(t, injector, "acme")
+		assert.Equal(t, []string{"acme"}, destroyed)
+	})
+}
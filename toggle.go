@@ -0,0 +1,95 @@
+package goinject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// ErrBindingDisabled is matched (using errors.Is) by errors returned when the only bindings of a key are disabled by
+// their toggle, see Toggleable
+var ErrBindingDisabled = errors.New("binding is disabled")
+
+type toggleableAnnotation struct {
+	toggle string
+}
+
+func (a *toggleableAnnotation) apply(b *binding) error {
+	if a.toggle == "" {
+		return newInjectorConfigurationError("argument of Toggleable must not be empty", nil)
+	}
+	b.toggle = a.toggle
+	return nil
+}
+
+// Toggleable return an annotation attaching the binding to a feature toggle, switched at runtime with
+// Injector.SetToggle. Toggles are enabled until they are disabled. Bindings of disabled toggles are ignored by
+// resolutions: they resolve as absent for optional injections, and fail with an error matching ErrBindingDisabled
+// otherwise. Instances already injected are not affected.
+func Toggleable(toggle string) Annotation {
+	return &toggleableAnnotation{toggle: toggle}
+}
+
+// toggles hold the state of the feature toggles of an injector
+type toggles struct {
+	mu       sync.RWMutex
+	disabled map[string]bool
+}
+
+// SetToggle enable or disable the bindings annotated with Toggleable(toggle)
+func (injector *Injector) SetToggle(toggle string, enabled bool) {
+	injector.toggles.mu.Lock()
+	defer injector.toggles.mu.Unlock()
+	if injector.toggles.disabled == nil {
+		injector.toggles.disabled = make(map[string]bool)
+	}
+	if enabled {
+		delete(injector.toggles.disabled, toggle)
+	} else {
+		injector.toggles.disabled[toggle] = true
+	}
+}
+
+// Toggles return the state of the toggles of the bindings, by toggle name
+func (injector *Injector) Toggles() map[string]bool {
+	res := make(map[string]bool)
+	for _, b := range injector.allBindings() {
+		if b.toggle != "" {
+			res[b.toggle] = injector.isEnabled(b)
+		}
+	}
+	return res
+}
+
+// isEnabled tell if the binding is not disabled by its toggle
+func (injector *Injector) isEnabled(b *binding) bool {
+	if b.toggle == "" {
+		return true
+	}
+	injector.toggles.mu.RLock()
+	defer injector.toggles.mu.RUnlock()
+	return !injector.toggles.disabled[b.toggle]
+}
+
+// disabledBindingError return the error of the resolution of t and annotation if its bindings are disabled by their
+// toggles or belong to stopped units, cause otherwise
+func (injector *Injector) disabledBindingError(t reflect.Type, annotation string, cause error) error {
+	var disabled, stopped []string
+	for _, b := range injector.findBindingsForAnnotatedType(t, annotation) {
+		if !injector.isEnabled(b) {
+			disabled = append(disabled, b.toggle)
+		} else if !injector.isUnitRunning(b) {
+			stopped = append(stopped, b.unit)
+		}
+	}
+	if len(disabled) > 0 {
+		sort.Strings(disabled)
+		return newInjectionError(t, annotation, fmt.Errorf("%w by toggle %q", ErrBindingDisabled, disabled[0]))
+	} else if len(stopped) > 0 {
+		sort.Strings(stopped)
+		return newInjectionError(t, annotation, fmt.Errorf("%w: %s", ErrUnitStopped, stopped[0]))
+	}
+	return cause
+}
@@ -0,0 +1,51 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type PaymentGateway interface {
+	Pay(amount int) string
+}
+
+type paymentsV2 struct{}
+
+func (p *paymentsV2) Pay(_ int) string { return "v2" }
+
+func TestToggleable(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func() *paymentsV2 { return &paymentsV2{} }, As(Type[PaymentGateway]()), Toggleable("payments.v2")),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"payments.v2": true}, injector.Toggles())
+
+	ctx := context.Background()
+	err = injector.Invoke(ctx, func(g PaymentGateway) {
+		assert.Equal(t, "v2", g.Pay(10))
+	})
+	assert.Nil(t, err)
+
+	injector.SetToggle("payments.v2", false)
+	assert.Equal(t, map[string]bool{"payments.v2": false}, injector.Toggles())
+	err = injector.Invoke(ctx, func(_ PaymentGateway) {})
+	assert.ErrorIs(t, err, ErrBindingDisabled)
+
+	err = injector.Invoke(ctx, func(g Optional[PaymentGateway]) {
+		assert.Equal(t, OptionalMissing, g.State())
+	})
+	assert.Nil(t, err)
+
+	injector.SetToggle("payments.v2", true)
+	err = injector.Invoke(ctx, func(g PaymentGateway) {
+		assert.Equal(t, "v2", g.Pay(10))
+	})
+	assert.Nil(t, err)
+
+	t.Run("Toggleable should require a name", func(t *testing.T) {
+		_, err := NewInjector(Provide(func() *paymentsV2 { return &paymentsV2{} }, Toggleable("")))
+		assert.ErrorContains(t, err, "argument of Toggleable must not be empty")
+	})
+}
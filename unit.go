@@ -0,0 +1,189 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// ErrUnitStopped is matched (using errors.Is) by errors returned when the only bindings of a key belong to a unit
+// stopped with Injector.StopUnit
+var ErrUnitStopped = errors.New("unit is stopped")
+
+type unitOption struct {
+	name    string
+	options []Option
+}
+
+func (o *unitOption) apply(mod *configuration) error {
+	if o.name == "" {
+		return newInjectorConfigurationError("name of Unit must not be empty", nil)
+	}
+	outer := mod.unit
+	mod.unit = o.name
+	defer func() { mod.unit = outer }()
+	for _, opt := range o.options {
+		if err := opt.apply(mod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unit return an Option grouping the bindings of the given options, so that they can be stopped, started and
+// refreshed together at runtime (see Injector.StopUnit), e.g. to degrade a feature during an incident. A binding
+// belongs to its innermost unit.
+func Unit(name string, options ...Option) Option {
+	return &unitOption{name: name, options: options}
+}
+
+// units hold the stopped units of an injector
+type units struct {
+	mu      sync.RWMutex
+	stopped map[string]bool
+}
+
+// isUnitRunning tell if b does not belong to a stopped unit
+func (injector *Injector) isUnitRunning(b *binding) bool {
+	if b.unit == "" {
+		return true
+	}
+	injector.units.mu.RLock()
+	defer injector.units.mu.RUnlock()
+	return !injector.units.stopped[b.unit]
+}
+
+// unitBindings return the bindings of the unit, in registration order
+func (injector *Injector) unitBindings(unit string) ([]*binding, error) {
+	var res []*binding
+	for _, b := range injector.allBindings() {
+		if b.unit == unit {
+			res = append(res, b)
+		}
+	}
+	if len(res) == 0 {
+		return nil, newInvalidInputError(fmt.Sprintf("unit %q has no binding", unit))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].order < res[j].order })
+	return res, nil
+}
+
+// StopUnit stop the bindings of the unit: the stop hooks of its started bindings are run (in reverse start order),
+// its singleton instances are destroyed, and its bindings cannot be resolved until the unit is started again with
+// StartUnit. All hooks are run even if some of them fail, the returned error joins their errors.
+// Instances already injected are not affected.
+func (injector *Injector) StopUnit(ctx context.Context, unit string) error {
+	if err := injector.checkRunning("StopUnit"); err != nil {
+		return err
+	}
+	bindings, err := injector.unitBindings(unit)
+	if err != nil {
+		return err
+	}
+	injector.units.mu.Lock()
+	if injector.units.stopped == nil {
+		injector.units.stopped = make(map[string]bool)
+	}
+	injector.units.stopped[unit] = true
+	injector.units.mu.Unlock()
+
+	injector.lifecycle.mu.Lock()
+	var started []*binding
+	injector.lifecycle.started = slices.DeleteFunc(injector.lifecycle.started, func(b *binding) bool {
+		if b.unit == unit {
+			started = append(started, b)
+			return true
+		}
+		return false
+	})
+	injector.lifecycle.mu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		b := started[i]
+		if b.onStop == nil {
+			continue
+		}
+		if err := injector.stopBinding(ctx, b); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	registry := injector.singletonScope.instanceRegistry
+	for i := len(bindings) - 1; i >= 0; i-- {
+		if b := bindings[i]; b.scope == Singleton {
+			registry.evict(b)
+			injector.lifecycle.forget(b)
+			b.destroySingleton()
+		}
+	}
+	return decorateError(injector.errorDecorators, errors.Join(errs...))
+}
+
+// StartUnit start the bindings of the unit: its bindings can be resolved again, its eager singletons are created
+// and the start hooks of its bindings are run phase by phase, see Injector.Start. It stops at the first failure.
+func (injector *Injector) StartUnit(ctx context.Context, unit string) error {
+	if err := injector.checkRunning("StartUnit"); err != nil {
+		return err
+	}
+	bindings, err := injector.unitBindings(unit)
+	if err != nil {
+		return err
+	}
+	injector.units.mu.Lock()
+	delete(injector.units.stopped, unit)
+	injector.units.mu.Unlock()
+
+	injector.bindingsMu.RLock()
+	eager := slices.Clone(injector.eagerBindings)
+	injector.bindingsMu.RUnlock()
+	for _, b := range bindings {
+		if b.onStart == nil && b.onStop == nil && b.readinessCheck == nil && !slices.Contains(eager, b) {
+			continue
+		}
+		if _, err = injector.getScopedInstanceFromBinding(ctx, b); err != nil {
+			return decorateError(injector.errorDecorators, fmt.Errorf("failed to start unit %s: %w", unit, err))
+		}
+	}
+	injector.lifecycle.mu.Lock()
+	created := slices.Clone(injector.lifecycle.created)
+	injector.lifecycle.mu.Unlock()
+	for _, phase := range injector.lifecycle.phases {
+		for _, b := range created {
+			if b.unit != unit || b.phase != phase || injector.isStarted(b) {
+				continue
+			}
+			if err = injector.startBinding(ctx, b); err != nil {
+				return decorateError(injector.errorDecorators, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RefreshUnit refresh the singleton bindings of the unit, see Injector.Refresh. All the bindings are refreshed even
+// if some of them fail, the returned error joins their errors.
+func (injector *Injector) RefreshUnit(ctx context.Context, unit string) error {
+	if err := injector.checkRunning("RefreshUnit"); err != nil {
+		return err
+	}
+	bindings, err := injector.unitBindings(unit)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	refreshed := make(map[BindingKey]bool)
+	for _, b := range bindings {
+		if b.scope != Singleton || refreshed[b.key()] {
+			continue
+		}
+		refreshed[b.key()] = true
+		if err = injector.Refresh(ctx, b.key()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
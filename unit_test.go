@@ -0,0 +1,65 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type SearchIndex struct {
+	running   bool
+	destroyed int
+}
+
+type SearchAPI struct {
+	Index *SearchIndex
+}
+
+func TestUnit(t *testing.T) {
+	var indexes []*SearchIndex
+	injector, err := NewInjector(
+		Unit("search",
+			Provide(func() *SearchIndex {
+				index := &SearchIndex{}
+				indexes = append(indexes, index)
+				return index
+			},
+				OnStart(func(_ context.Context, i *SearchIndex) error { i.running = true; return nil }),
+				OnStop(func(_ context.Context, i *SearchIndex) error { i.running = false; return nil }),
+				WithDestroy(func(i *SearchIndex) { i.destroyed++ })),
+			Provide(func(i *SearchIndex) *SearchAPI { return &SearchAPI{Index: i} }),
+		),
+	)
+	assert.Nil(t, err)
+	ctx := context.Background()
+	assert.Nil(t, injector.Start(ctx))
+	assert.Len(t, indexes, 1)
+	assert.True(t, indexes[0].running)
+
+	assert.Nil(t, injector.StopUnit(ctx, "search"))
+	assert.False(t, indexes[0].running)
+	assert.Equal(t, 1, indexes[0].destroyed)
+	err = injector.Invoke(ctx, func(_ *SearchAPI) {})
+	assert.ErrorIs(t, err, ErrUnitStopped)
+
+	assert.Nil(t, injector.StartUnit(ctx, "search"))
+	assert.Len(t, indexes, 2)
+	assert.True(t, indexes[1].running)
+	err = injector.Invoke(ctx, func(api *SearchAPI) {
+		assert.Same(t, indexes[1], api.Index)
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, injector.RefreshUnit(ctx, "search"))
+	assert.Len(t, indexes, 3)
+
+	t.Run("Unknown units should fail", func(t *testing.T) {
+		assert.ErrorContains(t, injector.StopUnit(ctx, "unknown"), "unit \"unknown\" has no binding")
+	})
+
+	injector.Shutdown()
+	for _, index := range indexes {
+		assert.Equal(t, 1, index.destroyed)
+	}
+}
@@ -0,0 +1,109 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type withValidityAnnotation struct {
+	validity any
+}
+
+func (a *withValidityAnnotation) apply(b *binding) error {
+	validityFnVal := reflect.ValueOf(a.validity)
+	if validityFnVal.Kind() != reflect.Func ||
+		validityFnVal.Type().NumIn() != 1 ||
+		validityFnVal.Type().In(0) != b.providedType ||
+		validityFnVal.Type().NumOut() != 1 ||
+		validityFnVal.Type().Out(0).Kind() != reflect.Bool {
+		return newInjectorConfigurationError(
+			"argument of WithValidity must be a function with one argument returning bool",
+			nil,
+		)
+	}
+	b.validity = func(val reflect.Value) bool {
+		return validityFnVal.Call([]reflect.Value{val})[0].Bool()
+	}
+	return nil
+}
+
+// WithValidity return an annotation that declare a validity check (a function taking the provided type and
+// returning a bool). The check is run each time the binding is resolved, and an instance failing it is re-created
+// within its scope, the stale instance being destroyed (see WithDestroy).
+// It is useful for tokens or clients expiring independently of the scope lifetime.
+func WithValidity(validity any) Annotation {
+	return &withValidityAnnotation{
+		validity: validity,
+	}
+}
+
+// validatedHolder is the value stored in the scope for bindings declaring a validity check, it hold the current
+// instance of the binding
+type validatedHolder struct {
+	mu           sync.Mutex
+	value        reflect.Value
+	destroyValue func() // destroy value if set, see Injector.createInstance
+}
+
+var validatedHolderReflectType = reflect.TypeFor[*validatedHolder]()
+
+func (h *validatedHolder) destroyCurrent() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.destroy()
+}
+
+// destroy must be called with the lock held
+func (h *validatedHolder) destroy() {
+	if h.destroyValue != nil {
+		h.destroyValue()
+	}
+	h.value = reflect.Value{}
+	h.destroyValue = nil
+}
+
+// resolveValidatedInstance resolve the instance of a binding annotated with WithValidity: the scope hold a
+// validatedHolder whose instance is re-created when it is not valid anymore. create return the new instance and the
+// function destroying it, if any.
+func resolveValidatedInstance(
+	ctx context.Context,
+	scope Scope,
+	binding *binding,
+	resolve func(instanceCreator func() (Instance, error)) (Instance, error),
+	create func() (reflect.Value, func(), error),
+) (Instance, error) {
+	holder, err := resolve(func() (Instance, error) {
+		h := &validatedHolder{}
+		scope.RegisterDestructionCallback(withShutdownOrder(ctx, binding.shutdownOrder), h.destroyCurrent)
+		return Instance(reflect.ValueOf(h)), nil
+	})
+	if err != nil {
+		return Instance{}, err
+	}
+	holderValue := reflect.Value(holder)
+	if !holderValue.IsValid() || holderValue.Type() != validatedHolderReflectType {
+		return Instance{}, newInjectionError(binding.typeof, binding.annotatedWith,
+			fmt.Errorf("scope %q did not return the validated instance holder", binding.scope))
+	}
+	h := holderValue.Interface().(*validatedHolder)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.value.IsValid() && binding.validity(h.value) {
+		return Instance(h.value), nil
+	}
+	val, destroy, err := create()
+	if err != nil {
+		return Instance(val), err
+	}
+	h.destroy()
+	h.value = val
+	h.destroyValue = destroy
+	if binding.scope == Singleton {
+		destroyCurrent := h.destroyCurrent
+		binding.destroyCurrent.Store(&destroyCurrent)
+	}
+	return Instance(val), nil
+}
@@ -0,0 +1,105 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Token struct {
+	ID      int
+	expired bool
+}
+
+func TestWithValidity(t *testing.T) {
+	t.Run("Should re-create invalid singleton and destroy stale one", func(t *testing.T) {
+		created := 0
+		var destroyed []int
+		injector, err := NewInjector(
+			Provide(func() *Token {
+				created++
+				return &Token{ID: created}
+			}, WithValidity(func(t *Token) bool {
+				return !t.expired
+			}), WithDestroy(func(t *Token) {
+				destroyed = append(destroyed, t.ID)
+			})),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+
+		var first, second, third *Token
+		assert.Nil(t, injector.Invoke(ctx, func(t *Token) { first = t }))
+		assert.Nil(t, injector.Invoke(ctx, func(t *Token) { second = t }))
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, created)
+
+		first.expired = true
+		assert.Nil(t, injector.Invoke(ctx, func(t *Token) { third = t }))
+		assert.NotSame(t, first, third)
+		assert.Equal(t, 2, third.ID)
+		assert.Equal(t, []int{1}, destroyed)
+
+		injector.Shutdown()
+		assert.Equal(t, []int{1, 2}, destroyed)
+	})
+
+	t.Run("Should work with contextual scope", func(t *testing.T) {
+		created := 0
+		injector, err := NewInjector(
+			RegisterScope("session", NewContextualScope(sessionScopeKeyVal)),
+			Provide(func() *Token {
+				created++
+				return &Token{ID: created}
+			}, In("session"), WithValidity(func(t *Token) bool {
+				return !t.expired
+			})),
+		)
+		assert.Nil(t, err)
+		ctx := WithContextualScopeEnabled(context.Background(), sessionScopeKeyVal)
+		defer ShutdownContextualScope(ctx, sessionScopeKeyVal)
+
+		assert.Nil(t, injector.Invoke(ctx, func(token *Token) { token.expired = true }))
+		assert.Nil(t, injector.Invoke(ctx, func(token *Token) {
+			assert.Equal(t, 2, token.ID)
+		}))
+	})
+
+	t.Run("Should run start hooks and destroy swapped out instances", func(t *testing.T) {
+		var started []int
+		destroyed := 0
+		injector, err := NewInjector(
+			Provide(func() *Token { return &Token{ID: 1} },
+				WithValidity(func(t *Token) bool { return !t.expired }),
+				WithDestroy(func(_ *Token) { destroyed++ }),
+				OnStart(func(_ context.Context, t *Token) error {
+					started = append(started, t.ID)
+					return nil
+				})),
+		)
+		assert.Nil(t, err)
+		ctx := context.Background()
+		assert.Nil(t, injector.Start(ctx))
+		assert.Equal(t, []int{1}, started)
+
+		assert.Nil(t, injector.Swap(KeyOf[*Token](), Provide(func() *Token { return &Token{ID: 2} })))
+		assert.Equal(t, 1, destroyed)
+		injector.Shutdown()
+		assert.Equal(t, 1, destroyed)
+	})
+
+	t.Run("WithValidity should raise an error if not a predicate of provided type", func(t *testing.T) {
+		_, err := NewInjector(
+			Provide(func() *Token {
+				return &Token{}
+			}, WithValidity(func(_ *Parent) bool { return true })),
+		)
+		assert.IsType(t, err, &ConfigurationReport{})
+		assert.Equal(t,
+			"got error while configuring provider for provided type *goinject.Token:\nargument of WithValidity"+
+				" must be a function with one argument returning bool",
+			err.Error(),
+		)
+	})
+}
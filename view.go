@@ -0,0 +1,81 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotInView is matched (using errors.Is) by the errors returned by a Resolver created by Injector.View when a
+// binding that is not exposed by the view is requested
+var ErrNotInView = errors.New("binding is not exposed by the view")
+
+// Resolver resolve instances without giving access to the injector, see Injector.View
+type Resolver interface {
+	// Invoke call function with its arguments resolved, like Injector.Invoke
+	Invoke(ctx context.Context, function any) error
+	// Resolve return the instance of the binding of key, within an invocation
+	Resolve(ctx context.Context, key BindingKey) (any, error)
+}
+
+// injectorView is a Resolver restricted to some keys
+type injectorView struct {
+	injector *Injector
+	allowed  map[BindingKey]bool
+}
+
+var _ Resolver = &injectorView{}
+
+// View return a Resolver that can only resolve the bindings of the allowed keys, to be handed to plugins or scripting
+// layers without exposing the whole injector (its registration, lifecycle and shutdown methods, or other bindings).
+// Dependencies of the allowed bindings are resolved as usual, the *Injector itself is only resolvable if its key is
+// allowed.
+func (injector *Injector) View(allowedKeys ...BindingKey) Resolver {
+	allowed := make(map[BindingKey]bool, len(allowedKeys))
+	for _, key := range allowedKeys {
+		allowed[key] = true
+	}
+	return &injectorView{injector: injector, allowed: allowed}
+}
+
+func (v *injectorView) Invoke(ctx context.Context, function any) error {
+	fvalue, err := validateInvokedFunction(function)
+	if err != nil {
+		return err
+	}
+	for _, key := range dependenciesOf(fvalue.Type()) {
+		if err = v.check(key); err != nil {
+			return err
+		}
+	}
+	return v.injector.Invoke(ctx, function)
+}
+
+func (v *injectorView) Resolve(ctx context.Context, key BindingKey) (any, error) {
+	if err := v.check(key); err != nil {
+		return nil, err
+	}
+	injector := v.injector
+	if err := injector.checkNotStopped("Resolve"); err != nil {
+		return nil, err
+	}
+	ctx, invocationID := withInvocationID(injector.withInvocation(ctx))
+	ctx, shutdownInvocationScope := withPerInvocationScope(ctx)
+	defer shutdownInvocationScope()
+	defer injector.generations.enter()()
+	instance, err := injector.getInstanceOfAnnotatedType(ctx, key.Type, key.Annotation, false)
+	if err != nil {
+		return nil, decorateError(injector.errorDecorators, newInvocationError(invocationID, err))
+	}
+	if !instance.IsValid() {
+		return nil, nil
+	}
+	return instance.Interface(), nil
+}
+
+// check return an error if key is not allowed by the view
+func (v *injectorView) check(key BindingKey) error {
+	if v.allowed[key] {
+		return nil
+	}
+	return newInjectionError(key.Type, key.Annotation, ErrNotInView)
+}
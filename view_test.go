@@ -0,0 +1,37 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView(t *testing.T) {
+	injector, err := NewInjector(
+		Provide(func() *AppConfig { return &AppConfig{} }),
+		Provide(func(_ *AppConfig) *Parent { return &Parent{} }),
+	)
+	assert.Nil(t, err)
+	view := injector.View(KeyOf[*Parent]())
+	ctx := context.Background()
+
+	err = view.Invoke(ctx, func(p *Parent) {
+		assert.NotNil(t, p)
+	})
+	assert.Nil(t, err)
+	parent, err := view.Resolve(ctx, KeyOf[*Parent]())
+	assert.Nil(t, err)
+	assert.IsType(t, &Parent{}, parent)
+
+	err = view.Invoke(ctx, func(_ *Parent, _ *AppConfig) {
+		assert.Fail(t, "should not be reached")
+	})
+	assert.ErrorIs(t, err, ErrNotInView)
+	err = view.Invoke(ctx, func(_ *Injector) {})
+	assert.ErrorIs(t, err, ErrNotInView)
+	err = view.Invoke(ctx, func(_ Provider[*AppConfig]) {})
+	assert.ErrorIs(t, err, ErrNotInView)
+	_, err = view.Resolve(ctx, KeyOf[*AppConfig]())
+	assert.ErrorIs(t, err, ErrNotInView)
+}
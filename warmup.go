@@ -0,0 +1,87 @@
+package goinject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type warmUpOption struct {
+	targets []AsType
+}
+
+func (o *warmUpOption) apply(mod *configuration) error {
+	if mod.warmUp == nil {
+		mod.warmUp = make(map[reflect.Type]bool)
+	}
+	for _, target := range o.targets {
+		mod.warmUp[target.getType()] = true
+	}
+	return nil
+}
+
+// WarmUp return an InjectorOption restricting the eager creation of singletons to the bindings of the given types (with
+// any annotation) and their dependencies, other singletons being created on first resolution. Serverless
+// deployments use it to prebuild only the handler path. Several WarmUp options add up.
+func WarmUp(targets ...AsType) InjectorOption {
+	return newInjectorOption("WarmUp", &warmUpOption{targets: targets})
+}
+
+// checkWarmUp return an error for each warm-up target without binding
+func (mod *configuration) checkWarmUp() []error {
+	bound := make(map[reflect.Type]bool)
+	for b := range mod.bindings {
+		bound[b.typeof] = true
+	}
+	var errs []error
+	for t := range mod.warmUp {
+		if !bound[t] {
+			errs = append(errs, newInjectorConfigurationError(fmt.Sprintf("warm-up target %s has no binding", t), nil))
+		}
+	}
+	return errs
+}
+
+// isEager tell if the singleton binding b is created eagerly, cached bindings never are as they need a resolution
+// context to be created, nor are bindings with a LazyProxy
+func (mod *configuration) isEager(b *binding) bool {
+	return b.cacheKey == nil && b.lazyProxy == nil && (mod.warmUp == nil || mod.warmUp[b.typeof])
+}
+
+// WarmUp create the singletons of the given keys that are not created yet (e.g. the ones excluded by the WarmUp
+// Option), and their dependencies, like the eager creation of NewInjector: NonCritical bindings failing to be
+// created are degraded, other failures are returned. It is meant to be called after the application reports ready
+// but before traffic shifts to it. ctx cancellation stops the warm-up between two singletons.
+func (injector *Injector) WarmUp(ctx context.Context, keys ...BindingKey) error {
+	if err := injector.checkRunning("WarmUp"); err != nil {
+		return err
+	}
+	var bindings []*binding
+	for _, key := range keys {
+		keyBindings := injector.findBindingsForAnnotatedType(key.Type, key.Annotation)
+		if len(keyBindings) == 0 {
+			return newInvalidInputError(fmt.Sprintf("warm-up target %s has no binding", key))
+		}
+		for _, b := range keyBindings {
+			if b.scope != Singleton || b.cacheKey != nil {
+				return newInvalidInputError(fmt.Sprintf("warm-up target %s is not a singleton binding", key))
+			}
+		}
+		bindings = append(bindings, keyBindings...)
+	}
+	for _, b := range bindings {
+		if ctx != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		injector.bindingsMu.RLock()
+		_, degraded := injector.degraded[b]
+		injector.bindingsMu.RUnlock()
+		if degraded || injector.singletonScope.instanceRegistry.has(b) {
+			continue
+		}
+		if err := injector.createSingleton(b); err != nil {
+			return decorateError(injector.errorDecorators, fmt.Errorf("failed to warm up singleton instance: %w", err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package goinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmUp(t *testing.T) {
+	var created []string
+	injector, err := NewInjector(
+		WarmUp(Type[*Parent]()),
+		Provide(func(_ *Child) *Parent {
+			created = append(created, "parent")
+			return &Parent{}
+		}),
+		Provide(func() *Child {
+			created = append(created, "child")
+			return &Child{}
+		}),
+		Provide(func() *AppConfig {
+			created = append(created, "config")
+			return &AppConfig{}
+		}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"child", "parent"}, created)
+
+	for i := 0; i < 2; i++ {
+		err = injector.Invoke(context.Background(), func(_ *AppConfig, _ *Parent) {})
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, []string{"child", "parent", "config"}, created)
+
+	_, err = NewInjector(WarmUp(Type[*Parent]()))
+	assert.ErrorContains(t, err, "warm-up target *goinject.Parent has no binding")
+}
+
+func TestInjectorWarmUp(t *testing.T) {
+	var created []string
+	injector, err := NewInjector(
+		WarmUp(Type[*Parent]()),
+		Provide(func() *Parent { return &Parent{} }),
+		Provide(func() *AppConfig {
+			created = append(created, "config")
+			return &AppConfig{}
+		}),
+		Provide(func() (*Child, error) { return nil, errors.New("child failure") }, NonCritical()),
+		Provide(func() *Session { return &Session{} }, In(PerLookUp)),
+	)
+	assert.Nil(t, err)
+	assert.Empty(t, created)
+
+	ctx := context.Background()
+	assert.Nil(t, injector.WarmUp(ctx, KeyOf[*AppConfig](), KeyOf[*Child]()))
+	assert.Nil(t, injector.WarmUp(ctx, KeyOf[*AppConfig]()))
+	assert.Equal(t, []string{"config"}, created)
+	err = injector.Invoke(ctx, func(_ *Child) {})
+	assert.ErrorContains(t, err, "child failure")
+
+	assert.ErrorContains(t, injector.WarmUp(ctx, KeyOf[*Session]()), "is not a singleton binding")
+	assert.ErrorContains(t, injector.WarmUp(ctx, KeyOf[*Request]()), "has no binding")
+}
@@ -0,0 +1,115 @@
+package goinject
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// WarningKind is the kind of condition reported by a WarningEvent
+type WarningKind string
+
+const (
+	// WarningShadowedBinding report a binding that is never injected because another binding with the same key
+	// takes precedence (a regular binding over a fallback, or a later binding with LastRegisteredWins)
+	WarningShadowedBinding WarningKind = "shadowed-binding"
+	// WarningFallbackUsed report a fallback binding (see NoOpFallback) injected because no regular binding is
+	// registered for its key
+	WarningFallbackUsed WarningKind = "fallback-used"
+	// WarningUnusedOptional report an optional inject tag option that has no effect, because the field has a
+	// default tag or an Optional type
+	WarningUnusedOptional WarningKind = "unused-optional"
+	// WarningDeprecatedBinding report an injection point of a binding annotated with Deprecated
+	WarningDeprecatedBinding WarningKind = "deprecated-binding"
+)
+
+// WarningEvent is notified to observers for wiring smells that do not prevent the injector from working. Each
+// warning is notified once, and all of them are returned by Injector.Warnings.
+type WarningEvent struct {
+	Kind     WarningKind
+	Key      BindingKey
+	Location string // source location of the binding, if any
+	Message  string
+}
+
+func (WarningEvent) isEvent() {}
+
+func (w WarningEvent) String() string {
+	if w.Location == "" {
+		return fmt.Sprintf("%s: %s: %s", w.Kind, w.Key, w.Message)
+	}
+	return fmt.Sprintf("%s: %s (%s): %s", w.Kind, w.Key, w.Location, w.Message)
+}
+
+// warnings record the warnings of an injector
+type warnings struct {
+	mu       sync.Mutex
+	warnings []WarningEvent
+	seen     map[WarningEvent]bool
+}
+
+// Warnings return the warnings reported by the injector so far, in order
+func (injector *Injector) Warnings() []WarningEvent {
+	injector.warnings.mu.Lock()
+	defer injector.warnings.mu.Unlock()
+	return append([]WarningEvent(nil), injector.warnings.warnings...)
+}
+
+// warn record the warning and notify it to observers, unless it was already reported
+func (injector *Injector) warn(warning WarningEvent) {
+	injector.warnings.mu.Lock()
+	if injector.warnings.seen[warning] {
+		injector.warnings.mu.Unlock()
+		return
+	}
+	if injector.warnings.seen == nil {
+		injector.warnings.seen = make(map[WarningEvent]bool)
+	}
+	injector.warnings.seen[warning] = true
+	injector.warnings.warnings = append(injector.warnings.warnings, warning)
+	injector.warnings.mu.Unlock()
+	injector.notify(warning)
+}
+
+// warnBindings report the shadowed bindings (those of all missing in kept) and the fallback bindings in use
+func (injector *Injector) warnBindings(all, kept []*binding) {
+	isKept := make(map[*binding]bool, len(kept))
+	for _, b := range kept {
+		isKept[b] = true
+	}
+	for _, b := range all {
+		if b.conversionFrom != nil { // conversions are only used when needed
+			continue
+		} else if !isKept[b] {
+			injector.warn(WarningEvent{
+				Kind:     WarningShadowedBinding,
+				Key:      b.key(),
+				Location: b.location,
+				Message:  "binding is shadowed by another binding with the same key",
+			})
+		} else if b.fallback {
+			injector.warn(WarningEvent{
+				Kind:     WarningFallbackUsed,
+				Key:      b.key(),
+				Location: b.location,
+				Message:  "no binding registered, the fallback is used",
+			})
+		}
+	}
+}
+
+// warnUnusedOptional report the optional option of the inject tag of a field that has a default or an Optional type
+func (injector *Injector) warnUnusedOptional(field reflect.StructField, annotation string, hasDefault bool) {
+	reason := "the default tag applies"
+	if !hasDefault {
+		if !isOptionalType(field.Type) {
+			return
+		}
+		reason = "Optional fields are always optional"
+	}
+	injector.warn(WarningEvent{
+		Kind:    WarningUnusedOptional,
+		Key:     BindingKey{Type: field.Type, Annotation: annotation},
+		Message: fmt.Sprintf("optional option of field %s has no effect, %s", field.Name, reason),
+	})
+}
@@ -0,0 +1,69 @@
+package goinject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarnings(t *testing.T) {
+	t.Run("shadowed and fallback bindings", func(t *testing.T) {
+		var events []Event
+		injector, err := NewInjector(
+			WithObserver(func(event Event) {
+				events = append(events, event)
+			}),
+			OverridePolicy(LastRegisteredWins),
+			Provide(func() *Parent { return &Parent{} }),
+			Module("overrides", Provide(func() *Parent { return &Parent{} })),
+			NoOpFallback[*Child](&Child{}),
+			NoOpFallback[*AppConfig](&AppConfig{}),
+			Provide(func() *AppConfig { return &AppConfig{} }),
+		)
+		assert.Nil(t, err)
+
+		warnings := injector.Warnings()
+		assert.Len(t, warnings, 3)
+		kinds := make(map[WarningKind][]BindingKey)
+		for _, warning := range warnings {
+			kinds[warning.Kind] = append(kinds[warning.Kind], warning.Key)
+			assert.Contains(t, warning.Location, "warning_test.go")
+		}
+		assert.ElementsMatch(t, []BindingKey{KeyOf[*Parent](), KeyOf[*AppConfig]()}, kinds[WarningShadowedBinding])
+		assert.Equal(t, []BindingKey{KeyOf[*Child]()}, kinds[WarningFallbackUsed])
+		var notified []WarningEvent
+		for _, event := range events {
+			if warning, ok := event.(WarningEvent); ok {
+				notified = append(notified, warning)
+			}
+		}
+		assert.Equal(t, warnings, notified)
+	})
+
+	t.Run("unused optional", func(t *testing.T) {
+		type params struct {
+			Params
+			Port   int               `inject:"http.port,optional" default:"8080"`
+			Config Optional[*Parent] `inject:",optional"`
+			Child  *Child            `inject:",optional"`
+		}
+		injector, err := NewInjector()
+		assert.Nil(t, err)
+
+		for i := 0; i < 2; i++ {
+			err = injector.Invoke(context.Background(), func(_ params) {})
+			assert.Nil(t, err)
+		}
+		warnings := injector.Warnings()
+		assert.Len(t, warnings, 2)
+		assert.Equal(t, WarningEvent{
+			Kind:    WarningUnusedOptional,
+			Key:     KeyOf[int]("http.port"),
+			Message: "optional option of field Port has no effect, the default tag applies",
+		}, warnings[0])
+		assert.Equal(t, KeyOf[Optional[*Parent]](), warnings[1].Key)
+		assert.Equal(t, "unused-optional: int(\"http.port\"): optional option of field Port has no effect, "+
+			"the default tag applies", warnings[0].String())
+	})
+}
@@ -0,0 +1,185 @@
+package goinject
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog hold the constructors and types a wiring file may reference by key, see LoadWiring
+type Catalog struct {
+	constructors map[string]any
+	types        map[string]AsType
+}
+
+// NewCatalog return an empty Catalog
+func NewCatalog() *Catalog {
+	return &Catalog{
+		constructors: make(map[string]any),
+		types:        make(map[string]AsType),
+	}
+}
+
+// Constructor register a constructor (as accepted by Provide) under key, it return the catalog for chaining
+func (c *Catalog) Constructor(key string, constructor any) *Catalog {
+	c.constructors[key] = constructor
+	return c
+}
+
+// Type register a type that bindings of the wiring file can be bound to (see As) under alias, it return the
+// catalog for chaining
+func (c *Catalog) Type(alias string, t AsType) *Catalog {
+	c.types[alias] = t
+	return c
+}
+
+// wiringFile is the format of the files loaded by LoadWiring
+type wiringFile struct {
+	Bindings []wiringBinding `json:"bindings" yaml:"bindings"`
+}
+
+type wiringBinding struct {
+	Constructor string           `json:"constructor" yaml:"constructor"`   // key of the constructor in the catalog
+	As          string           `json:"as,omitempty" yaml:"as,omitempty"` // alias of the type in the catalog
+	Name        string           `json:"name,omitempty" yaml:"name,omitempty"`
+	Group       string           `json:"group,omitempty" yaml:"group,omitempty"`
+	Scope       string           `json:"scope,omitempty" yaml:"scope,omitempty"` // registered scope name
+	When        *wiringCondition `json:"when,omitempty" yaml:"when,omitempty"`
+}
+
+type wiringCondition struct {
+	Env      *wiringEnvCondition `json:"env,omitempty" yaml:"env,omitempty"`
+	Variants []string            `json:"variants,omitempty" yaml:"variants,omitempty"`
+}
+
+type wiringEnvCondition struct {
+	Name           string `json:"name" yaml:"name"`
+	Value          string `json:"value" yaml:"value"`
+	MatchIfMissing bool   `json:"matchIfMissing,omitempty" yaml:"matchIfMissing,omitempty"`
+}
+
+// wiringDecoders are the decoders of wiring files by extension, they reject unknown keys so that a typo in a file
+// edited by operators does not silently drop a setting
+var wiringDecoders = map[string]func(data []byte, v any) error{
+	".json": decodeStrictJSON,
+	".yaml": decodeStrictYAML,
+	".yml":  decodeStrictYAML,
+}
+
+func decodeStrictJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+func decodeStrictYAML(data []byte, v any) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) { // an empty file is an empty document
+		return err
+	}
+	return nil
+}
+
+type wiringOption struct {
+	path     string
+	catalog  *Catalog
+	location string
+}
+
+func (o *wiringOption) apply(mod *configuration) error {
+	if o.catalog == nil {
+		return newInjectorConfigurationError(fmt.Sprintf("catalog of wiring file %s cannot be nil", o.path), nil)
+	}
+	decode, ok := wiringDecoders[filepath.Ext(o.path)]
+	if !ok {
+		return newInjectorConfigurationError(
+			fmt.Sprintf("unsupported wiring file %s, expected a .json, .yaml or .yml file", o.path), nil)
+	}
+	data, err := os.ReadFile(o.path)
+	if err != nil {
+		return newInjectorConfigurationError(fmt.Sprintf("failed to read wiring file %s", o.path), err)
+	}
+	var file wiringFile
+	if err = decode(data, &file); err != nil {
+		return newInjectorConfigurationError(fmt.Sprintf("failed to parse wiring file %s", o.path), err)
+	}
+	for i, b := range file.Bindings {
+		option, err := o.option(b)
+		if err != nil {
+			return newInjectorConfigurationError(
+				fmt.Sprintf("invalid binding #%d of wiring file %s", i+1, o.path), err)
+		}
+		if err = option.apply(mod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// option convert a binding of the wiring file to a Provide option, wrapped in When options if it has conditions
+func (o *wiringOption) option(b wiringBinding) (Option, error) {
+	constructor, ok := o.catalog.constructors[b.Constructor]
+	if !ok {
+		return nil, fmt.Errorf("unknown constructor %q", b.Constructor)
+	}
+	var annotations []Annotation
+	if b.As != "" {
+		t, ok := o.catalog.types[b.As]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q", b.As)
+		}
+		annotations = append(annotations, As(t))
+	}
+	if b.Name != "" && b.Group != "" {
+		return nil, fmt.Errorf("name and group cannot be both set")
+	} else if b.Name != "" {
+		annotations = append(annotations, Named(b.Name))
+	} else if b.Group != "" {
+		annotations = append(annotations, IntoGroup(b.Group))
+	}
+	if b.Scope != "" {
+		annotations = append(annotations, In(b.Scope))
+	}
+	var option Option = &provideOption{
+		constructor: constructor,
+		annotations: annotations,
+		location:    o.location,
+	}
+	if b.When != nil && b.When.Env != nil {
+		env := b.When.Env
+		option = When(OnEnvironmentVariable(env.Name, env.Value, env.MatchIfMissing), option)
+	}
+	if b.When != nil && len(b.When.Variants) > 0 {
+		option = When(OnVariant(b.When.Variants...), option)
+	}
+	return option, nil
+}
+
+// LoadWiring return an Option reading a declarative wiring file (JSON or YAML, depending on the file extension) and
+// providing its bindings, so that operators can tune the composition of pre-compiled providers. Bindings reference
+// the constructors and types registered in catalog by key:
+//
+//	bindings:
+//	  - constructor: postgres   # key of Catalog.Constructor
+//	    as: Store               # alias of Catalog.Type
+//	    name: primary           # or group: stores
+//	    scope: inject.Singleton
+//	    when:                   # all conditions must match
+//	      env: {name: STORE, value: postgres, matchIfMissing: true}
+//	      variants: [cloud]
+//
+// Unknown keys in the file fail NewInjector.
+func LoadWiring(path string, catalog *Catalog) Option {
+	return &wiringOption{
+		path:     path,
+		catalog:  catalog,
+		location: callerLocation(),
+	}
+}
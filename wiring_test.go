@@ -0,0 +1,92 @@
+package goinject
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Store interface {
+	Kind() string
+}
+
+type postgresStore struct{}
+
+func (s *postgresStore) Kind() string { return "postgres" }
+
+type memoryStore struct{}
+
+func (s *memoryStore) Kind() string { return "memory" }
+
+func TestLoadWiring(t *testing.T) {
+	catalog := NewCatalog().
+		Constructor("postgres", func() *postgresStore { return &postgresStore{} }).
+		Constructor("memory", func() *memoryStore { return &memoryStore{} }).
+		Type("Store", Type[Store]())
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "wiring.yaml")
+	assert.Nil(t, os.WriteFile(yamlPath, []byte(`bindings:
+  - constructor: postgres
+    as: Store
+    name: primary
+    when:
+      variants: [cloud]
+  - constructor: memory
+    as: Store
+    name: cache
+    scope: inject.PerLookUp
+`), 0o600))
+
+	injector, err := NewInjector(WithVariants("cloud"), LoadWiring(yamlPath, catalog))
+	assert.Nil(t, err)
+	err = injector.Invoke(context.Background(), func(p struct {
+		Params
+		Primary Store `inject:"primary"`
+		Cache   Store `inject:"cache"`
+	}) {
+		assert.Equal(t, "postgres", p.Primary.Kind())
+		assert.Equal(t, "memory", p.Cache.Kind())
+	})
+	assert.Nil(t, err)
+
+	injector, err = NewInjector(LoadWiring(yamlPath, catalog))
+	assert.Nil(t, err)
+	err = injector.Invoke(context.Background(), func(_ struct {
+		Params
+		Primary Store `inject:"primary"`
+	}) {
+	})
+	assert.ErrorContains(t, err, "did not found binding")
+
+	t.Run("Unknown constructors should fail", func(t *testing.T) {
+		jsonPath := filepath.Join(dir, "wiring.json")
+		assert.Nil(t, os.WriteFile(jsonPath, []byte(`{"bindings": [{"constructor": "mysql"}]}`), 0o600))
+		_, err := NewInjector(LoadWiring(jsonPath, catalog))
+		assert.ErrorContains(t, err, "invalid binding #1 of wiring file")
+		assert.ErrorContains(t, err, "unknown constructor \"mysql\"")
+	})
+	t.Run("Unknown keys should fail", func(t *testing.T) {
+		typoPath := filepath.Join(dir, "typo.yaml")
+		assert.Nil(t, os.WriteFile(typoPath, []byte(`bindings:
+  - constructor: postgres
+    as: Store
+    nmae: primary
+`), 0o600))
+		_, err := NewInjector(LoadWiring(typoPath, catalog))
+		assert.ErrorContains(t, err, "failed to parse wiring file")
+		assert.ErrorContains(t, err, "field nmae not found")
+
+		jsonPath := filepath.Join(dir, "typo.json")
+		assert.Nil(t, os.WriteFile(jsonPath, []byte(`{"bindings": [{"constructor": "postgres", "nmae": "primary"}]}`), 0o600))
+		_, err = NewInjector(LoadWiring(jsonPath, catalog))
+		assert.ErrorContains(t, err, "unknown field \"nmae\"")
+	})
+
+	t.Run("Nil catalog should fail", func(t *testing.T) {
+		_, err := NewInjector(LoadWiring(yamlPath, nil))
+		assert.ErrorContains(t, err, "catalog of wiring file "+yamlPath+" cannot be nil")
+	})
+}
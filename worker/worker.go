@@ -0,0 +1,168 @@
+// Package worker runs long-lived workers registered as goinject bindings.
+//
+// Workers are bindings implementing Worker added to the Group group:
+//
+//	goinject.Provide(NewIndexer, goinject.As(goinject.Type[worker.Worker]()), goinject.IntoGroup(worker.Group))
+//
+// The Pool provided by Module start each worker in its own goroutine, restart it according to the RestartPolicy
+// and stop workers in reverse order when the injector is shut down. Workers are in registration order only if the
+// injector is created with goinject.Deterministic(), the order of the group is unspecified otherwise.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/illuin-tech/goinject"
+)
+
+// Group is the group workers must be added to using goinject.IntoGroup
+const Group = "workers"
+
+// Worker is a long-lived task, Run should return when ctx is canceled
+type Worker interface {
+	Run(ctx context.Context) error
+}
+
+// RestartMode tells when a worker is restarted after Run returned
+type RestartMode int
+
+const (
+	// RestartNever never restart workers
+	RestartNever RestartMode = iota
+	// RestartOnFailure restart workers whose Run returned an error
+	RestartOnFailure
+	// RestartAlways restart workers whatever Run returned
+	RestartAlways
+)
+
+// RestartPolicy configure how workers are restarted
+type RestartPolicy struct {
+	Mode RestartMode
+	// MaxRestarts is the maximum number of restarts of a worker, 0 means unlimited
+	MaxRestarts int
+	// Backoff is the delay before restarting a worker
+	Backoff time.Duration
+}
+
+// ErrAlreadyStarted is returned when starting a Pool twice
+var ErrAlreadyStarted = errors.New("worker pool already started")
+
+// Pool supervise the workers of the injector
+type Pool struct {
+	workers []Worker
+	policy  RestartPolicy
+
+	mu      sync.Mutex
+	started bool
+	running []*runningWorker
+}
+
+type runningWorker struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error // last error returned by Run, read after done is closed
+}
+
+type poolParams struct {
+	goinject.Params
+	Workers []Worker `inject:"workers,optional"`
+}
+
+// Module return a goinject module providing a *Pool running the workers of the Group group
+func Module(policy RestartPolicy) goinject.Option {
+	return goinject.Module("worker",
+		goinject.Provide(func(params poolParams) *Pool {
+			return NewPool(policy, params.Workers...)
+		}, goinject.In(goinject.Singleton), goinject.WithDestroy(func(p *Pool) {
+			_ = p.Stop()
+		})),
+	)
+}
+
+// NewPool create a Pool for the given workers
+func NewPool(policy RestartPolicy, workers ...Worker) *Pool {
+	return &Pool{
+		workers: workers,
+		policy:  policy,
+	}
+}
+
+// Start run every worker in its own goroutine, workers are stopped when ctx is canceled or when Stop is called
+func (p *Pool) Start(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.started {
+		return ErrAlreadyStarted
+	}
+	p.started = true
+	for _, w := range p.workers {
+		workerCtx, cancel := context.WithCancel(ctx)
+		rw := &runningWorker{cancel: cancel, done: make(chan struct{})}
+		p.running = append(p.running, rw)
+		go p.supervise(workerCtx, w, rw)
+	}
+	return nil
+}
+
+func (p *Pool) supervise(ctx context.Context, w Worker, rw *runningWorker) {
+	defer close(rw.done)
+	for restarts := 0; ; restarts++ {
+		rw.err = runWorker(ctx, w)
+		if ctx.Err() != nil || !p.shouldRestart(rw.err, restarts) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.policy.Backoff):
+		}
+	}
+}
+
+// runWorker run w, converting panics to errors so that a panicking worker is supervised like a failing one
+func runWorker(ctx context.Context, w Worker) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("worker panicked: %v", r)
+		}
+	}()
+	return w.Run(ctx)
+}
+
+func (p *Pool) shouldRestart(err error, restarts int) bool {
+	if p.policy.MaxRestarts > 0 && restarts >= p.policy.MaxRestarts {
+		return false
+	}
+	switch p.policy.Mode {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// Stop cancel workers in the reverse order of NewPool (of the group, see the package documentation for Module),
+// waiting for each one to return before stopping the previous one. It returns the errors returned by the last run of
+// each worker, context cancellation errors excepted.
+func (p *Pool) Stop() error {
+	p.mu.Lock()
+	running := p.running
+	p.running = nil
+	p.mu.Unlock()
+
+	var errs []error
+	for i := len(running) - 1; i >= 0; i-- {
+		running[i].cancel()
+		<-running[i].done
+		if err := running[i].err; err != nil && !errors.Is(err, context.Canceled) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
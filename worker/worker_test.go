@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/illuin-tech/goinject"
+)
+
+type recordingWorker struct {
+	name    string
+	mu      *sync.Mutex
+	stopped *[]string
+	started chan struct{}
+}
+
+func (w *recordingWorker) Run(ctx context.Context) error {
+	close(w.started)
+	<-ctx.Done()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	*w.stopped = append(*w.stopped, w.name)
+	return ctx.Err()
+}
+
+type flakyWorker struct {
+	runs atomic.Int32
+}
+
+func (w *flakyWorker) Run(_ context.Context) error {
+	w.runs.Add(1)
+	return errors.New("flaky")
+}
+
+func TestPool(t *testing.T) {
+	t.Run("Should run workers and stop them in reverse order on shutdown", func(t *testing.T) {
+		var mu sync.Mutex
+		var stopped []string
+		first := &recordingWorker{name: "first", mu: &mu, stopped: &stopped, started: make(chan struct{})}
+		second := &recordingWorker{name: "second", mu: &mu, stopped: &stopped, started: make(chan struct{})}
+		injector, err := goinject.NewInjector(
+			goinject.Deterministic(),
+			Module(RestartPolicy{}),
+			goinject.Provide(func() *recordingWorker { return first },
+				goinject.As(goinject.Type[Worker]()), goinject.IntoGroup(Group)),
+			goinject.Provide(func() *recordingWorker { return second },
+				goinject.As(goinject.Type[Worker]()), goinject.IntoGroup(Group)),
+		)
+		assert.Nil(t, err)
+		err = injector.Invoke(context.Background(), func(p *Pool) error {
+			return p.Start(context.Background())
+		})
+		assert.Nil(t, err)
+		<-first.started
+		<-second.started
+
+		injector.Shutdown()
+		assert.Equal(t, []string{"second", "first"}, stopped)
+	})
+
+	t.Run("Should restart failing workers according to policy", func(t *testing.T) {
+		w := &flakyWorker{}
+		p := NewPool(RestartPolicy{Mode: RestartOnFailure, MaxRestarts: 3, Backoff: time.Millisecond}, w)
+		assert.Nil(t, p.Start(context.Background()))
+		assert.ErrorIs(t, p.Start(context.Background()), ErrAlreadyStarted)
+		assert.Eventually(t, func() bool { return w.runs.Load() == 4 }, time.Second, time.Millisecond)
+		assert.ErrorContains(t, p.Stop(), "flaky")
+	})
+}